@@ -0,0 +1,33 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import "github.com/spf13/cobra"
+
+var AuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Inspect the executor calls",
+	Long:  `Inspect the executor calls recorded by the server, to know what Ava did in the cluster and why.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			_ = cmd.Help()
+			return
+		}
+	},
+}
+
+func init() {
+	AuditCmd.AddCommand(listCmd)
+}
@@ -0,0 +1,108 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	db "github.com/matthisholleville/ava/internal/prisma"
+	"github.com/matthisholleville/ava/pkg/audit"
+	"github.com/matthisholleville/ava/pkg/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	threadID string
+	executor string
+	since    string
+	until    string
+	limit    int
+	output   string
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the executor calls",
+	Long:  `List the executor calls, the most recent first, as a table or as JSON lines.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := viper.Get("logger").(logger.ILogger)
+
+		now := time.Now()
+		filter := audit.Filter{ThreadID: threadID, Executor: executor, Limit: limit}
+		var err error
+		if filter.Since, err = audit.ParseTime(since, now); err != nil {
+			logger.Fatal(err.Error())
+		}
+		if filter.Until, err = audit.ParseTime(until, now); err != nil {
+			logger.Fatal(err.Error())
+		}
+
+		client := db.NewClient()
+		if err := client.Prisma.Connect(); err != nil {
+			logger.Fatal(err.Error())
+		}
+		defer func() {
+			_ = client.Prisma.Disconnect()
+		}()
+
+		entries, err := audit.NewRecorder(client, logger).List(context.Background(), filter)
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+
+		switch output {
+		case "jsonl":
+			err = audit.WriteJSONLines(os.Stdout, entries)
+		case "table":
+			err = writeTable(entries)
+		default:
+			err = fmt.Errorf("output must be table or jsonl, got %q", output)
+		}
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+	},
+}
+
+func writeTable(entries []audit.Entry) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tTHREAD\tEXECUTOR\tARGUMENTS\tDURATION\tAPPROVER\tERROR")
+	for _, entry := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%dms\t%s\t%s\n",
+			entry.CreatedAt.Format(time.RFC3339),
+			entry.ThreadID,
+			entry.Executor,
+			entry.Arguments,
+			entry.DurationMs,
+			entry.Approver,
+			entry.Error,
+		)
+	}
+	return w.Flush()
+}
+
+func init() {
+	listCmd.Flags().StringVarP(&threadID, "thread", "t", "", "Only the calls of this thread")
+	listCmd.Flags().StringVarP(&executor, "executor", "e", "", "Only the calls of this executor")
+	listCmd.Flags().StringVar(&since, "since", "24h", "RFC3339 time or duration before now, e.g. 24h")
+	listCmd.Flags().StringVar(&until, "until", "", "RFC3339 time or duration before now")
+	listCmd.Flags().IntVarP(&limit, "limit", "l", 100, "Maximum number of calls, 1000 at most")
+	listCmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table or jsonl")
+}
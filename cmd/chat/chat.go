@@ -15,10 +15,29 @@
 package chat
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"os/user"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/matthisholleville/ava/internal/configuration"
+	db "github.com/matthisholleville/ava/internal/prisma"
+	"github.com/matthisholleville/ava/pkg/ai/types"
+	"github.com/matthisholleville/ava/pkg/approval"
+	"github.com/matthisholleville/ava/pkg/audit"
 	"github.com/matthisholleville/ava/pkg/chat"
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/matthisholleville/ava/pkg/executors"
+	"github.com/matthisholleville/ava/pkg/executors/limits"
+	"github.com/matthisholleville/ava/pkg/executors/plugin"
+	"github.com/matthisholleville/ava/pkg/executors/policy"
 	"github.com/matthisholleville/ava/pkg/logger"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -31,6 +50,8 @@ var (
 	kubeconfig  string
 	message     string
 	thread      string
+	timeout     time.Duration
+	dryRun      bool
 )
 
 var ChatCmd = &cobra.Command{
@@ -40,15 +61,76 @@ var ChatCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		logger := viper.Get("logger").(logger.ILogger)
 		configuration := configuration.LoadConfiguration(logger)
+		if err := configuration.AI.Validate(); err != nil {
+			logger.Fatal(err.Error())
+		}
+		if err := configuration.Executors.Approval.Validate(); err != nil {
+			logger.Fatal(err.Error())
+		}
+		if _, err := policy.New(configuration.Executors.Policy); err != nil {
+			logger.Fatal(err.Error())
+		}
+
+		if err := limits.Validate(configuration.Executors.Limits); err != nil {
+			logger.Fatal(err.Error())
+		}
+
+		if err := executors.ValidateCustom(configuration.Executors); err != nil {
+			logger.Fatal(err.Error())
+		}
+
+		if err := plugin.Validate(configuration.Executors.Plugins); err != nil {
+			logger.Fatal(err.Error())
+		}
 
 		logger.Info("Chatting with Ava")
 
+		if backend == "" {
+			backend = configuration.AI.Backend()
+		}
+
+		if timeout > 0 {
+			configuration.AI.RunTimeout = timeout
+		}
+
+		// Ctrl+C cancels the analysis and the remote run.
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		plugins, err := plugin.Start(configuration.Executors.Plugins, logger)
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+		plugin.Configure(plugins)
+		defer plugins.Close()
+
+		// The kill switch and the limits are the ones of the server when
+		// the database is configured, and the executor calls are audited.
+		// The chats are persisted too, so that the backends keeping the
+		// threads in memory can continue them.
+		var auditor common.Auditor
+		client := database(logger)
+		if client != nil {
+			defer func() { _ = client.Prisma.Disconnect() }()
+			limits.Configure(limits.NewDBStore(client))
+			auditor = audit.NewRecorder(client, logger)
+		}
+
+		approvals := approval.NewManager(configuration.Executors.Approval, logger, nil)
+		requester := currentUser()
+
 		chat, err := chat.NewChat(
 			backend,
-			configuration.AI.OpenAI.APIKey,
+			configuration.AI,
 			logger,
 			chat.WithLanguage(language),
+			chat.WithContext(ctx),
 			chat.WithConfigureAssistant(logger, configuration.Executors.Enabled),
+			chat.WithApproval(approvals, requester, promptNotifier(logger, approvals, requester)),
+			chat.WithDryRun(dryRun || configuration.Executors.DryRun),
+			chat.WithAudit(auditor),
+			chat.WithDbClient(client),
+			chat.WithPersist(client != nil),
 		)
 		if err != nil {
 			logger.Fatal(err.Error())
@@ -59,24 +141,127 @@ var ChatCmd = &cobra.Command{
 			if err != nil {
 				logger.Fatal(err.Error())
 			}
+		} else if !chat.KeepsHistory() {
+			logger.Warn(fmt.Sprintf("The %s backend does not keep the thread %s without DATABASE_URL, the conversation starts over", backend, thread))
+		} else if chat.Persist {
+			// The thread may have been created before DATABASE_URL was set.
+			if _, err := chat.FindThreadUnique(thread); errors.Is(err, db.ErrNotFound) {
+				_, err = chat.PersistThread(thread)
+				if err != nil {
+					logger.Fatal(err.Error())
+				}
+			}
 		}
 
-		response, err := chat.Chat(message, thread)
+		events := make(chan types.Event)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			printTrace(logger, events)
+		}()
+
+		response, err := chat.ChatStream(message, thread, nil, events)
+		<-done
 		if err != nil {
 			logger.Fatal(err.Error())
 		}
 
-		logger.Info(response)
-		logger.Info(fmt.Sprintf("If you want to continue the conversation, use the --thread flag with the following value: %s", thread))
+		if chat.Persist {
+			if _, err := chat.PersistChat(message, response, thread); err != nil {
+				logger.Error(fmt.Sprintf("Unable to persist the chat: %s", err.Error()))
+			}
+		}
+
+		if chat.KeepsHistory() {
+			logger.Info(fmt.Sprintf("If you want to continue the conversation, use the --thread flag with the following value: %s", thread))
+		}
 
 	},
 }
 
+// printTrace prints the executors called by Ava and the answer as they come.
+func printTrace(logger logger.ILogger, events <-chan types.Event) {
+	for event := range events {
+		switch event.Type {
+		case types.EventToolCall:
+			logger.Info(fmt.Sprintf("Calling %s %s", event.Tool, event.Arguments))
+		case types.EventToolOutput:
+			logger.Info(fmt.Sprintf("%s returned: %s", event.Tool, event.Output))
+		case types.EventTextDelta:
+			fmt.Print(event.Text)
+		case types.EventCompleted:
+			fmt.Println()
+		}
+	}
+}
+
+// promptNotifier asks on the terminal to approve the actions. A single
+// goroutine reads the terminal, an answer goes to the last action asked.
+func promptNotifier(logger logger.ILogger, approvals *approval.Manager, requester string) approval.Notifier {
+	var (
+		mu      sync.Mutex
+		current string
+		once    sync.Once
+	)
+	read := func() {
+		reader := bufio.NewReader(os.Stdin)
+		for {
+			answer, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			id := current
+			current = ""
+			mu.Unlock()
+			if id == "" {
+				continue
+			}
+			answer = strings.ToLower(strings.TrimSpace(answer))
+			approved := answer == "y" || answer == "yes"
+			if err := approvals.Resolve(id, approved, requester, ""); err != nil {
+				logger.Warn(fmt.Sprintf("Action %s: %s", id, err.Error()))
+			}
+		}
+	}
+
+	return approval.NotifierFunc(func(action approval.Action) error {
+		once.Do(func() { go read() })
+		mu.Lock()
+		current = action.ID
+		mu.Unlock()
+		fmt.Printf("\nAva wants to run %s %s. Approve within %s? [y/N] ", action.Executor, action.Arguments, approvals.Timeout)
+		return nil
+	})
+}
+
+// database connects to the database of the server, if DATABASE_URL is set.
+func database(logger logger.ILogger) *db.PrismaClient {
+	if os.Getenv("DATABASE_URL") == "" {
+		return nil
+	}
+	client := db.NewClient()
+	if err := client.Prisma.Connect(); err != nil {
+		logger.Fatal(err.Error())
+	}
+	return client
+}
+
+func currentUser() string {
+	u, err := user.Current()
+	if err != nil {
+		return "cli"
+	}
+	return u.Username
+}
+
 func init() {
 	ChatCmd.Flags().StringVarP(&message, "message", "m", "", "Message to send")
 	ChatCmd.Flags().StringVarP(&language, "language", "g", "en", "Language to use")
-	ChatCmd.Flags().StringVarP(&backend, "backend", "b", "openai", "Backend AI provider")
+	ChatCmd.Flags().StringVarP(&backend, "backend", "b", "", "Backend AI provider (openai, completion, ollama, anthropic, scripted). Defaults to ai.type from the configuration, then openai")
 	ChatCmd.Flags().StringVar(&kubecontext, "kubecontext", "", "Kubernetes context to use. Only required if out-of-cluster.")
 	ChatCmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
+	ChatCmd.Flags().DurationVar(&timeout, "timeout", 0, "Maximum duration of the analysis. Defaults to ai.runTimeout from the configuration")
+	ChatCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Simulate the write executors and list the planned actions")
 	ChatCmd.Flags().StringVar(&thread, "thread", "", "Thread ID to use. Only required if you want to continue a conversation.")
 }
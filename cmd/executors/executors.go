@@ -0,0 +1,40 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executors
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var by string
+
+var ExecutorsCmd = &cobra.Command{
+	Use:   "executors",
+	Short: "Manage Ava's executors",
+	Long:  `Manage Ava's executors, e.g. disable the write executors of every replica.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			_ = cmd.Help()
+			return
+		}
+	},
+}
+
+func init() {
+	ExecutorsCmd.AddCommand(disableCmd)
+	ExecutorsCmd.AddCommand(enableCmd)
+	ExecutorsCmd.AddCommand(statusCmd)
+	ExecutorsCmd.PersistentFlags().StringVar(&by, "by", "", "Name of the operator, defaults to the current user")
+}
@@ -0,0 +1,101 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executors
+
+import (
+	"context"
+	"fmt"
+	"os/user"
+
+	db "github.com/matthisholleville/ava/internal/prisma"
+	"github.com/matthisholleville/ava/pkg/executors/limits"
+	"github.com/matthisholleville/ava/pkg/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var disableCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Disable the write executors",
+	Long:  `Disable the write executors of every replica sharing the database, the kill switch.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		setWriteDisabled(true)
+	},
+}
+
+var enableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Enable the write executors",
+	Long:  `Enable the write executors again.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		setWriteDisabled(false)
+	},
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show if the write executors are disabled",
+	Long:  `Show if the kill switch of the write executors is on.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := viper.Get("logger").(logger.ILogger)
+		store, disconnect := connect(logger)
+		defer disconnect()
+
+		disabled, err := store.WriteDisabled(context.Background())
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+		if disabled {
+			fmt.Println("Write executors are disabled")
+			return
+		}
+		fmt.Println("Write executors are enabled")
+	},
+}
+
+func setWriteDisabled(disabled bool) {
+	logger := viper.Get("logger").(logger.ILogger)
+	store, disconnect := connect(logger)
+	defer disconnect()
+
+	operator := by
+	if operator == "" {
+		operator = "cli"
+		if current, err := user.Current(); err == nil {
+			operator = current.Username
+		}
+	}
+
+	if err := store.SetWriteDisabled(context.Background(), disabled, operator); err != nil {
+		logger.Fatal(err.Error())
+	}
+
+	if disabled {
+		logger.Info("Write executors disabled")
+		return
+	}
+	logger.Info("Write executors enabled")
+}
+
+// connect opens the database shared with the server.
+func connect(logger logger.ILogger) (limits.Store, func()) {
+	client := db.NewClient()
+	if err := client.Prisma.Connect(); err != nil {
+		logger.Fatal(err.Error())
+	}
+	return limits.NewDBStore(client), func() {
+		_ = client.Prisma.Disconnect()
+	}
+}
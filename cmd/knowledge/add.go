@@ -48,7 +48,7 @@ var addCmd = &cobra.Command{
 		avaCfg := avaCfg.LoadConfiguration(logger)
 		logger.Info("Adding a new documents to Ava's knowledge base")
 		backendKnowledge, err := backendKnowledge.NewBackendKnowledge(backendKnowledge.KnowledgeConfiguration{
-			ActiveProvider: avaCfg.AI.Type,
+			ActiveProvider: avaCfg.AI.Backend(),
 			OpenAI: openai.Configuration{
 				APIKey: avaCfg.AI.OpenAI.APIKey,
 			},
@@ -32,7 +32,7 @@ var purgeCmd = &cobra.Command{
 		avaCfg := avaCfg.LoadConfiguration(logger)
 		logger.Info("Purging Ava's knowledge base")
 		knowledge, err := knowledge.NewBackendKnowledge(knowledge.KnowledgeConfiguration{
-			ActiveProvider: avaCfg.AI.Type,
+			ActiveProvider: avaCfg.AI.Backend(),
 			OpenAI: openai.Configuration{
 				APIKey: avaCfg.AI.OpenAI.APIKey,
 			},
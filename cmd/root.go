@@ -21,12 +21,15 @@ import (
 	"strings"
 
 	"github.com/adrg/xdg"
+	"github.com/matthisholleville/ava/cmd/audit"
 	"github.com/matthisholleville/ava/cmd/chat"
 	"github.com/matthisholleville/ava/cmd/config"
+	"github.com/matthisholleville/ava/cmd/executors"
 	"github.com/matthisholleville/ava/cmd/knowledge"
 	"github.com/matthisholleville/ava/cmd/serve"
 	"github.com/matthisholleville/ava/internal/configuration"
 	"github.com/matthisholleville/ava/pkg/logger"
+	"github.com/matthisholleville/ava/pkg/redact"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -56,6 +59,8 @@ func init() {
 	rootCmd.AddCommand(chat.ChatCmd)
 	rootCmd.AddCommand(serve.ServeCmd)
 	rootCmd.AddCommand(config.ConfigCmd)
+	rootCmd.AddCommand(executors.ExecutorsCmd)
+	rootCmd.AddCommand(audit.AuditCmd)
 	rootCmd.PersistentFlags().StringVarP(&LogFormat, "log-format", "f", "raw", "Log format")
 	rootCmd.PersistentFlags().StringVarP(&LogLevel, "log-level", "l", "debug", "Log level")
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", fmt.Sprintf("Default config file (%s/ava/ava.yaml)", xdg.ConfigHome))
@@ -90,6 +95,10 @@ func initConfig() {
 		}
 	}
 
+	if err := redact.Configure(viper.GetStringSlice("redaction.patterns")); err != nil {
+		panic(err.Error())
+	}
+
 	viper.Set("logger", logger)
 }
 
@@ -19,6 +19,7 @@ import (
 
 	avaCfg "github.com/matthisholleville/ava/internal/configuration"
 	"github.com/matthisholleville/ava/pkg/api"
+	"github.com/matthisholleville/ava/pkg/executors/plugin"
 	"github.com/matthisholleville/ava/pkg/logger"
 	"github.com/matthisholleville/ava/pkg/signals"
 	"github.com/spf13/cobra"
@@ -63,6 +64,7 @@ var ServeCmd = &cobra.Command{
 		stopCh := signals.SetupSignalHandler()
 		sd, _ := signals.NewShutdown(serverShutdownTimeout, logger)
 		sd.Graceful(stopCh, httpServer, healthy, ready)
+		plugin.Default().Close()
 	},
 }
 
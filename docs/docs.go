@@ -15,6 +15,331 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/actions/{id}/approve": {
+            "post": {
+                "description": "used to approve an executor waiting for an approval",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Action"
+                ],
+                "summary": "Approve an action",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "_",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/api.ResolveAction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/actions/{id}/reject": {
+            "post": {
+                "description": "used to reject an executor waiting for an approval, the reason is sent to Ava",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Action"
+                ],
+                "summary": "Reject an action",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "_",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/api.ResolveAction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/executors/write": {
+            "get": {
+                "description": "used to know if the kill switch of the write executors is on",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Status of the write executors",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.WriteExecutorsStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/executors/write/disable": {
+            "post": {
+                "description": "used to disable the write executors of every replica immediately, the kill switch",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Disable the write executors",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/executors/write/enable": {
+            "post": {
+                "description": "used to enable the write executors again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Enable the write executors",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/audit": {
+            "get": {
+                "description": "used to know what Ava did in the cluster, the most recent calls first. With format=jsonl, the calls are exported as JSON lines.",
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "List the executor calls",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Thread ID",
+                        "name": "threadId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Executor name",
+                        "name": "executor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 time or duration before now, e.g. 24h",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 time or duration before now",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of calls, 1000 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json or jsonl",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.AuditResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/chat": {
             "post": {
                 "description": "used to chat with Ava",
@@ -133,7 +458,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.FetchMessagesResponse"
+                            "$ref": "#/definitions/api.FetchChatResponse"
                         }
                     },
                     "400": {
@@ -142,6 +467,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -202,6 +533,35 @@ const docTemplate = `{
                 }
             }
         },
+        "/chat/{id}/stream": {
+            "get": {
+                "description": "Server-Sent Events describing the analysis in progress in the chat: runStarted, toolCall, toolOutput, textDelta, then completed or failed",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Chat"
+                ],
+                "summary": "Stream the progress of an analysis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Event"
+                        }
+                    }
+                }
+            }
+        },
         "/event/slack": {
             "post": {
                 "description": "used to chat with Ava when a slack event is received",
@@ -248,6 +608,44 @@ const docTemplate = `{
                 }
             }
         },
+        "/event/slack/interactions": {
+            "post": {
+                "description": "used to approve or reject an action with the buttons of the Slack approval message",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Event"
+                ],
+                "summary": "Receive a Slack interaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Interaction payload",
+                        "name": "payload",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/knowledge": {
             "post": {
                 "description": "used to add knowledge to Ava",
@@ -430,9 +828,25 @@ const docTemplate = `{
                 }
             }
         },
+        "api.AuditResponse": {
+            "type": "object",
+            "properties": {
+                "calls": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/audit.Entry"
+                    }
+                }
+            }
+        },
         "api.CreateNewChat": {
             "type": "object",
             "properties": {
+                "dryRun": {
+                    "description": "DryRun simulates the write executors, the answer lists the planned actions.",
+                    "type": "boolean",
+                    "example": false
+                },
                 "language": {
                     "type": "string",
                     "example": "en"
@@ -479,12 +893,46 @@ const docTemplate = `{
                 }
             }
         },
+        "api.FetchChatResponse": {
+            "type": "object",
+            "properties": {
+                "completionTokens": {
+                    "type": "integer"
+                },
+                "cost": {
+                    "description": "Cost is the estimated cost in USD.",
+                    "type": "number"
+                },
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.FetchMessagesResponse"
+                    }
+                },
+                "promptTokens": {
+                    "type": "integer"
+                }
+            }
+        },
         "api.FetchMessagesResponse": {
             "type": "object",
             "properties": {
                 "chat": {
                     "type": "string"
                 },
+                "completionTokens": {
+                    "type": "integer"
+                },
+                "cost": {
+                    "description": "Cost is the estimated cost in USD.",
+                    "type": "number"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "promptTokens": {
+                    "type": "integer"
+                },
                 "response": {
                     "type": "string"
                 }
@@ -493,6 +941,15 @@ const docTemplate = `{
         "api.PurgeKnowledge": {
             "type": "object"
         },
+        "api.ResolveAction": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "The pod is still serving traffic"
+                }
+            }
+        },
         "api.SuccessResponse": {
             "type": "object",
             "properties": {
@@ -548,6 +1005,62 @@ const docTemplate = `{
                 }
             }
         },
+        "api.WriteExecutorsStatus": {
+            "type": "object",
+            "properties": {
+                "disabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "audit.Entry": {
+            "type": "object",
+            "properties": {
+                "affected": {
+                    "description": "Affected are the objects changed by the call, as kind/namespace/name.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "approver": {
+                    "type": "string"
+                },
+                "arguments": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "durationMs": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "executor": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "output": {
+                    "type": "string"
+                },
+                "runId": {
+                    "type": "string"
+                },
+                "threadId": {
+                    "type": "string"
+                },
+                "toolCallId": {
+                    "type": "string"
+                }
+            }
+        },
         "slack.ReceiveSlackEvent": {
             "type": "object",
             "properties": {
@@ -738,6 +1251,64 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "types.Event": {
+            "type": "object",
+            "properties": {
+                "actionID": {
+                    "type": "string"
+                },
+                "arguments": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "output": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "threadID": {
+                    "type": "string"
+                },
+                "tool": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/types.EventType"
+                }
+            }
+        },
+        "types.EventType": {
+            "type": "string",
+            "enum": [
+                "runStarted",
+                "toolCall",
+                "toolOutput",
+                "textDelta",
+                "completed",
+                "failed",
+                "approvalRequested"
+            ],
+            "x-enum-varnames": [
+                "EventRunStarted",
+                "EventToolCall",
+                "EventToolOutput",
+                "EventTextDelta",
+                "EventCompleted",
+                "EventFailed",
+                "EventApprovalRequested"
+            ]
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "A token of api.tokens, as \"Bearer <token>\".",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -1,6 +1,8 @@
 package configuration
 
 import (
+	"time"
+
 	"fmt"
 	"reflect"
 
@@ -14,6 +16,14 @@ type Configuration struct {
 	AI        AI        `yaml:"ai,omitempty"`
 	API       API       `yaml:"api,omitempty"`
 	Events    Events    `yaml:"events,omitempty"`
+	Redaction Redaction `yaml:"redaction,omitempty"`
+}
+
+// Redaction masks secrets in the executor outputs, the chats and the logs.
+type Redaction struct {
+	// Patterns are regular expressions added to the built-in ones. When a
+	// pattern has groups, only the first one is masked.
+	Patterns []string `yaml:"patterns,omitempty"`
 }
 
 type Knowledge struct {
@@ -29,6 +39,183 @@ type Executors struct {
 	K8S     K8SExecutors    `yaml:"k8s,omitempty"`
 	Common  CommonExecutors `yaml:"common,omitempty"`
 	Web     WebExecutors    `yaml:"web,omitempty"`
+	Output  Output          `yaml:"output,omitempty"`
+	// Approval pauses the write executors until a human approves them.
+	Approval Approval `yaml:"approval,omitempty"`
+	// DryRun simulates the write executors of every analysis.
+	DryRun bool `yaml:"dryRun,omitempty"`
+	// Policy allows or denies the executor calls.
+	Policy Policy `yaml:"policy,omitempty"`
+	// Limits bound the number of calls of the executors over time.
+	Limits []Limit `yaml:"limits,omitempty"`
+	// Timeout bounds the run of the executors, 1 minute by default and 10
+	// minutes 30 seconds for the executors which wait.
+	Timeout time.Duration `yaml:"timeout,omitempty" example:"1m"`
+	// Timeouts override Timeout for some executors, the first match applies.
+	Timeouts []ExecutorTimeout `yaml:"timeouts,omitempty"`
+	// HTTP are custom executors calling HTTP endpoints.
+	HTTP []HTTPExecutor `yaml:"http,omitempty"`
+	// Plugins serve executors out of the process of Ava over gRPC.
+	Plugins Plugins `yaml:"plugins,omitempty"`
+	// Scripts are custom executors running a script in a sandbox.
+	Scripts Scripts `yaml:"scripts,omitempty"`
+}
+
+type Scripts struct {
+	// Sandbox isolates the scripts with bubblewrap. Disabling it runs the
+	// scripts on the host with their resource limits only.
+	Sandbox   ScriptSandbox    `yaml:"sandbox,omitempty"`
+	Executors []ScriptExecutor `yaml:"executors,omitempty"`
+}
+
+type ScriptSandbox struct {
+	Disabled bool `yaml:"disabled,omitempty"`
+	// Command is the bubblewrap binary, bwrap by default.
+	Command string `yaml:"command,omitempty" example:"bwrap"`
+}
+
+// ScriptExecutor runs a script with its parameters as environment
+// variables, on a read-only filesystem without network by default.
+type ScriptExecutor struct {
+	Name        string      `yaml:"name,omitempty" example:"checkCertificate"`
+	Description string      `yaml:"description,omitempty" example:"Check the certificate of a host"`
+	Parameters  []Parameter `yaml:"parameters,omitempty"`
+	// Interpreter runs the script, sh by default.
+	Interpreter string `yaml:"interpreter,omitempty" example:"python3"`
+	Script      string `yaml:"script,omitempty"`
+	// Network allows the script to reach the network.
+	Network bool `yaml:"network,omitempty"`
+	// Memory bounds the virtual memory of the script, 512Mi by default.
+	Memory string `yaml:"memory,omitempty" example:"512Mi"`
+	// CPUTime bounds the CPU time of the script, 30 seconds by default.
+	CPUTime time.Duration `yaml:"cpuTime,omitempty" example:"30s"`
+	// OutputLimit bounds the output read, 64KiB by default.
+	OutputLimit string `yaml:"outputLimit,omitempty" example:"64Ki"`
+	// Write marks an executor changing something: it is subject to the
+	// approval, the dry run and the kill switch like the write executors.
+	Write bool `yaml:"write,omitempty"`
+}
+
+// Plugins are the binaries started by Ava and the endpoints of the plugins
+// already running, e.g. in a sidecar.
+type Plugins struct {
+	// Directory holds the plugin binaries, every executable is started.
+	Directory string           `yaml:"directory,omitempty" example:"/etc/ava/plugins"`
+	Endpoints []PluginEndpoint `yaml:"endpoints,omitempty"`
+	// HealthCheckInterval is the interval of the health checks, the crashed
+	// binaries are restarted. 30 seconds by default.
+	HealthCheckInterval time.Duration `yaml:"healthCheckInterval,omitempty" example:"30s"`
+}
+
+type PluginEndpoint struct {
+	Name string `yaml:"name,omitempty" example:"catalog"`
+	// Address is a host:port or a unix:///path/to/socket.
+	Address string `yaml:"address,omitempty" example:"localhost:7070"`
+}
+
+// HTTPExecutor calls an HTTP endpoint. The URL, the headers and the body
+// are Go templates of the parameters, the values are escaped in the URL.
+// ${ENV} in the headers is replaced by the environment variable.
+type HTTPExecutor struct {
+	Name        string      `yaml:"name,omitempty" example:"getServiceHealth"`
+	Description string      `yaml:"description,omitempty" example:"Get the health of a service from the service catalog"`
+	Parameters  []Parameter `yaml:"parameters,omitempty"`
+	// Method defaults to GET.
+	Method  string            `yaml:"method,omitempty" example:"GET"`
+	URL     string            `yaml:"url,omitempty" example:"https://catalog.internal/services/{{ .service }}/health"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+	Body    string            `yaml:"body,omitempty"`
+	// Extract is a JSONPath applied to the response, e.g. $.status.
+	Extract string `yaml:"extract,omitempty" example:"$.status"`
+	// AllowedStatusCodes are the successful status codes, 2xx by default.
+	AllowedStatusCodes []int `yaml:"allowedStatusCodes,omitempty"`
+	// Write marks an executor changing something: it is subject to the
+	// approval, the dry run and the kill switch like the write executors.
+	// Defaults to true for any method but GET and HEAD.
+	Write *bool `yaml:"write,omitempty"`
+}
+
+// Parameter is a parameter of an executor defined in the configuration.
+type Parameter struct {
+	Name string `yaml:"name,omitempty" example:"service"`
+	// Type is string, integer, number or boolean. Defaults to string.
+	Type        string   `yaml:"type,omitempty" example:"string"`
+	Description string   `yaml:"description,omitempty"`
+	Required    bool     `yaml:"required,omitempty"`
+	Enum        []string `yaml:"enum,omitempty"`
+}
+
+type ExecutorTimeout struct {
+	// Executor is a glob of executor names.
+	Executor string        `yaml:"executor,omitempty" example:"wait"`
+	Timeout  time.Duration `yaml:"timeout,omitempty" example:"10m"`
+}
+
+// Limit allows at most Max calls of the executors per Window, counted per
+// target, per owner of the target, per namespace or for all the calls.
+type Limit struct {
+	// Executor is a glob of executor names.
+	Executor string        `yaml:"executor,omitempty" example:"deletePod"`
+	Per      string        `yaml:"per,omitempty" example:"owner"`
+	Max      int           `yaml:"max,omitempty" example:"3"`
+	Window   time.Duration `yaml:"window,omitempty" example:"1h"`
+}
+
+// Policy is evaluated before every executor call, the first matching rule
+// applies.
+type Policy struct {
+	// Default is the effect when no rule matches, allow or deny. Defaults to allow.
+	Default string       `yaml:"default,omitempty" example:"allow"`
+	Rules   []PolicyRule `yaml:"rules,omitempty"`
+}
+
+// PolicyRule matches a call when all its conditions match, an empty
+// condition matches every call.
+type PolicyRule struct {
+	Name string `yaml:"name,omitempty" example:"protect-kube-system"`
+	// Effect is allow or deny.
+	Effect string `yaml:"effect,omitempty" example:"deny"`
+	// Executors are globs of executor names.
+	Executors []string `yaml:"executors,omitempty" example:"deletePod"`
+	// Namespaces are globs of the namespace of the target object.
+	Namespaces []string `yaml:"namespaces,omitempty" example:"kube-system"`
+	// Selector is a label selector on the target object.
+	Selector string `yaml:"selector,omitempty" example:"app in (postgres, mysql)"`
+	// AlertLabels are globs of the labels of the alert being analyzed.
+	AlertLabels map[string]string `yaml:"alertLabels,omitempty"`
+	// Windows restrict the rule to time windows, e.g. a change freeze.
+	Windows []PolicyWindow `yaml:"windows,omitempty"`
+	// Message is sent to the model when the call is denied.
+	Message string `yaml:"message,omitempty" example:"kube-system is managed by the platform team"`
+}
+
+// PolicyWindow is either a period between Start and End or a weekly window
+// between From and To on Days.
+type PolicyWindow struct {
+	Start string   `yaml:"start,omitempty" example:"2025-12-20T00:00:00Z"`
+	End   string   `yaml:"end,omitempty" example:"2026-01-05T00:00:00Z"`
+	Days  []string `yaml:"days,omitempty" example:"Friday"`
+	From  string   `yaml:"from,omitempty" example:"16:00"`
+	To    string   `yaml:"to,omitempty" example:"23:59"`
+	// TimeZone of From and To. Defaults to UTC.
+	TimeZone string `yaml:"timeZone,omitempty" example:"Europe/Paris"`
+}
+
+// Approval configures the human approval of the write executors.
+type Approval struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+	// Timeout is how long an action waits for a decision. Defaults to 5 minutes.
+	Timeout time.Duration `yaml:"timeout,omitempty" example:"5m"`
+	// DefaultOutcome is applied when nobody decided before the timeout,
+	// approve or reject. Defaults to reject.
+	DefaultOutcome string `yaml:"defaultOutcome,omitempty" example:"reject"`
+}
+
+// Output bounds the output of the executors sent to the model.
+type Output struct {
+	MaxBytes  int  `yaml:"maxBytes,omitempty" example:"16384"`
+	MaxTokens int  `yaml:"maxTokens,omitempty" example:"4000"`
+	Raw       bool `yaml:"raw,omitempty"`
 }
 
 type K8SExecutors struct {
@@ -44,13 +231,73 @@ type WebExecutors struct {
 	Enabled bool `yaml:"enabled,omitempty"`
 }
 
+// DEFAULT_AI_TYPE is the backend used when ai.type is not set.
+const DEFAULT_AI_TYPE = "openai"
+
 type AI struct {
-	Type   string `yaml:"type,omitempty" example:"openai"`
-	OpenAI OpenAI `yaml:"openai,omitempty"`
+	Type       string     `yaml:"type,omitempty" example:"openai"`
+	OpenAI     OpenAI     `yaml:"openai,omitempty"`
+	Completion Completion `yaml:"completion,omitempty"`
+	Ollama     Ollama     `yaml:"ollama,omitempty"`
+	Anthropic  Anthropic  `yaml:"anthropic,omitempty"`
+	Scripted   Scripted   `yaml:"scripted,omitempty"`
+	Prompts    Prompts    `yaml:"prompts,omitempty"`
+	// RunTimeout bounds the duration of an analysis. Defaults to 10 minutes.
+	RunTimeout time.Duration `yaml:"runTimeout,omitempty" example:"10m"`
+	// Pricing is the price of the tokens per model, used to estimate the
+	// cost of the analyses.
+	Pricing map[string]Price `yaml:"pricing,omitempty"`
 }
 
 type OpenAI struct {
-	APIKey string `yaml:"apiKey,omitempty" example:""`
+	APIKey      string   `yaml:"apiKey,omitempty" example:""`
+	Model       string   `yaml:"model,omitempty" example:"gpt-4o"`
+	Temperature *float32 `yaml:"temperature,omitempty" example:"1"`
+	TopP        *float32 `yaml:"topP,omitempty" example:"1"`
+	// MaxSteps is the maximum number of tool call rounds of a run.
+	MaxSteps int `yaml:"maxSteps,omitempty" example:"20"`
+}
+
+// Price of a model in USD per million tokens.
+type Price struct {
+	Prompt     float64 `yaml:"prompt,omitempty" example:"2.5"`
+	Completion float64 `yaml:"completion,omitempty" example:"10"`
+}
+
+// Prompts overrides the default prompts with text/template files.
+type Prompts struct {
+	Instructions  string `yaml:"instructions,omitempty" example:"./prompts/instructions.tmpl"`
+	Analyse       string `yaml:"analyse,omitempty" example:"./prompts/analyse.tmpl"`
+	AnalyseAndFix string `yaml:"analyseAndFix,omitempty" example:"./prompts/analyse-and-fix.tmpl"`
+}
+
+// Completion configures any OpenAI-compatible /v1/chat/completions endpoint.
+type Completion struct {
+	BaseURL  string `yaml:"baseURL,omitempty" example:"https://api.openai.com/v1"`
+	APIKey   string `yaml:"apiKey,omitempty" example:""`
+	Model    string `yaml:"model,omitempty" example:"gpt-4o"`
+	MaxSteps int    `yaml:"maxSteps,omitempty" example:"20"`
+}
+
+// Anthropic configures the Anthropic Messages API.
+type Anthropic struct {
+	BaseURL   string `yaml:"baseURL,omitempty" example:"https://api.anthropic.com"`
+	APIKey    string `yaml:"apiKey,omitempty" example:""`
+	Model     string `yaml:"model,omitempty" example:"claude-3-5-sonnet-latest"`
+	MaxTokens int    `yaml:"maxTokens,omitempty" example:"4096"`
+	MaxSteps  int    `yaml:"maxSteps,omitempty" example:"20"`
+}
+
+// Scripted configures the deterministic backend used for tests and demos.
+type Scripted struct {
+	Path string `yaml:"path,omitempty" example:"./docs/examples/script.yaml"`
+}
+
+// Ollama configures a local Ollama server. The model must support tool calling.
+type Ollama struct {
+	BaseURL  string `yaml:"baseURL,omitempty" example:"http://localhost:11434/v1"`
+	Model    string `yaml:"model,omitempty" example:"llama3.1"`
+	MaxSteps int    `yaml:"maxSteps,omitempty" example:"20"`
 }
 
 type API struct {
@@ -58,6 +305,19 @@ type API struct {
 	Knowledge KnowledgeAPI `yaml:"knowledge,omitempty"`
 	Events    EventsAPI    `yaml:"events,omitempty"`
 	Swagger   Swagger      `yaml:"swagger,omitempty"`
+	Admin     AdminAPI     `yaml:"admin,omitempty"`
+	Audit     AuditAPI     `yaml:"audit,omitempty"`
+	// Tokens are the bearer tokens of the callers of the admin, actions and
+	// audit APIs, by name. The name of the token is recorded as the caller.
+	Tokens map[string]string `yaml:"tokens,omitempty"`
+}
+
+type AdminAPI struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+}
+
+type AuditAPI struct {
+	Enabled bool `yaml:"enabled,omitempty"`
 }
 
 type Swagger struct {
@@ -65,7 +325,13 @@ type Swagger struct {
 }
 
 type ChatAPI struct {
-	Enabled bool `yaml:"enabled,omitempty"`
+	Enabled bool       `yaml:"enabled,omitempty"`
+	Webhook WebhookAPI `yaml:"webhook,omitempty"`
+}
+
+type WebhookAPI struct {
+	// DryRun simulates the write executors of the analyses of the alerts.
+	DryRun bool `yaml:"dryRun,omitempty"`
 }
 
 type KnowledgeAPI struct {
@@ -94,13 +360,15 @@ func WriteInitConfig(logger logger.ILogger) {
 	viper.SetDefault("executors.common.enabled", true)
 	viper.SetDefault("executors.web.enabled", true)
 
-	viper.SetDefault("ai.type", "openai")
+	viper.SetDefault("ai.type", DEFAULT_AI_TYPE)
 	viper.SetDefault("ai.openai.apiKey", "${OPENAI_API_KEY}")
 
 	viper.SetDefault("api.chat.enabled", true)
 	viper.SetDefault("api.knowledge.enabled", true)
 	viper.SetDefault("api.events.enabled", true)
 	viper.SetDefault("api.swagger.enabled", true)
+	viper.SetDefault("api.admin.enabled", false)
+	viper.SetDefault("api.audit.enabled", false)
 
 	viper.SetDefault("events.type", "slack")
 	viper.SetDefault("events.slack.validationToken", "${SLACK_VALIDATION_TOKEN}")
@@ -118,6 +386,49 @@ func WriteInitConfig(logger logger.ILogger) {
 	}
 }
 
+// Backend returns the backend of ai.type, DEFAULT_AI_TYPE if it is not set.
+func (a AI) Backend() string {
+	if a.Type == "" {
+		return DEFAULT_AI_TYPE
+	}
+	return a.Type
+}
+
+// Validate checks the settings of the AI backends that can not be checked
+// by the backends themselves before the first chat.
+func (a AI) Validate() error {
+	if a.OpenAI.Temperature != nil && (*a.OpenAI.Temperature < 0 || *a.OpenAI.Temperature > 2) {
+		return fmt.Errorf("ai.openai.temperature must be between 0 and 2, got %v", *a.OpenAI.Temperature)
+	}
+
+	if a.OpenAI.TopP != nil && (*a.OpenAI.TopP < 0 || *a.OpenAI.TopP > 1) {
+		return fmt.Errorf("ai.openai.topP must be between 0 and 1, got %v", *a.OpenAI.TopP)
+	}
+
+	for model, price := range a.Pricing {
+		if price.Prompt < 0 || price.Completion < 0 {
+			return fmt.Errorf("ai.pricing.%s must not be negative", model)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the approval configuration.
+func (a Approval) Validate() error {
+	switch a.DefaultOutcome {
+	case "", "approve", "reject":
+	default:
+		return fmt.Errorf("executors.approval.defaultOutcome must be approve or reject, got %q", a.DefaultOutcome)
+	}
+
+	if a.Timeout < 0 {
+		return fmt.Errorf("executors.approval.timeout must not be negative")
+	}
+
+	return nil
+}
+
 func LoadConfiguration(logger logger.ILogger) *Configuration {
 	var config Configuration
 	err := viper.Unmarshal(&config)
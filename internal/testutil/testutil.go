@@ -0,0 +1,81 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package testutil contains the helpers shared by the tests of the AI
+// backends: the logger, the default prompts and a stub of a JSON API.
+package testutil
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matthisholleville/ava/internal/configuration"
+	"github.com/matthisholleville/ava/pkg/ai/prompt"
+	"github.com/matthisholleville/ava/pkg/logger"
+	"github.com/spf13/viper"
+)
+
+// Assistant is the part of a backend configured by ConfigureAssistant.
+type Assistant interface {
+	ConfigureAssistant(logger logger.ILogger, enableExecutors bool) error
+}
+
+// Logger sets the logger read by the executors and returns it.
+func Logger() logger.ILogger {
+	l := logger.InitLogger("raw", "error")
+	viper.Set("logger", l)
+	return l
+}
+
+// Prompts returns the default prompts.
+func Prompts(t *testing.T) *prompt.Prompts {
+	t.Helper()
+	prompts, err := prompt.New(configuration.Prompts{})
+	if err != nil {
+		t.Fatalf("unable to parse the prompts: %v", err)
+	}
+	return prompts
+}
+
+// ConfigureAssistant configures the backend with the enabled executors.
+func ConfigureAssistant(t *testing.T, client Assistant) {
+	t.Helper()
+	if err := client.ConfigureAssistant(Logger(), true); err != nil {
+		t.Fatalf("unable to configure the client: %v", err)
+	}
+}
+
+// NewJSONServer starts a stub of a JSON API, closed at the end of the test.
+// Each request body is decoded into a Request and answered with the value
+// returned by respond. respond runs on the goroutine of the server, it must
+// report the failures with t.Errorf.
+func NewJSONServer[Request any](t *testing.T, respond func(r *http.Request, request Request) any) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request Request
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("unable to decode the request: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(respond(r, request)); err != nil {
+			t.Errorf("unable to encode the response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
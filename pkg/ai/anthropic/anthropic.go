@@ -0,0 +1,313 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anthropic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/matthisholleville/ava/pkg/ai/history"
+	"github.com/matthisholleville/ava/pkg/ai/prompt"
+	"github.com/matthisholleville/ava/pkg/ai/types"
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/matthisholleville/ava/pkg/executors"
+	"github.com/matthisholleville/ava/pkg/logger"
+)
+
+const (
+	anthropicClientName = "anthropic"
+	apiVersion          = "2023-06-01"
+	defaultBaseURL      = "https://api.anthropic.com"
+	defaultModel        = "claude-3-5-sonnet-latest"
+	defaultMaxTokens    = 4096
+	defaultMaxSteps     = 20
+)
+
+var (
+	errKnowledgeNotSupported = errors.New("knowledge base is not supported by this AI backend")
+	threads                  = history.NewStore[Message]()
+)
+
+// AnthropicClient runs the tool-use loop on top of the Anthropic Messages API.
+type AnthropicClient struct {
+	httpClient      *http.Client
+	Configuration   Configuration
+	Prompts         *prompt.Prompts
+	logger          logger.ILogger
+	enableExecutors bool
+	executors       []string
+	instructions    string
+	tools           []Tool
+}
+
+type Configuration struct {
+	BaseURL   string `json:"baseURL"`
+	APIKey    string `json:"apiKey"`
+	Model     string `json:"model"`
+	MaxTokens int    `json:"maxTokens"`
+	MaxSteps  int    `json:"maxSteps"`
+}
+
+func (c *AnthropicClient) Configure(logger logger.ILogger) error {
+	if c.Configuration.APIKey == "" {
+		return errors.New("anthropic api key is required")
+	}
+	if c.Configuration.BaseURL == "" {
+		c.Configuration.BaseURL = defaultBaseURL
+	}
+	if c.Configuration.Model == "" {
+		c.Configuration.Model = defaultModel
+	}
+	if c.Configuration.MaxTokens <= 0 {
+		c.Configuration.MaxTokens = defaultMaxTokens
+	}
+	if c.Configuration.MaxSteps <= 0 {
+		c.Configuration.MaxSteps = defaultMaxSteps
+	}
+
+	c.httpClient = &http.Client{}
+	c.logger = logger
+
+	return nil
+}
+
+func (c *AnthropicClient) ConfigureKnowledge(logger logger.ILogger) error {
+	return errKnowledgeNotSupported
+}
+
+func (c *AnthropicClient) ConfigureAssistant(logger logger.ILogger, enableExecutors bool) error {
+	err := c.Configure(logger)
+	if err != nil {
+		return err
+	}
+
+	c.enableExecutors = enableExecutors
+	c.executors = nil
+	c.tools = nil
+	if enableExecutors {
+		c.logger.Debug("Adding the executors to the tools")
+		c.executors = prompt.ExecutorNames(executors.GetExecutors())
+		c.tools = c.executorToTool()
+	}
+
+	c.instructions, err = c.Prompts.Instructions(c.executors)
+	return err
+}
+
+func (c *AnthropicClient) executorToTool() []Tool {
+	var tools []Tool
+
+	executors := executors.GetExecutors()
+	for _, executor := range executors {
+		tools = append(tools, Tool{
+			Name:        executor.GetName(),
+			Description: executor.GetDescription(),
+			InputSchema: json.RawMessage([]byte(executor.GetParams())),
+		})
+	}
+	return tools
+}
+
+func (c *AnthropicClient) Purge() error {
+	return errKnowledgeNotSupported
+}
+
+func (c *AnthropicClient) UploadFiles(files []string) error {
+	return errKnowledgeNotSupported
+}
+
+func (c *AnthropicClient) GetName() string {
+	return anthropicClientName
+}
+
+func (c *AnthropicClient) CreateThread() (*string, error) {
+	threadID := history.NewThreadID()
+	threads.Set(threadID, []Message{})
+	return &threadID, nil
+}
+
+func (c *AnthropicClient) Analyze(ctx context.Context, request types.AnalyzeRequest, executorConfig common.Executor) (types.AnalyzeResponse, error) {
+	return c.AnalyzeStream(ctx, request, executorConfig, nil)
+}
+
+// AnalyzeStream does not stream the text of the answer, it is sent as a
+// single delta once received. The usage is returned even if the analysis failed.
+func (c *AnthropicClient) AnalyzeStream(ctx context.Context, request types.AnalyzeRequest, executorConfig common.Executor, events chan<- types.Event) (types.AnalyzeResponse, error) {
+	threadID := request.ThreadID
+	response := types.AnalyzeResponse{Usage: types.Usage{Model: c.Configuration.Model}}
+	c.logger.Info(fmt.Sprintf("Analyzing a message of %d bytes in the thread %s", len(request.Message), request.ThreadID))
+
+	inputMessage, err := c.Prompts.Analyse(request, c.enableExecutors, c.executors)
+	if err != nil {
+		return response, err
+	}
+
+	messages, ok := threads.Get(threadID)
+	if !ok {
+		messages = c.rebuildHistory(request)
+	}
+
+	messages = append(messages, Message{
+		Role: roleUser,
+		Content: []ContentBlock{
+			{
+				Type: contentTypeText,
+				Text: inputMessage,
+			},
+		},
+	})
+
+	c.logger.Info("Analysis in progress...")
+	for step := 0; step < c.Configuration.MaxSteps; step++ {
+		c.logger.Debug(fmt.Sprintf("Creating a message (step %d)", step+1))
+		resp, err := c.createMessage(ctx, messages)
+		if err != nil {
+			return response, err
+		}
+		response.Usage.Add(resp.Usage.InputTokens, resp.Usage.OutputTokens)
+
+		// The answer or its tool calls were cut, the thread is kept as it
+		// was before the message.
+		if resp.StopReason == stopReasonMaxTokens {
+			return response, &types.RunError{
+				Err:    types.ErrRunIncomplete,
+				Reason: fmt.Sprintf("the answer exceeded %d tokens", c.Configuration.MaxTokens),
+			}
+		}
+
+		messages = append(messages, Message{
+			Role:    roleAssistant,
+			Content: resp.Content,
+		})
+
+		if resp.StopReason != stopReasonToolUse {
+			threads.Set(threadID, messages)
+			response.Text = c.responseText(resp)
+			types.Emit(events, types.TextDeltaEvent(response.Text))
+			return response, nil
+		}
+
+		results := []ContentBlock{}
+		for _, block := range resp.Content {
+			if block.Type != contentTypeToolUse {
+				continue
+			}
+			c.logger.Info(fmt.Sprintf("Execution of the function : %s", block.Name))
+			types.Emit(events, types.ToolCallEvent(block.Name, string(block.Input)))
+			output := executors.Execute(executorConfig.WithToolCall("", block.ID), block.Name, string(block.Input))
+			types.Emit(events, types.ToolOutputEvent(block.Name, output))
+			results = append(results, ContentBlock{
+				Type:      contentTypeToolResult,
+				ToolUseID: block.ID,
+				Content:   output,
+			})
+		}
+
+		messages = append(messages, Message{
+			Role:    roleUser,
+			Content: results,
+		})
+	}
+
+	threads.Set(threadID, messages)
+	return response, &types.MaxStepsError{Steps: c.Configuration.MaxSteps}
+}
+
+// rebuildHistory returns the history of a thread no longer in memory from
+// its persisted turns, the tool calls are not kept.
+func (c *AnthropicClient) rebuildHistory(request types.AnalyzeRequest) []Message {
+	turns, err := history.Turns(request)
+	if err != nil {
+		c.logger.Warn(fmt.Sprintf("Thread %s could not be loaded, starting a new conversation: %s", request.ThreadID, err.Error()))
+		return nil
+	}
+	if len(turns) == 0 {
+		c.logger.Warn(fmt.Sprintf("Thread %s not found, starting a new conversation", request.ThreadID))
+		return nil
+	}
+	var messages []Message
+	for _, turn := range turns {
+		messages = append(messages,
+			Message{Role: roleUser, Content: []ContentBlock{{Type: contentTypeText, Text: turn.Input}}},
+			Message{Role: roleAssistant, Content: []ContentBlock{{Type: contentTypeText, Text: turn.Response}}},
+		)
+	}
+	return messages
+}
+
+// responseText concatenates the text blocks of the model response.
+func (c *AnthropicClient) responseText(resp *MessagesResponse) string {
+	var texts []string
+	for _, block := range resp.Content {
+		if block.Type == contentTypeText {
+			texts = append(texts, block.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+func (c *AnthropicClient) createMessage(ctx context.Context, messages []Message) (*MessagesResponse, error) {
+	body, err := json.Marshal(MessagesRequest{
+		Model:     c.Configuration.Model,
+		MaxTokens: c.Configuration.MaxTokens,
+		System:    c.instructions,
+		Messages:  messages,
+		Tools:     c.tools,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(c.Configuration.BaseURL, "/")+"/v1/messages", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", c.Configuration.APIKey)
+	req.Header.Set("anthropic-version", apiVersion)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errorResponse ErrorResponse
+		if err := json.Unmarshal(data, &errorResponse); err == nil && errorResponse.Error.Message != "" {
+			return nil, fmt.Errorf("anthropic api error (%d): %s", resp.StatusCode, errorResponse.Error.Message)
+		}
+		return nil, fmt.Errorf("anthropic api error (%d): %s", resp.StatusCode, string(data))
+	}
+
+	var messagesResponse MessagesResponse
+	err = json.Unmarshal(data, &messagesResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &messagesResponse, nil
+}
@@ -0,0 +1,117 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anthropic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/matthisholleville/ava/internal/testutil"
+	"github.com/matthisholleville/ava/pkg/ai/types"
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/spf13/viper"
+)
+
+func newStubServer(t *testing.T, stopReason string) string {
+	server := testutil.NewJSONServer(t, func(r *http.Request, request MessagesRequest) any {
+		if r.Header.Get("x-api-key") != "key" || r.Header.Get("anthropic-version") != apiVersion {
+			t.Errorf("the request should be authenticated: %v", r.Header)
+		}
+
+		last := request.Messages[len(request.Messages)-1].Content[0]
+		response := MessagesResponse{Role: roleAssistant, Usage: Usage{InputTokens: 10, OutputTokens: 5}}
+		switch {
+		case stopReason != "":
+			response.StopReason = stopReason
+			response.Content = []ContentBlock{{Type: contentTypeText, Text: "The pod"}}
+		case last.Type == contentTypeToolResult:
+			response.StopReason = "end_turn"
+			response.Content = []ContentBlock{{Type: contentTypeText, Text: "tool said: " + last.Content}}
+		default:
+			response.StopReason = stopReasonToolUse
+			response.Content = []ContentBlock{
+				{Type: contentTypeText, Text: "Let me wait."},
+				{Type: contentTypeToolUse, ID: "toolu_1", Name: "wait", Input: json.RawMessage(`{"time":0}`)},
+			}
+		}
+		return response
+	})
+	return server.URL
+}
+
+func newTestClient(t *testing.T, baseURL string) *AnthropicClient {
+	viper.Set("executors.common.enabled", true)
+
+	client := &AnthropicClient{
+		Configuration: Configuration{
+			BaseURL: baseURL,
+			APIKey:  "key",
+		},
+		Prompts: testutil.Prompts(t),
+	}
+	testutil.ConfigureAssistant(t, client)
+	return client
+}
+
+func TestAnalyzeToolLoop(t *testing.T) {
+	client := newTestClient(t, newStubServer(t, ""))
+
+	threadID, err := client.CreateThread()
+	if err != nil {
+		t.Fatalf("unable to create a thread: %v", err)
+	}
+
+	response, err := client.Analyze(context.Background(), types.AnalyzeRequest{Message: "Pod web in namespace default Crashlooping.", Language: "en", ThreadID: *threadID}, common.Executor{})
+	if err != nil {
+		t.Fatalf("analyze returned an error: %v", err)
+	}
+
+	if want := "tool said: Waited for 0 seconds"; response.Text != want {
+		t.Errorf("analyze returned wrong response: got %q want %q", response.Text, want)
+	}
+
+	if response.Usage.PromptTokens != 20 || response.Usage.CompletionTokens != 10 {
+		t.Errorf("analyze returned wrong usage: got %d/%d want %d/%d", response.Usage.PromptTokens, response.Usage.CompletionTokens, 20, 10)
+	}
+
+	history, _ := threads.Get(*threadID)
+	if len(history) != 4 {
+		t.Errorf("thread history has wrong length: got %d want %d", len(history), 4)
+	}
+}
+
+func TestAnalyzeMaxTokens(t *testing.T) {
+	client := newTestClient(t, newStubServer(t, stopReasonMaxTokens))
+
+	threadID, err := client.CreateThread()
+	if err != nil {
+		t.Fatalf("unable to create a thread: %v", err)
+	}
+
+	response, err := client.Analyze(context.Background(), types.AnalyzeRequest{Message: "Pod web in namespace default Crashlooping.", ThreadID: *threadID}, common.Executor{})
+
+	if !errors.Is(err, types.ErrRunIncomplete) {
+		t.Fatalf("analyze should return an incomplete run error: got %v", err)
+	}
+	if response.Text != "" || response.Usage.CompletionTokens != 5 {
+		t.Errorf("a cut answer should not be returned, its usage should: got %+v", response)
+	}
+	if history, _ := threads.Get(*threadID); len(history) != 0 {
+		t.Errorf("the thread should be kept as it was: got %d messages", len(history))
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package completion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/matthisholleville/ava/pkg/ai/history"
+	"github.com/matthisholleville/ava/pkg/ai/prompt"
+	"github.com/matthisholleville/ava/pkg/ai/types"
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/matthisholleville/ava/pkg/executors"
+	"github.com/matthisholleville/ava/pkg/logger"
+	"github.com/sashabaranov/go-openai"
+)
+
+const (
+	completionClientName = "completion"
+	defaultModel         = openai.GPT4o
+	defaultMaxSteps      = 20
+)
+
+var (
+	errKnowledgeNotSupported = errors.New("knowledge base is not supported by this AI backend")
+	threads                  = history.NewStore[openai.ChatCompletionMessage]()
+)
+
+// CompletionClient drives the ReAct loop locally on top of any
+// OpenAI-compatible /v1/chat/completions endpoint. The conversation
+// history is kept by Ava instead of an OpenAI Assistants thread.
+type CompletionClient struct {
+	client          *openai.Client
+	Configuration   Configuration
+	Prompts         *prompt.Prompts
+	logger          logger.ILogger
+	enableExecutors bool
+	executors       []string
+	instructions    string
+	tools           []openai.Tool
+}
+
+type Configuration struct {
+	BaseURL  string `json:"baseURL"`
+	APIKey   string `json:"apiKey"`
+	Model    string `json:"model"`
+	MaxSteps int    `json:"maxSteps"`
+}
+
+func (c *CompletionClient) Configure(logger logger.ILogger) error {
+	config := openai.DefaultConfig(c.Configuration.APIKey)
+	if c.Configuration.BaseURL != "" {
+		config.BaseURL = c.Configuration.BaseURL
+	}
+	if c.Configuration.Model == "" {
+		c.Configuration.Model = defaultModel
+	}
+	if c.Configuration.MaxSteps <= 0 {
+		c.Configuration.MaxSteps = defaultMaxSteps
+	}
+
+	c.client = openai.NewClientWithConfig(config)
+	c.logger = logger
+
+	return nil
+}
+
+func (c *CompletionClient) ConfigureKnowledge(logger logger.ILogger) error {
+	return errKnowledgeNotSupported
+}
+
+func (c *CompletionClient) ConfigureAssistant(logger logger.ILogger, enableExecutors bool) error {
+	err := c.Configure(logger)
+	if err != nil {
+		return err
+	}
+
+	c.enableExecutors = enableExecutors
+	c.executors = nil
+	c.tools = nil
+	if enableExecutors {
+		c.logger.Debug("Adding the executors to the tools")
+		c.executors = prompt.ExecutorNames(executors.GetExecutors())
+		c.tools = c.executorToFunctionTool()
+	}
+
+	c.instructions, err = c.Prompts.Instructions(c.executors)
+	return err
+}
+
+func (c *CompletionClient) executorToFunctionTool() []openai.Tool {
+	var functions []openai.Tool
+
+	executors := executors.GetExecutors()
+	for _, executor := range executors {
+		functions = append(functions, openai.Tool{
+			Type: openai.ToolTypeFunction,
+			Function: &openai.FunctionDefinition{
+				Name:        executor.GetName(),
+				Parameters:  json.RawMessage([]byte(executor.GetParams())),
+				Description: executor.GetDescription(),
+			},
+		})
+	}
+	return functions
+}
+
+func (c *CompletionClient) Purge() error {
+	return errKnowledgeNotSupported
+}
+
+func (c *CompletionClient) UploadFiles(files []string) error {
+	return errKnowledgeNotSupported
+}
+
+func (c *CompletionClient) GetName() string {
+	return completionClientName
+}
+
+func (c *CompletionClient) CreateThread() (*string, error) {
+	threadID := history.NewThreadID()
+	threads.Set(threadID, c.newHistory())
+	return &threadID, nil
+}
+
+func (c *CompletionClient) newHistory() []openai.ChatCompletionMessage {
+	return []openai.ChatCompletionMessage{
+		{
+			Role:    openai.ChatMessageRoleSystem,
+			Content: c.instructions,
+		},
+	}
+}
+
+// rebuildHistory returns the history of a thread no longer in memory from
+// its persisted turns, the tool calls are not kept.
+func (c *CompletionClient) rebuildHistory(request types.AnalyzeRequest) []openai.ChatCompletionMessage {
+	messages := c.newHistory()
+	turns, err := history.Turns(request)
+	if err != nil {
+		c.logger.Warn(fmt.Sprintf("Thread %s could not be loaded, starting a new conversation: %s", request.ThreadID, err.Error()))
+		return messages
+	}
+	if len(turns) == 0 {
+		c.logger.Warn(fmt.Sprintf("Thread %s not found, starting a new conversation", request.ThreadID))
+		return messages
+	}
+	for _, turn := range turns {
+		messages = append(messages,
+			openai.ChatCompletionMessage{Role: openai.ChatMessageRoleUser, Content: turn.Input},
+			openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: turn.Response},
+		)
+	}
+	return messages
+}
+
+func (c *CompletionClient) Analyze(ctx context.Context, request types.AnalyzeRequest, executorConfig common.Executor) (types.AnalyzeResponse, error) {
+	return c.AnalyzeStream(ctx, request, executorConfig, nil)
+}
+
+// AnalyzeStream returns the usage of the completions even if the analysis failed.
+func (c *CompletionClient) AnalyzeStream(ctx context.Context, request types.AnalyzeRequest, executorConfig common.Executor, events chan<- types.Event) (types.AnalyzeResponse, error) {
+	threadID := request.ThreadID
+	response := types.AnalyzeResponse{Usage: types.Usage{Model: c.Configuration.Model}}
+	c.logger.Info(fmt.Sprintf("Analyzing a message of %d bytes in the thread %s", len(request.Message), request.ThreadID))
+
+	inputMessage, err := c.Prompts.Analyse(request, c.enableExecutors, c.executors)
+	if err != nil {
+		return response, err
+	}
+
+	messages, ok := threads.Get(threadID)
+	if !ok {
+		messages = c.rebuildHistory(request)
+	}
+
+	messages = append(messages, openai.ChatCompletionMessage{
+		Role:    openai.ChatMessageRoleUser,
+		Content: inputMessage,
+	})
+
+	c.logger.Info("Analysis in progress...")
+	for step := 0; step < c.Configuration.MaxSteps; step++ {
+		c.logger.Debug(fmt.Sprintf("Creating a chat completion (step %d)", step+1))
+		completionRequest := openai.ChatCompletionRequest{
+			Model:    c.Configuration.Model,
+			Messages: messages,
+			Tools:    c.tools,
+		}
+
+		var message openai.ChatCompletionMessage
+		if events != nil {
+			message, err = c.createChatCompletionStream(ctx, completionRequest, &response.Usage, events)
+		} else {
+			message, err = c.createChatCompletion(ctx, completionRequest, &response.Usage)
+		}
+		if err != nil {
+			return response, err
+		}
+
+		messages = append(messages, message)
+
+		if len(message.ToolCalls) == 0 {
+			threads.Set(threadID, messages)
+			response.Text = message.Content
+			return response, nil
+		}
+
+		for _, f := range message.ToolCalls {
+			c.logger.Info(fmt.Sprintf("Execution of the function : %s", f.Function.Name))
+			types.Emit(events, types.ToolCallEvent(f.Function.Name, f.Function.Arguments))
+			output := executors.Execute(executorConfig.WithToolCall("", f.ID), f.Function.Name, f.Function.Arguments)
+			types.Emit(events, types.ToolOutputEvent(f.Function.Name, output))
+			messages = append(messages, openai.ChatCompletionMessage{
+				Role:       openai.ChatMessageRoleTool,
+				Content:    output,
+				ToolCallID: f.ID,
+			})
+		}
+	}
+
+	threads.Set(threadID, messages)
+	return response, &types.MaxStepsError{Steps: c.Configuration.MaxSteps}
+}
+
+func (c *CompletionClient) createChatCompletion(ctx context.Context, request openai.ChatCompletionRequest, usage *types.Usage) (openai.ChatCompletionMessage, error) {
+	resp, err := c.client.CreateChatCompletion(ctx, request)
+	if err != nil {
+		return openai.ChatCompletionMessage{}, err
+	}
+	usage.Add(resp.Usage.PromptTokens, resp.Usage.CompletionTokens)
+
+	if len(resp.Choices) == 0 {
+		return openai.ChatCompletionMessage{}, errors.New("chat completion returned no choices")
+	}
+
+	return resp.Choices[0].Message, nil
+}
+
+// createChatCompletionStream streams the completion, sending the text to
+// events as it is generated, and assembles the message from the deltas.
+func (c *CompletionClient) createChatCompletionStream(ctx context.Context, request openai.ChatCompletionRequest, usage *types.Usage, events chan<- types.Event) (openai.ChatCompletionMessage, error) {
+	message := openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant}
+	request.StreamOptions = &openai.StreamOptions{IncludeUsage: true}
+
+	stream, err := c.client.CreateChatCompletionStream(ctx, request)
+	if err != nil {
+		return message, err
+	}
+	defer stream.Close()
+
+	var content strings.Builder
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return message, err
+		}
+		// The usage is sent in the last chunk, without choices.
+		if resp.Usage != nil {
+			usage.Add(resp.Usage.PromptTokens, resp.Usage.CompletionTokens)
+		}
+		if len(resp.Choices) == 0 {
+			continue
+		}
+
+		delta := resp.Choices[0].Delta
+		if delta.Content != "" {
+			content.WriteString(delta.Content)
+			types.Emit(events, types.TextDeltaEvent(delta.Content))
+		}
+
+		for _, toolCall := range delta.ToolCalls {
+			// Servers without the index start a new tool call with its ID.
+			index := len(message.ToolCalls) - 1
+			if toolCall.Index != nil {
+				index = *toolCall.Index
+			} else if toolCall.ID != "" || index < 0 {
+				index++
+			}
+			for index >= len(message.ToolCalls) {
+				message.ToolCalls = append(message.ToolCalls, openai.ToolCall{Type: openai.ToolTypeFunction})
+			}
+
+			if toolCall.ID != "" {
+				message.ToolCalls[index].ID = toolCall.ID
+			}
+			message.ToolCalls[index].Function.Name += toolCall.Function.Name
+			message.ToolCalls[index].Function.Arguments += toolCall.Function.Arguments
+		}
+	}
+
+	message.Content = content.String()
+	return message, nil
+}
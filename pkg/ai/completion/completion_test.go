@@ -0,0 +1,144 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package completion
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/matthisholleville/ava/internal/testutil"
+	"github.com/matthisholleville/ava/pkg/ai/history"
+	"github.com/matthisholleville/ava/pkg/ai/types"
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/sashabaranov/go-openai"
+	"github.com/spf13/viper"
+)
+
+func newStubServer(t *testing.T) string {
+	server := testutil.NewJSONServer(t, func(_ *http.Request, request openai.ChatCompletionRequest) any {
+		last := request.Messages[len(request.Messages)-1]
+		message := openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant}
+		if last.Role == openai.ChatMessageRoleTool {
+			message.Content = "tool said: " + last.Content
+		} else {
+			message.ToolCalls = []openai.ToolCall{
+				{
+					ID:   "call_1",
+					Type: openai.ToolTypeFunction,
+					Function: openai.FunctionCall{
+						Name:      "wait",
+						Arguments: `{"time":0}`,
+					},
+				},
+			}
+		}
+		return openai.ChatCompletionResponse{
+			Choices: []openai.ChatCompletionChoice{{Message: message}},
+			Usage:   openai.Usage{PromptTokens: 10, CompletionTokens: 5},
+		}
+	})
+	return server.URL
+}
+
+func newTestClient(t *testing.T, baseURL string, maxSteps int) *CompletionClient {
+	viper.Set("executors.common.enabled", true)
+
+	client := &CompletionClient{
+		Configuration: Configuration{
+			BaseURL:  baseURL,
+			MaxSteps: maxSteps,
+		},
+		Prompts: testutil.Prompts(t),
+	}
+	testutil.ConfigureAssistant(t, client)
+	return client
+}
+
+func TestAnalyzeToolLoop(t *testing.T) {
+	client := newTestClient(t, newStubServer(t), 0)
+
+	threadID, err := client.CreateThread()
+	if err != nil {
+		t.Fatalf("unable to create a thread: %v", err)
+	}
+
+	response, err := client.Analyze(context.Background(), types.AnalyzeRequest{Message: "Pod web in namespace default Crashlooping.", Language: "en", ThreadID: *threadID}, common.Executor{})
+	if err != nil {
+		t.Fatalf("analyze returned an error: %v", err)
+	}
+
+	if want := "tool said: Waited for 0 seconds"; response.Text != want {
+		t.Errorf("analyze returned wrong response: got %q want %q", response.Text, want)
+	}
+
+	if response.Usage.PromptTokens != 20 || response.Usage.CompletionTokens != 10 {
+		t.Errorf("analyze returned wrong usage: got %d/%d want %d/%d", response.Usage.PromptTokens, response.Usage.CompletionTokens, 20, 10)
+	}
+
+	history, _ := threads.Get(*threadID)
+	if len(history) != 5 {
+		t.Errorf("thread history has wrong length: got %d want %d", len(history), 5)
+	}
+}
+
+func TestAnalyzeGivesUpAfterMaxSteps(t *testing.T) {
+	client := newTestClient(t, newStubServer(t), 1)
+
+	threadID, err := client.CreateThread()
+	if err != nil {
+		t.Fatalf("unable to create a thread: %v", err)
+	}
+
+	_, err = client.Analyze(context.Background(), types.AnalyzeRequest{Message: "Pod web in namespace default Crashlooping.", ThreadID: *threadID}, common.Executor{})
+
+	var maxStepsError *types.MaxStepsError
+	if !errors.As(err, &maxStepsError) {
+		t.Fatalf("analyze should return a max steps error: got %v", err)
+	}
+	if maxStepsError.Steps != 1 {
+		t.Errorf("max steps error has wrong steps: got %d want %d", maxStepsError.Steps, 1)
+	}
+}
+
+func TestAnalyzeRebuildsHistory(t *testing.T) {
+	received := make(chan []openai.ChatCompletionMessage, 1)
+	server := testutil.NewJSONServer(t, func(_ *http.Request, request openai.ChatCompletionRequest) any {
+		received <- request.Messages
+		return openai.ChatCompletionResponse{
+			Choices: []openai.ChatCompletionChoice{{Message: openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: "still crashing"}}},
+		}
+	})
+
+	client := newTestClient(t, server.URL, 0)
+
+	request := types.AnalyzeRequest{
+		Message: "Is it fixed?",
+		// A thread that is not in memory.
+		ThreadID: history.NewThreadID(),
+		History: func() ([]types.Turn, error) {
+			return []types.Turn{{Input: "Pod web is crashlooping.", Response: "The image is missing."}}, nil
+		},
+	}
+	if _, err := client.Analyze(context.Background(), request, common.Executor{}); err != nil {
+		t.Fatalf("analyze returned an error: %v", err)
+	}
+
+	messages := <-received
+	if len(messages) != 4 || messages[1].Content != "Pod web is crashlooping." || messages[2].Content != "The image is missing." {
+		t.Errorf("the thread should be rebuilt from the persisted turns: got %+v", messages)
+	}
+}
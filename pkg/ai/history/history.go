@@ -0,0 +1,136 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/matthisholleville/ava/pkg/ai/types"
+	"github.com/matthisholleville/ava/pkg/common"
+)
+
+const (
+	threadIDLength = 24
+
+	DEFAULT_MAX_THREADS = 1000
+	DEFAULT_TTL         = 24 * time.Hour
+)
+
+// Store keeps the conversation history of the recent threads in memory for
+// the backends that run the tool-calling loop themselves. A store is meant
+// to be shared by all the clients of a backend because the API creates a new
+// client for each request of the same thread. The least recently used
+// threads are evicted beyond MaxThreads, and the threads unused for TTL. The
+// backends rebuild an evicted thread from the persisted chats.
+type Store[M any] struct {
+	MaxThreads int
+	TTL        time.Duration
+
+	mu      sync.Mutex
+	threads map[string]*list.Element
+	// recent orders the threads from the most recently used.
+	recent *list.List
+	now    func() time.Time
+}
+
+type entry[M any] struct {
+	threadID string
+	messages []M
+	usedAt   time.Time
+}
+
+func NewStore[M any]() *Store[M] {
+	return &Store[M]{
+		MaxThreads: DEFAULT_MAX_THREADS,
+		TTL:        DEFAULT_TTL,
+		threads:    map[string]*list.Element{},
+		recent:     list.New(),
+		now:        time.Now,
+	}
+}
+
+func (s *Store[M]) Get(threadID string) ([]M, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evict()
+	element, ok := s.threads[threadID]
+	if !ok {
+		return nil, false
+	}
+	e := element.Value.(*entry[M])
+	e.usedAt = s.now()
+	s.recent.MoveToFront(element)
+	return append([]M{}, e.messages...), true
+}
+
+func (s *Store[M]) Set(threadID string, messages []M) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if element, ok := s.threads[threadID]; ok {
+		e := element.Value.(*entry[M])
+		e.messages, e.usedAt = messages, s.now()
+		s.recent.MoveToFront(element)
+	} else {
+		s.threads[threadID] = s.recent.PushFront(&entry[M]{threadID: threadID, messages: messages, usedAt: s.now()})
+	}
+	s.evict()
+}
+
+// Len returns the number of threads kept.
+func (s *Store[M]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.recent.Len()
+}
+
+// evict removes the expired threads and the least recently used ones.
+func (s *Store[M]) evict() {
+	for element := s.recent.Back(); element != nil; element = s.recent.Back() {
+		e := element.Value.(*entry[M])
+		expired := s.TTL > 0 && s.now().Sub(e.usedAt) > s.TTL
+		if !expired && (s.MaxThreads <= 0 || s.recent.Len() <= s.MaxThreads) {
+			return
+		}
+		s.recent.Remove(element)
+		delete(s.threads, e.threadID)
+	}
+}
+
+// Turns returns the persisted turns of the thread of the request, the ones
+// without an answer are skipped.
+func Turns(request types.AnalyzeRequest) ([]types.Turn, error) {
+	if request.History == nil {
+		return nil, nil
+	}
+	turns, err := request.History()
+	if err != nil {
+		return nil, err
+	}
+	answered := turns[:0]
+	for _, turn := range turns {
+		if turn.Input != "" && turn.Response != "" {
+			answered = append(answered, turn)
+		}
+	}
+	return answered, nil
+}
+
+// NewThreadID generates a random thread identifier.
+func NewThreadID() string {
+	return fmt.Sprintf("thread_%s", common.GenerateRandomString(threadIDLength))
+}
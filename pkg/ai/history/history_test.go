@@ -0,0 +1,59 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStoreEvictsLeastRecentlyUsed(t *testing.T) {
+	store := NewStore[string]()
+	store.MaxThreads = 2
+
+	store.Set("a", []string{"1"})
+	store.Set("b", []string{"2"})
+	store.Get("a")
+	store.Set("c", []string{"3"})
+
+	if _, ok := store.Get("b"); ok {
+		t.Errorf("the least recently used thread should be evicted")
+	}
+	if messages, ok := store.Get("a"); !ok || messages[0] != "1" {
+		t.Errorf("a used thread should be kept: got %v", messages)
+	}
+	if store.Len() != 2 {
+		t.Errorf("the store should keep %d threads: got %d", 2, store.Len())
+	}
+}
+
+func TestStoreEvictsExpired(t *testing.T) {
+	now := time.Now()
+	store := NewStore[string]()
+	store.TTL = time.Hour
+	store.now = func() time.Time { return now }
+
+	store.Set("old", []string{"1"})
+	now = now.Add(30 * time.Minute)
+	store.Set("recent", []string{"2"})
+	now = now.Add(45 * time.Minute)
+
+	if _, ok := store.Get("old"); ok {
+		t.Errorf("a thread unused for the TTL should be evicted")
+	}
+	if _, ok := store.Get("recent"); !ok {
+		t.Errorf("a recent thread should be kept")
+	}
+}
@@ -15,9 +15,17 @@
 package ai
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/matthisholleville/ava/internal/configuration"
+	"github.com/matthisholleville/ava/pkg/ai/anthropic"
+	"github.com/matthisholleville/ava/pkg/ai/completion"
+	"github.com/matthisholleville/ava/pkg/ai/ollama"
 	"github.com/matthisholleville/ava/pkg/ai/openai"
+	"github.com/matthisholleville/ava/pkg/ai/prompt"
+	"github.com/matthisholleville/ava/pkg/ai/scripted"
+	"github.com/matthisholleville/ava/pkg/ai/types"
 	"github.com/matthisholleville/ava/pkg/common"
 	"github.com/matthisholleville/ava/pkg/logger"
 )
@@ -30,6 +38,13 @@ func WithPassword(password string) Option {
 	}
 }
 
+// WithConfiguration sets the backend settings read from the ai section of the configuration.
+func WithConfiguration(configuration configuration.AI) Option {
+	return func(a *AIProvider) {
+		a.configuration = configuration
+	}
+}
+
 type IAI interface {
 	Configure(logger logger.ILogger) error
 	ConfigureKnowledge(logger logger.ILogger) error
@@ -38,11 +53,15 @@ type IAI interface {
 	UploadFiles(path []string) error
 	GetName() string
 	CreateThread() (*string, error)
-	Analyze(text, language string, threadID string, executorConfig common.Executor) (string, error)
+	Analyze(ctx context.Context, request types.AnalyzeRequest, executorConfig common.Executor) (types.AnalyzeResponse, error)
+	// AnalyzeStream is Analyze sending the tool calls, the tool outputs and
+	// the text of the answer to events as they happen. It does not close events.
+	AnalyzeStream(ctx context.Context, request types.AnalyzeRequest, executorConfig common.Executor, events chan<- types.Event) (types.AnalyzeResponse, error)
 }
 
 type AIProvider struct {
-	password string
+	password      string
+	configuration configuration.AI
 }
 
 func NewAI(backend string, opts ...Option) (IAI, error) {
@@ -53,14 +72,63 @@ func NewAI(backend string, opts ...Option) (IAI, error) {
 	for _, opt := range opts {
 		opt(aiProvider)
 	}
+
+	prompts, err := prompt.New(aiProvider.configuration.Prompts)
+	if err != nil {
+		return nil, err
+	}
+
 	switch backend {
 	case "openai":
+		apiKey := aiProvider.password
+		if apiKey == "" {
+			apiKey = aiProvider.configuration.OpenAI.APIKey
+		}
 		ai = &openai.OpenAIClient{
 			Configuration: openai.Configuration{
-				APIKey: aiProvider.password,
+				APIKey:      apiKey,
+				Model:       aiProvider.configuration.OpenAI.Model,
+				Temperature: aiProvider.configuration.OpenAI.Temperature,
+				TopP:        aiProvider.configuration.OpenAI.TopP,
+				MaxSteps:    aiProvider.configuration.OpenAI.MaxSteps,
+			},
+			Prompts: prompts,
+		}
+	case "completion":
+		ai = &completion.CompletionClient{
+			Configuration: completion.Configuration{
+				BaseURL:  aiProvider.configuration.Completion.BaseURL,
+				APIKey:   aiProvider.configuration.Completion.APIKey,
+				Model:    aiProvider.configuration.Completion.Model,
+				MaxSteps: aiProvider.configuration.Completion.MaxSteps,
 			},
+			Prompts: prompts,
 		}
+	case "anthropic":
+		ai = &anthropic.AnthropicClient{
+			Configuration: anthropic.Configuration{
+				BaseURL:   aiProvider.configuration.Anthropic.BaseURL,
+				APIKey:    aiProvider.configuration.Anthropic.APIKey,
+				Model:     aiProvider.configuration.Anthropic.Model,
+				MaxTokens: aiProvider.configuration.Anthropic.MaxTokens,
+				MaxSteps:  aiProvider.configuration.Anthropic.MaxSteps,
+			},
+			Prompts: prompts,
+		}
+	case "ollama":
+		ai = ollama.NewClient(ollama.Configuration{
+			BaseURL:  aiProvider.configuration.Ollama.BaseURL,
+			Model:    aiProvider.configuration.Ollama.Model,
+			MaxSteps: aiProvider.configuration.Ollama.MaxSteps,
+		}, prompts)
 
+	case "scripted":
+		ai = &scripted.ScriptedClient{
+			Configuration: scripted.Configuration{
+				Path: aiProvider.configuration.Scripted.Path,
+			},
+			Prompts: prompts,
+		}
 	default:
 		return nil, fmt.Errorf("backend %s not found", backend)
 	}
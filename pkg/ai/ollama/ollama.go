@@ -0,0 +1,65 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ollama
+
+import (
+	"github.com/matthisholleville/ava/pkg/ai/completion"
+	"github.com/matthisholleville/ava/pkg/ai/prompt"
+)
+
+const (
+	ollamaClientName = "ollama"
+	defaultBaseURL   = "http://localhost:11434/v1"
+	defaultModel     = "llama3.1"
+	// Ollama ignores the API key but the OpenAI client always sends one.
+	defaultAPIKey = "ollama"
+)
+
+// OllamaClient talks to a local Ollama server through its OpenAI-compatible
+// endpoint, so prompts, pod logs and secrets never leave the infrastructure.
+type OllamaClient struct {
+	completion.CompletionClient
+}
+
+type Configuration struct {
+	BaseURL  string `json:"baseURL"`
+	Model    string `json:"model"`
+	MaxSteps int    `json:"maxSteps"`
+}
+
+func NewClient(configuration Configuration, prompts *prompt.Prompts) *OllamaClient {
+	if configuration.BaseURL == "" {
+		configuration.BaseURL = defaultBaseURL
+	}
+	if configuration.Model == "" {
+		configuration.Model = defaultModel
+	}
+
+	return &OllamaClient{
+		CompletionClient: completion.CompletionClient{
+			Configuration: completion.Configuration{
+				BaseURL:  configuration.BaseURL,
+				APIKey:   defaultAPIKey,
+				Model:    configuration.Model,
+				MaxSteps: configuration.MaxSteps,
+			},
+			Prompts: prompts,
+		},
+	}
+}
+
+func (c *OllamaClient) GetName() string {
+	return ollamaClientName
+}
@@ -0,0 +1,100 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ollama
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/matthisholleville/ava/internal/testutil"
+	"github.com/matthisholleville/ava/pkg/ai/types"
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/sashabaranov/go-openai"
+	"github.com/spf13/viper"
+)
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name          string
+		configuration Configuration
+		baseURL       string
+		model         string
+	}{
+		{
+			name:    "defaults",
+			baseURL: defaultBaseURL,
+			model:   defaultModel,
+		},
+		{
+			name:          "custom",
+			configuration: Configuration{BaseURL: "http://ollama:11434/v1", Model: "qwen2.5", MaxSteps: 5},
+			baseURL:       "http://ollama:11434/v1",
+			model:         "qwen2.5",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := NewClient(test.configuration, testutil.Prompts(t))
+
+			configuration := client.CompletionClient.Configuration
+			if configuration.BaseURL != test.baseURL || configuration.Model != test.model {
+				t.Errorf("expected %s with %s, got %s with %s", test.baseURL, test.model, configuration.BaseURL, configuration.Model)
+			}
+			if configuration.APIKey != defaultAPIKey {
+				t.Errorf("no API key should be required, got %q", configuration.APIKey)
+			}
+			if configuration.MaxSteps != test.configuration.MaxSteps {
+				t.Errorf("expected %d max steps, got %d", test.configuration.MaxSteps, configuration.MaxSteps)
+			}
+			if client.GetName() != ollamaClientName {
+				t.Errorf("unexpected name %s", client.GetName())
+			}
+		})
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	server := testutil.NewJSONServer(t, func(r *http.Request, request openai.ChatCompletionRequest) any {
+		if r.URL.Path != "/chat/completions" || r.Header.Get("Authorization") != "Bearer "+defaultAPIKey {
+			t.Errorf("unexpected request %s %v", r.URL.Path, r.Header)
+		}
+		if request.Model != "qwen2.5" {
+			t.Errorf("expected the model qwen2.5, got %s", request.Model)
+		}
+		return openai.ChatCompletionResponse{
+			Choices: []openai.ChatCompletionChoice{{Message: openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: "The pod web is crashlooping."}}},
+			Usage:   openai.Usage{PromptTokens: 10, CompletionTokens: 5},
+		}
+	})
+
+	viper.Set("executors.common.enabled", true)
+	client := NewClient(Configuration{BaseURL: server.URL, Model: "qwen2.5"}, testutil.Prompts(t))
+	testutil.ConfigureAssistant(t, client)
+
+	threadID, err := client.CreateThread()
+	if err != nil {
+		t.Fatalf("unable to create a thread: %v", err)
+	}
+
+	response, err := client.Analyze(context.Background(), types.AnalyzeRequest{Message: "Pod web in namespace default Crashlooping.", ThreadID: *threadID}, common.Executor{})
+	if err != nil {
+		t.Fatalf("analyze returned an error: %v", err)
+	}
+	if response.Text != "The pod web is crashlooping." || response.Usage.Model != "qwen2.5" || response.Usage.PromptTokens != 10 {
+		t.Errorf("analyze returned wrong response: %+v", response)
+	}
+}
@@ -17,37 +17,56 @@ package openai
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"path/filepath"
 	"time"
 
+	"github.com/matthisholleville/ava/pkg/ai/prompt"
 	"github.com/matthisholleville/ava/pkg/ai/types"
 	"github.com/matthisholleville/ava/pkg/common"
 	"github.com/matthisholleville/ava/pkg/executors"
 	"github.com/matthisholleville/ava/pkg/logger"
-	"github.com/matthisholleville/ava/pkg/metrics"
 	"github.com/sashabaranov/go-openai"
 )
 
-const openaiClientName = "openai"
+const (
+	openaiClientName = "openai"
+	defaultMaxSteps  = 20
+	cancelRunTimeout = 10 * time.Second
+)
+
+// pollInterval is the interval between two retrievals of a run.
+var pollInterval = 1 * time.Second
 
 type OpenAIClient struct {
 	client          *openai.Client
 	Configuration   Configuration
+	Prompts         *prompt.Prompts
 	ctx             context.Context
 	logger          logger.ILogger
 	enableExecutors bool
+	executors       []string
 }
 
 type Configuration struct {
-	VectorID    string `json:"vectorID"`
-	AssistantID string `json:"assistantID"`
-	APIKey      string `json:"apiKey"`
+	VectorID    string   `json:"vectorID"`
+	AssistantID string   `json:"assistantID"`
+	APIKey      string   `json:"apiKey"`
+	Model       string   `json:"model"`
+	Temperature *float32 `json:"temperature"`
+	TopP        *float32 `json:"topP"`
+	MaxSteps    int      `json:"maxSteps"`
 }
 
 func (c *OpenAIClient) Configure(logger logger.ILogger) error {
 
+	if c.Configuration.Model == "" {
+		c.Configuration.Model = openai.GPT4o
+	}
+	if c.Configuration.MaxSteps <= 0 {
+		c.Configuration.MaxSteps = defaultMaxSteps
+	}
+
 	c.client = openai.NewClient(c.Configuration.APIKey)
 	c.ctx = context.Background()
 	c.logger = logger
@@ -73,25 +92,26 @@ func (c *OpenAIClient) getVectorStore() error {
 	return fmt.Errorf("vector store not found")
 }
 
-func (c *OpenAIClient) getPrompt(language, text string) string {
-
-	if c.enableExecutors {
-		return fmt.Sprintf(types.ANALYSE_AND_FIX_PROMPT, language, text)
-	}
-
-	return fmt.Sprintf(types.ANALYSE_PROMPT, language, text)
-
+func (c *OpenAIClient) Analyze(ctx context.Context, request types.AnalyzeRequest, executorConfig common.Executor) (types.AnalyzeResponse, error) {
+	return c.AnalyzeStream(ctx, request, executorConfig, nil)
 }
 
-func (c *OpenAIClient) Analyze(text, language string, threadID string, executorConfig common.Executor) (string, error) {
-	var response string
+// AnalyzeStream does not stream the text of the answer, it is sent as a
+// single delta once the run is completed. The usage of the run is returned
+// even if it failed.
+func (c *OpenAIClient) AnalyzeStream(ctx context.Context, request types.AnalyzeRequest, executorConfig common.Executor, events chan<- types.Event) (types.AnalyzeResponse, error) {
+	response := types.AnalyzeResponse{Usage: types.Usage{Model: c.Configuration.Model}}
+	threadID := request.ThreadID
 
-	c.logger.Info(fmt.Sprintf("Analyzing the message: %s", text))
+	c.logger.Info(fmt.Sprintf("Analyzing a message of %d bytes in the thread %s", len(request.Message), request.ThreadID))
 
-	inputMessage := c.getPrompt(language, text)
+	inputMessage, err := c.Prompts.Analyse(request, c.enableExecutors, c.executors)
+	if err != nil {
+		return response, err
+	}
 
 	c.logger.Debug("Creating a message")
-	_, err := c.createMessage(threadID, inputMessage)
+	_, err = c.createMessage(ctx, threadID, inputMessage)
 	if err != nil {
 		return response, err
 	}
@@ -99,108 +119,151 @@ func (c *OpenAIClient) Analyze(text, language string, threadID string, executorC
 	c.logger.Debug(fmt.Sprintf("Debug link: https://platform.openai.com/playground/assistants?assistant=%s&thread=%s", c.Configuration.AssistantID, threadID))
 
 	c.logger.Debug("Creating a run")
-	run, err := c.createRun(threadID)
+	run, err := c.createRun(ctx, threadID)
 	if err != nil {
 		return response, err
 	}
 
 	c.logger.Debug("Watching the run")
-	_, err = c.watchRun(executorConfig, threadID, run.ID)
+	run, err = c.watchRun(ctx, executorConfig, threadID, run.ID, events)
+	if run != nil {
+		response.Usage.Add(run.Usage.PromptTokens, run.Usage.CompletionTokens)
+	}
 	if err != nil {
 		return response, err
 	}
 
-	messages, err := c.listThreadMessage(threadID, run.ID)
+	messages, err := c.listThreadMessage(ctx, threadID, run.ID)
 	if err != nil {
 		return response, err
 	}
 
-	response = messages.Messages[0].Content[0].Text.Value
+	response.Text = messages.Messages[0].Content[0].Text.Value
+	types.Emit(events, types.TextDeltaEvent(response.Text))
 
 	return response, nil
 }
 
-func (c *OpenAIClient) listThreadMessage(threadId, runID string) (messages openai.MessagesList, err error) {
-	messages, err = c.client.ListMessage(c.ctx, threadId, nil, nil, nil, nil, &runID)
+func (c *OpenAIClient) listThreadMessage(ctx context.Context, threadId, runID string) (messages openai.MessagesList, err error) {
+	messages, err = c.client.ListMessage(ctx, threadId, nil, nil, nil, nil, &runID)
 	return messages, err
 }
 
-func (c *OpenAIClient) retrieveRun(threadId, runId string) (*openai.Run, error) {
-	response, err := c.client.RetrieveRun(c.ctx, threadId, runId)
+func (c *OpenAIClient) retrieveRun(ctx context.Context, threadId, runId string) (*openai.Run, error) {
+	response, err := c.client.RetrieveRun(ctx, threadId, runId)
 	return &response, err
 }
 
-func (c *OpenAIClient) watchRun(e common.Executor, threadId, runId string) (*openai.Run, error) {
-	completed := false
+// watchRun polls the run until it completes, executing the requested
+// functions. The remote run is cancelled when ctx is done or when the
+// maximum number of steps is reached. The run is returned with the error of
+// the terminal statuses.
+func (c *OpenAIClient) watchRun(ctx context.Context, e common.Executor, threadId, runId string, events chan<- types.Event) (*openai.Run, error) {
+	steps := 0
 
 	c.logger.Info("Analysis in progress...")
 
-	for !completed {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
 
-		time.Sleep(1 * time.Second)
+	for {
+		select {
+		case <-ctx.Done():
+			c.cancelRun(threadId, runId)
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
 
 		c.logger.Debug("Retrieving the run")
-		run, err := c.retrieveRun(threadId, runId)
+		run, err := c.retrieveRun(ctx, threadId, runId)
 		if err != nil {
+			if ctx.Err() != nil {
+				c.cancelRun(threadId, runId)
+			}
 			return nil, err
 		}
 
-		if run.Status == "failed" {
-			return nil, errors.New("run failed")
-		}
-
 		c.logger.Debug(fmt.Sprintf("Run status: %s", run.Status))
-		if run.Status == "requires_action" {
-			functionsToBeCalled := run.RequiredAction.SubmitToolOutputs
+		switch run.Status {
+		case openai.RunStatusCompleted:
+			return run, nil
+		case openai.RunStatusFailed:
+			return run, &types.RunError{Err: types.ErrRunFailed, Reason: runLastError(run)}
+		case openai.RunStatusCancelled:
+			return run, &types.RunError{Err: types.ErrRunCancelled}
+		case openai.RunStatusExpired:
+			return run, &types.RunError{Err: types.ErrRunExpired}
+		case openai.RunStatusIncomplete:
+			return run, &types.RunError{Err: types.ErrRunIncomplete, Reason: runLastError(run)}
+		case openai.RunStatusRequiresAction:
+			if steps >= c.Configuration.MaxSteps {
+				c.cancelRun(threadId, runId)
+				return run, &types.MaxStepsError{Steps: steps}
+			}
+			steps++
 
 			outputs := []openai.ToolOutput{}
-
-			executors := executors.GetExecutors()
-
-			for _, f := range functionsToBeCalled.ToolCalls {
+			for _, f := range run.RequiredAction.SubmitToolOutputs.ToolCalls {
 
 				c.logger.Info(fmt.Sprintf("Execution of the function : %s", f.Function.Name))
-				metrics.ExecutorCounter.WithLabelValues(f.Function.Name).Inc()
+				types.Emit(events, types.ToolCallEvent(f.Function.Name, f.Function.Arguments))
+				output := executors.Execute(e.WithToolCall(runId, f.ID), f.Function.Name, f.Function.Arguments)
+				types.Emit(events, types.ToolOutputEvent(f.Function.Name, output))
 				outputs = append(outputs, openai.ToolOutput{
 					ToolCallID: f.ID,
-					Output:     executors[f.Function.Name].Exec(e, f.Function.Arguments),
+					Output:     output,
 				})
 			}
 
 			c.logger.Debug("Submitting the tool outputs")
-			run, err = c.submitToolOutputs(threadId, run.ID, outputs)
+			_, err = c.submitToolOutputs(ctx, threadId, runId, outputs)
 			if err != nil {
+				if ctx.Err() != nil {
+					c.cancelRun(threadId, runId)
+				}
 				return nil, err
 			}
-			return c.watchRun(e, threadId, run.ID)
 		}
+	}
+}
 
-		if run.Status == "completed" {
-			return run, nil
-		}
+// cancelRun cancels the remote run. It does not use the context of the
+// analysis since it is usually already done.
+func (c *OpenAIClient) cancelRun(threadId, runId string) {
+	c.logger.Info(fmt.Sprintf("Cancelling the run %s", runId))
+	ctx, cancel := context.WithTimeout(context.Background(), cancelRunTimeout)
+	defer cancel()
 
+	_, err := c.client.CancelRun(ctx, threadId, runId)
+	if err != nil {
+		c.logger.Warn(fmt.Sprintf("Unable to cancel the run %s: %s", runId, err.Error()))
 	}
+}
 
-	return nil, errors.ErrUnsupported
+func runLastError(run *openai.Run) string {
+	if run.LastError == nil {
+		return ""
+	}
+	return run.LastError.Message
 }
 
-func (c *OpenAIClient) submitToolOutputs(threadId, runId string, outputs []openai.ToolOutput) (*openai.Run, error) {
-	run, err := c.client.SubmitToolOutputs(c.ctx, threadId, runId, openai.SubmitToolOutputsRequest{
+func (c *OpenAIClient) submitToolOutputs(ctx context.Context, threadId, runId string, outputs []openai.ToolOutput) (*openai.Run, error) {
+	run, err := c.client.SubmitToolOutputs(ctx, threadId, runId, openai.SubmitToolOutputsRequest{
 		ToolOutputs: outputs,
 	})
 	return &run, err
 }
 
-func (c *OpenAIClient) createMessage(threadId, content string) (*openai.Message, error) {
-	message, err := c.client.CreateMessage(c.ctx, threadId, openai.MessageRequest{
+func (c *OpenAIClient) createMessage(ctx context.Context, threadId, content string) (*openai.Message, error) {
+	message, err := c.client.CreateMessage(ctx, threadId, openai.MessageRequest{
 		Role:    "user",
 		Content: content,
 	})
 	return &message, err
 }
 
-func (c *OpenAIClient) createRun(threadId string) (*openai.Run, error) {
-	run, err := c.client.CreateRun(c.ctx, threadId, openai.RunRequest{
+func (c *OpenAIClient) createRun(ctx context.Context, threadId string) (*openai.Run, error) {
+	run, err := c.client.CreateRun(ctx, threadId, openai.RunRequest{
 		AssistantID: c.Configuration.AssistantID,
 	})
 	return &run, err
@@ -267,7 +330,17 @@ func (c *OpenAIClient) ConfigureAssistant(logger logger.ILogger, enableExecutors
 		return err
 	}
 	assistantName := types.ASSISTANT_NAME
-	assistantInstructions := types.ASSISTANT_INSTRUCTIONS
+
+	c.enableExecutors = enableExecutors
+	c.executors = nil
+	if enableExecutors {
+		c.executors = prompt.ExecutorNames(executors.GetExecutors())
+	}
+
+	assistantInstructions, err := c.Prompts.Instructions(c.executors)
+	if err != nil {
+		return err
+	}
 
 	for _, assistant := range assistants.Assistants {
 		if assistant.Name != nil && *assistant.Name == assistantName {
@@ -286,7 +359,7 @@ func (c *OpenAIClient) ConfigureAssistant(logger logger.ILogger, enableExecutors
 		c.logger.Debug("Creating the assistant")
 		assistant, err := c.client.CreateAssistant(c.ctx, openai.AssistantRequest{
 			Name:  &assistantName,
-			Model: openai.GPT4o,
+			Model: c.Configuration.Model,
 		})
 		if err != nil {
 			return err
@@ -305,10 +378,12 @@ func (c *OpenAIClient) ConfigureAssistant(logger logger.ILogger, enableExecutors
 		c.logger.Debug("Adding the executors to the assistant")
 		tools = append(tools, c.executorToFunctionTool()...)
 	}
-	c.enableExecutors = enableExecutors
 
 	c.logger.Debug("Modifying the assistant")
 	_, err = c.client.ModifyAssistant(c.ctx, c.Configuration.AssistantID, openai.AssistantRequest{
+		Model:        c.Configuration.Model,
+		Temperature:  c.Configuration.Temperature,
+		TopP:         c.Configuration.TopP,
 		Instructions: &assistantInstructions,
 		Tools:        tools,
 		ToolResources: &openai.AssistantToolResource{
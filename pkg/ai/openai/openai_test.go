@@ -0,0 +1,162 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/matthisholleville/ava/internal/testutil"
+	"github.com/matthisholleville/ava/pkg/ai/types"
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/sashabaranov/go-openai"
+)
+
+const (
+	testThreadID = "thread_1"
+	testRunID    = "run_1"
+)
+
+// newStubServer stubs the run endpoints of the Assistants API. The run is
+// always returned with the given status and the cancellations are counted.
+func newStubServer(t *testing.T, run openai.Run, cancelled *int32) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/threads/"+testThreadID+"/runs/"+testRunID, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(run)
+	})
+	mux.HandleFunc("/threads/"+testThreadID+"/runs/"+testRunID+"/cancel", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(cancelled, 1)
+		cancelledRun := run
+		cancelledRun.Status = openai.RunStatusCancelling
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(cancelledRun)
+	})
+	return httptest.NewServer(mux)
+}
+
+func newTestClient(baseURL string) *OpenAIClient {
+	config := openai.DefaultConfig("key")
+	config.BaseURL = baseURL
+	return &OpenAIClient{
+		client:        openai.NewClientWithConfig(config),
+		Configuration: Configuration{MaxSteps: defaultMaxSteps},
+		ctx:           context.Background(),
+		logger:        testutil.Logger(),
+	}
+}
+
+func TestWatchRunStatus(t *testing.T) {
+	pollInterval = time.Millisecond
+	defer func() { pollInterval = time.Second }()
+
+	tests := []struct {
+		name   string
+		run    openai.Run
+		err    error
+		reason string
+	}{
+		{
+			name: "completed",
+			run:  openai.Run{Status: openai.RunStatusCompleted},
+		},
+		{
+			name: "failed",
+			run: openai.Run{
+				Status:    openai.RunStatusFailed,
+				LastError: &openai.RunLastError{Code: openai.RunErrorServerError, Message: "server error"},
+			},
+			err:    types.ErrRunFailed,
+			reason: "server error",
+		},
+		{
+			name: "cancelled",
+			run:  openai.Run{Status: openai.RunStatusCancelled},
+			err:  types.ErrRunCancelled,
+		},
+		{
+			name: "expired",
+			run:  openai.Run{Status: openai.RunStatusExpired},
+			err:  types.ErrRunExpired,
+		},
+		{
+			name: "incomplete",
+			run:  openai.Run{Status: openai.RunStatusIncomplete},
+			err:  types.ErrRunIncomplete,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var cancelled int32
+			test.run.ID = testRunID
+			test.run.ThreadID = testThreadID
+			test.run.Usage = openai.Usage{PromptTokens: 10, CompletionTokens: 5}
+			server := newStubServer(t, test.run, &cancelled)
+			defer server.Close()
+
+			client := newTestClient(server.URL)
+			run, err := client.watchRun(context.Background(), common.Executor{}, testThreadID, testRunID, nil)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected the error %v, got %v", test.err, err)
+			}
+			if run == nil || run.Usage.PromptTokens != 10 {
+				t.Errorf("the run should be returned with its usage, got %+v", run)
+			}
+			if test.err != nil {
+				var runErr *types.RunError
+				if !errors.As(err, &runErr) {
+					t.Fatalf("expected a RunError, got %T", err)
+				}
+				if runErr.Reason != test.reason {
+					t.Errorf("expected the reason %q, got %q", test.reason, runErr.Reason)
+				}
+			}
+			if cancelled != 0 {
+				t.Errorf("a terminal run should not be cancelled")
+			}
+		})
+	}
+}
+
+func TestWatchRunCancel(t *testing.T) {
+	pollInterval = time.Millisecond
+	defer func() { pollInterval = time.Second }()
+
+	var cancelled int32
+	server := newStubServer(t, openai.Run{ID: testRunID, ThreadID: testThreadID, Status: openai.RunStatusInProgress}, &cancelled)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	client := newTestClient(server.URL)
+	_, err := client.watchRun(ctx, common.Executor{}, testThreadID, testRunID, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to be exceeded, got %v", err)
+	}
+	if atomic.LoadInt32(&cancelled) != 1 {
+		t.Errorf("the remote run should be cancelled once, got %d", cancelled)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompt
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/matthisholleville/ava/internal/configuration"
+	"github.com/matthisholleville/ava/pkg/ai/types"
+	"github.com/matthisholleville/ava/pkg/executors"
+)
+
+var funcs = template.FuncMap{
+	"join": strings.Join,
+}
+
+// Data is available in the instructions and analysis templates.
+type Data struct {
+	// Problem is the message sent by the user or the alert.
+	Problem  string
+	Language string
+	// Labels of the alert that triggered the analysis, if any.
+	Labels map[string]string
+	// Executors are the names of the enabled executors, sorted.
+	Executors []string
+}
+
+// Prompts renders the assistant instructions and the analysis prompts.
+type Prompts struct {
+	instructions  *template.Template
+	analyse       *template.Template
+	analyseAndFix *template.Template
+}
+
+// New parses the templates configured in ava.yaml, falling back on the
+// default prompts, and checks that they render.
+func New(config configuration.Prompts) (*Prompts, error) {
+	var err error
+	p := &Prompts{}
+
+	p.instructions, err = parse("instructions", config.Instructions, types.ASSISTANT_INSTRUCTIONS)
+	if err != nil {
+		return nil, err
+	}
+
+	p.analyse, err = parse("analyse", config.Analyse, types.ANALYSE_PROMPT)
+	if err != nil {
+		return nil, err
+	}
+
+	p.analyseAndFix, err = parse("analyseAndFix", config.AnalyseAndFix, types.ANALYSE_AND_FIX_PROMPT)
+	if err != nil {
+		return nil, err
+	}
+
+	sample := Data{
+		Problem:   "Pod web-server in namespace default Crashlooping.",
+		Language:  "en",
+		Labels:    map[string]string{"alertname": "KubePodCrashLooping"},
+		Executors: []string{"getPod"},
+	}
+	for _, t := range []*template.Template{p.instructions, p.analyse, p.analyseAndFix} {
+		if _, err := render(t, sample); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+func parse(name, path, defaultPrompt string) (*template.Template, error) {
+	text := defaultPrompt
+	if path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the %s prompt: %w", name, err)
+		}
+		text = string(content)
+	}
+
+	t, err := template.New(name).Funcs(funcs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s prompt: %w", name, err)
+	}
+	return t, nil
+}
+
+func render(t *template.Template, data Data) (string, error) {
+	var buffer bytes.Buffer
+	if err := t.Execute(&buffer, data); err != nil {
+		return "", fmt.Errorf("unable to render the %s prompt: %w", t.Name(), err)
+	}
+	return buffer.String(), nil
+}
+
+// Instructions returns the system instructions of the assistant.
+func (p *Prompts) Instructions(executors []string) (string, error) {
+	return render(p.instructions, Data{Executors: executors})
+}
+
+// Analyse returns the prompt of an analysis. The fix prompt is used when
+// executors are enabled.
+func (p *Prompts) Analyse(request types.AnalyzeRequest, enableExecutors bool, executors []string) (string, error) {
+	t := p.analyse
+	if enableExecutors {
+		t = p.analyseAndFix
+	}
+
+	return render(t, Data{
+		Problem:   request.Message,
+		Language:  request.Language,
+		Labels:    request.Labels,
+		Executors: executors,
+	})
+}
+
+// ExecutorNames returns the sorted names of the executors given to the model.
+func ExecutorNames(enabled map[string]executors.IExecutorV2) []string {
+	names := make([]string, 0, len(enabled))
+	for name := range enabled {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,180 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scripted
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	scriptsMu sync.Mutex
+	// scripts are shared by path so that the turns already played survive
+	// the creation of a new client by each API request.
+	scripts = map[string]*Script{}
+)
+
+// Script is the list of turns expected by the scripted backend, in order.
+// It can be written in YAML or JSON.
+type Script struct {
+	Turns []Turn `json:"turns"`
+
+	mu sync.Mutex
+	// played is the number of turns played.
+	played int
+	// deviation is the first deviation from the script, the script fails
+	// from then on.
+	deviation error
+}
+
+// Turn is one call to Analyze.
+type Turn struct {
+	// Input is a regular expression the message must match. An empty input matches any message.
+	Input string `json:"input"`
+	// Prompt is a regular expression the rendered prompt must match. An
+	// empty prompt matches any prompt.
+	Prompt    string     `json:"prompt"`
+	ToolCalls []ToolCall `json:"toolCalls"`
+	Response  string     `json:"response"`
+
+	inputRegexp  *regexp.Regexp
+	promptRegexp *regexp.Regexp
+}
+
+type ToolCall struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`
+	// Expect is a string the output of the executor must contain.
+	Expect string `json:"expect"`
+}
+
+func (t ToolCall) arguments() string {
+	if len(t.Arguments) == 0 {
+		return "{}"
+	}
+	return string(t.Arguments)
+}
+
+// LoadScript reads and validates the script at path. A script already
+// loaded from the same path is returned as is.
+func LoadScript(path string) (*Script, error) {
+	scriptsMu.Lock()
+	defer scriptsMu.Unlock()
+
+	if script, ok := scripts[path]; ok {
+		return script, nil
+	}
+
+	if path == "" {
+		return nil, fmt.Errorf("script path is required")
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read script %s: %w", path, err)
+	}
+
+	var script Script
+	err = yaml.Unmarshal(content, &script)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse script %s: %w", path, err)
+	}
+
+	if len(script.Turns) == 0 {
+		return nil, fmt.Errorf("script %s has no turns", path)
+	}
+
+	for i := range script.Turns {
+		script.Turns[i].inputRegexp, err = regexp.Compile(script.Turns[i].Input)
+		if err != nil {
+			return nil, fmt.Errorf("invalid input of turn %d in script %s: %w", i+1, path, err)
+		}
+		script.Turns[i].promptRegexp, err = regexp.Compile(script.Turns[i].Prompt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid prompt of turn %d in script %s: %w", i+1, path, err)
+		}
+		for _, f := range script.Turns[i].ToolCalls {
+			if f.Name == "" {
+				return nil, fmt.Errorf("tool call without name in turn %d of script %s", i+1, path)
+			}
+		}
+	}
+
+	scripts[path] = &script
+	return &script, nil
+}
+
+// next returns the next turn of the script if the message and the prompt
+// match it. The script fails on the first deviation.
+func (s *Script) next(message, prompt string) (*Turn, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.deviation != nil {
+		return nil, s.deviation
+	}
+	if s.played == len(s.Turns) {
+		return nil, s.deviate(fmt.Errorf("script deviation: no turn left for the message %q", message))
+	}
+
+	turn := &s.Turns[s.played]
+	if !turn.inputRegexp.MatchString(message) {
+		return nil, s.deviate(fmt.Errorf("script deviation: turn %d expects a message matching %q, got %q", s.played+1, turn.Input, message))
+	}
+	if !turn.promptRegexp.MatchString(prompt) {
+		return nil, s.deviate(fmt.Errorf("script deviation: turn %d expects a prompt matching %q, got %q", s.played+1, turn.Prompt, prompt))
+	}
+	s.played++
+	return turn, nil
+}
+
+// fail records a deviation found while playing a turn.
+func (s *Script) fail(err error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.deviate(err)
+}
+
+func (s *Script) deviate(err error) error {
+	if s.deviation == nil {
+		s.deviation = err
+	}
+	return err
+}
+
+func (s *Script) remaining() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.deviation != nil {
+		return s.deviation
+	}
+
+	var inputs []string
+	for _, turn := range s.Turns[s.played:] {
+		inputs = append(inputs, fmt.Sprintf("%q", turn.Input))
+	}
+
+	if len(inputs) > 0 {
+		return fmt.Errorf("script turns never played: %s", strings.Join(inputs, ", "))
+	}
+	return nil
+}
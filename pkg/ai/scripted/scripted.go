@@ -0,0 +1,156 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scripted
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/matthisholleville/ava/pkg/ai/history"
+	"github.com/matthisholleville/ava/pkg/ai/prompt"
+	"github.com/matthisholleville/ava/pkg/ai/types"
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/matthisholleville/ava/pkg/executors"
+	"github.com/matthisholleville/ava/pkg/logger"
+)
+
+const scriptedClientName = "scripted"
+
+var errKnowledgeNotSupported = errors.New("knowledge base is not supported by this AI backend")
+
+// ScriptedClient is a deterministic backend replaying a script of expected
+// inputs, tool calls and final answers, in order. Tool calls are sent to the
+// real executors and the first deviation from the script fails it.
+type ScriptedClient struct {
+	Configuration   Configuration
+	Prompts         *prompt.Prompts
+	script          *Script
+	logger          logger.ILogger
+	enableExecutors bool
+	executors       []string
+}
+
+type Configuration struct {
+	Path string `json:"path"`
+}
+
+func (c *ScriptedClient) Configure(logger logger.ILogger) error {
+	script, err := LoadScript(c.Configuration.Path)
+	if err != nil {
+		return err
+	}
+
+	c.script = script
+	c.logger = logger
+
+	return nil
+}
+
+func (c *ScriptedClient) ConfigureKnowledge(logger logger.ILogger) error {
+	return errKnowledgeNotSupported
+}
+
+func (c *ScriptedClient) ConfigureAssistant(logger logger.ILogger, enableExecutors bool) error {
+	err := c.Configure(logger)
+	if err != nil {
+		return err
+	}
+
+	c.enableExecutors = enableExecutors
+	c.executors = nil
+	if enableExecutors {
+		c.executors = prompt.ExecutorNames(executors.GetExecutors())
+	}
+	return nil
+}
+
+func (c *ScriptedClient) Purge() error {
+	return errKnowledgeNotSupported
+}
+
+func (c *ScriptedClient) UploadFiles(files []string) error {
+	return errKnowledgeNotSupported
+}
+
+func (c *ScriptedClient) GetName() string {
+	return scriptedClientName
+}
+
+func (c *ScriptedClient) CreateThread() (*string, error) {
+	threadID := history.NewThreadID()
+	return &threadID, nil
+}
+
+func (c *ScriptedClient) Analyze(ctx context.Context, request types.AnalyzeRequest, executorConfig common.Executor) (types.AnalyzeResponse, error) {
+	return c.AnalyzeStream(ctx, request, executorConfig, nil)
+}
+
+func (c *ScriptedClient) AnalyzeStream(ctx context.Context, request types.AnalyzeRequest, executorConfig common.Executor, events chan<- types.Event) (types.AnalyzeResponse, error) {
+	c.logger.Info(fmt.Sprintf("Analyzing a message of %d bytes in the thread %s", len(request.Message), request.ThreadID))
+
+	inputMessage, err := c.Prompts.Analyse(request, c.enableExecutors, c.executors)
+	if err != nil {
+		return types.AnalyzeResponse{}, err
+	}
+
+	turn, err := c.script.next(request.Message, inputMessage)
+	if err != nil {
+		c.logger.Error(err.Error())
+		return types.AnalyzeResponse{}, err
+	}
+
+	if len(turn.ToolCalls) > 0 && !c.enableExecutors {
+		err := c.script.fail(fmt.Errorf("script deviation: turn %q calls executors but executors are disabled", turn.Input))
+		c.logger.Error(err.Error())
+		return types.AnalyzeResponse{}, err
+	}
+
+	enabledExecutors := executors.GetExecutors()
+	for i, f := range turn.ToolCalls {
+		if err := ctx.Err(); err != nil {
+			return types.AnalyzeResponse{}, err
+		}
+
+		if _, ok := enabledExecutors[f.Name]; !ok {
+			err := c.script.fail(fmt.Errorf("script deviation: executor %s is not enabled", f.Name))
+			c.logger.Error(err.Error())
+			return types.AnalyzeResponse{}, err
+		}
+
+		c.logger.Info(fmt.Sprintf("Execution of the function : %s", f.Name))
+		types.Emit(events, types.ToolCallEvent(f.Name, f.arguments()))
+		output := executors.Execute(executorConfig.WithToolCall("", fmt.Sprintf("call_%d", i)), f.Name, f.arguments())
+		types.Emit(events, types.ToolOutputEvent(f.Name, output))
+
+		if f.Expect != "" && !strings.Contains(output, f.Expect) {
+			err := c.script.fail(fmt.Errorf("script deviation: output of %s does not contain %q: %s", f.Name, f.Expect, output))
+			c.logger.Error(err.Error())
+			return types.AnalyzeResponse{}, err
+		}
+	}
+
+	types.Emit(events, types.TextDeltaEvent(turn.Response))
+	return types.AnalyzeResponse{
+		Text:  turn.Response,
+		Usage: types.Usage{Model: scriptedClientName},
+	}, nil
+}
+
+// Remaining returns an error listing the turns of the script that were never played.
+func (c *ScriptedClient) Remaining() error {
+	return c.script.remaining()
+}
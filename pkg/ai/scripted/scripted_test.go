@@ -0,0 +1,148 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scripted
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matthisholleville/ava/internal/testutil"
+	"github.com/matthisholleville/ava/pkg/ai/types"
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/matthisholleville/ava/pkg/kubernetes"
+	"github.com/spf13/viper"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testScript = `
+turns:
+  - input: "web-server .* Crashlooping"
+    toolCalls:
+      - name: getPod
+        arguments:
+          podName: web-server
+          namespaceName: default
+        expect: '"name":"web-server"'
+    response: The pod web-server is crashlooping.
+`
+
+func newTestClient(t *testing.T, script string) *ScriptedClient {
+	viper.Set("executors.k8s.read", true)
+
+	path := filepath.Join(t.TempDir(), "script.yaml")
+	if err := os.WriteFile(path, []byte(script), 0600); err != nil {
+		t.Fatalf("unable to write the script: %v", err)
+	}
+
+	client := &ScriptedClient{Configuration: Configuration{Path: path}, Prompts: testutil.Prompts(t)}
+	testutil.ConfigureAssistant(t, client)
+	return client
+}
+
+func newTestExecutor() common.Executor {
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-server", Namespace: "default"}}
+	return common.Executor{
+		Client:  &kubernetes.Client{Client: fake.NewSimpleClientset(pod)},
+		Context: context.Background(),
+	}
+}
+
+func TestAnalyzeFollowsScript(t *testing.T) {
+	client := newTestClient(t, testScript)
+
+	response, err := client.Analyze(context.Background(), types.AnalyzeRequest{Message: "Pod web-server in namespace default Crashlooping.", Language: "en", ThreadID: "thread"}, newTestExecutor())
+	if err != nil {
+		t.Fatalf("analyze returned an error: %v", err)
+	}
+
+	if want := "The pod web-server is crashlooping."; response.Text != want {
+		t.Errorf("analyze returned wrong response: got %q want %q", response.Text, want)
+	}
+
+	if err := client.Remaining(); err != nil {
+		t.Errorf("all turns should have been played: %v", err)
+	}
+}
+
+func TestAnalyzeFailsOnDeviation(t *testing.T) {
+	client := newTestClient(t, testScript)
+
+	_, err := client.Analyze(context.Background(), types.AnalyzeRequest{Message: "Node worker-1 is NotReady.", Language: "en", ThreadID: "thread"}, newTestExecutor())
+	if err == nil {
+		t.Fatal("analyze should fail on an unexpected message")
+	}
+
+	if err := client.Remaining(); err == nil {
+		t.Error("the turn should not have been played")
+	}
+}
+
+func TestAnalyzeFollowsOrder(t *testing.T) {
+	script := `
+turns:
+  - input: "web-server"
+    prompt: "(?s)The problem: Pod web-server"
+    response: The pod web-server is crashlooping.
+  - input: "fixed"
+    response: Not yet.
+`
+	tests := []struct {
+		name     string
+		messages []string
+		failed   int
+	}{
+		{
+			name:     "in order",
+			messages: []string{"Pod web-server Crashlooping.", "Is it fixed?"},
+			failed:   -1,
+		},
+		{
+			name:     "out of order",
+			messages: []string{"Is it fixed?", "Pod web-server Crashlooping."},
+			failed:   0,
+		},
+		{
+			name:     "unexpected prompt",
+			messages: []string{"Crashlooping: web-server"},
+			failed:   0,
+		},
+		{
+			name:     "no turn left",
+			messages: []string{"Pod web-server Crashlooping.", "Is it fixed?", "Is it fixed now?"},
+			failed:   2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newTestClient(t, script)
+
+			for i, message := range test.messages {
+				_, err := client.Analyze(context.Background(), types.AnalyzeRequest{Message: message, ThreadID: "thread"}, newTestExecutor())
+				if failed := test.failed >= 0 && i >= test.failed; failed != (err != nil) {
+					t.Errorf("message %d: expected to fail: %t, got %v", i, failed, err)
+				}
+			}
+
+			if err := client.Remaining(); (err != nil) != (test.failed >= 0) {
+				t.Errorf("unexpected state of the script: %v", err)
+			}
+		})
+	}
+}
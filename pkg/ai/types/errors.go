@@ -0,0 +1,56 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Terminal statuses of a run that did not produce an answer.
+var (
+	ErrRunFailed     = errors.New("run failed")
+	ErrRunCancelled  = errors.New("run cancelled")
+	ErrRunExpired    = errors.New("run expired")
+	ErrRunIncomplete = errors.New("run incomplete")
+)
+
+// RunError is returned when a run reaches a terminal status without an
+// answer. It wraps one of the ErrRun errors.
+type RunError struct {
+	Err    error
+	Reason string
+}
+
+func (e *RunError) Error() string {
+	if e.Reason == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s: %s", e.Err.Error(), e.Reason)
+}
+
+func (e *RunError) Unwrap() error {
+	return e.Err
+}
+
+// MaxStepsError is returned when the model is still calling executors after
+// the maximum number of steps.
+type MaxStepsError struct {
+	Steps int
+}
+
+func (e *MaxStepsError) Error() string {
+	return fmt.Sprintf("Ava gave up after %d steps", e.Steps)
+}
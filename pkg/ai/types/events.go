@@ -0,0 +1,90 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+type EventType string
+
+const (
+	EventRunStarted EventType = "runStarted"
+	EventToolCall   EventType = "toolCall"
+	EventToolOutput EventType = "toolOutput"
+	EventTextDelta  EventType = "textDelta"
+	EventCompleted  EventType = "completed"
+	EventFailed     EventType = "failed"
+	// EventApprovalRequested is sent when a write executor waits for an
+	// approval.
+	EventApprovalRequested EventType = "approvalRequested"
+)
+
+// maxOutputSummaryLength is the length of the executor output sent in
+// toolOutput events.
+const maxOutputSummaryLength = 200
+
+// Event describes the progress of an analysis.
+type Event struct {
+	Type      EventType `json:"type"`
+	ThreadID  string    `json:"threadID,omitempty"`
+	Tool      string    `json:"tool,omitempty"`
+	Arguments string    `json:"arguments,omitempty"`
+	Output    string    `json:"output,omitempty"`
+	Text      string    `json:"text,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	ActionID  string    `json:"actionID,omitempty"`
+}
+
+// IsTerminal returns true if no event follows this one.
+func (e Event) IsTerminal() bool {
+	return e.Type == EventCompleted || e.Type == EventFailed
+}
+
+// Emit sends the event if events is not nil. Consumers must read the
+// channel until it is closed.
+func Emit(events chan<- Event, event Event) {
+	if events != nil {
+		events <- event
+	}
+}
+
+func ToolCallEvent(tool, arguments string) Event {
+	return Event{
+		Type:      EventToolCall,
+		Tool:      tool,
+		Arguments: arguments,
+	}
+}
+
+// ToolOutputEvent summarizes the output of the executor.
+func ToolOutputEvent(tool, output string) Event {
+	if len(output) > maxOutputSummaryLength {
+		output = fmt.Sprintf("%s... (%d bytes)", strings.ToValidUTF8(output[:maxOutputSummaryLength], ""), len(output))
+	}
+	return Event{
+		Type:   EventToolOutput,
+		Tool:   tool,
+		Output: output,
+	}
+}
+
+func TextDeltaEvent(text string) Event {
+	return Event{
+		Type: EventTextDelta,
+		Text: text,
+	}
+}
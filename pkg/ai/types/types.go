@@ -16,6 +16,9 @@ package types
 
 const VECTOR_STORE_NAME = "ava-sre-agent"
 const ASSISTANT_NAME = "ava-sre-agent"
+
+// ASSISTANT_INSTRUCTIONS, ANALYSE_AND_FIX_PROMPT and ANALYSE_PROMPT are the
+// default text/template prompts. See prompt.Data for the available fields.
 const ASSISTANT_INSTRUCTIONS = `
 You are an expert Site Reliability Engineer, tasked with helping
 the SRE team respond to and resolve incidents. Please use vector
@@ -42,15 +45,67 @@ var (
 	1. Check if a runbook exists in the file search. If it does, follow the instructions in that runbook to address the issue and try to solve it using the functions available to you.
 	2. If no runbook is available, inform the user that you will do your best to assist them using your general knowledge and the functions at your disposal.
 
-	You must respond to the user in %s with a detailed explanation of the steps that allowed you to understand and fix the problem.
+	You must respond to the user in {{ .Language }} with a detailed explanation of the steps that allowed you to understand and fix the problem.
+
+	The problem: {{ .Problem }}
+	{{- if .Labels }}
 
-	The problem: %s
+	The labels of the alert:
+	{{- range $name, $value := .Labels }}
+	- {{ $name }}: {{ $value }}
+	{{- end }}
+	{{- end }}
 	`
 
 	ANALYSE_PROMPT = `
 	Using the provided runbooks, help the user understand the problem and provide a detailed explanation of the steps he can follow to fix the problem.
 
-	You must respond to the user in %s.
+	You must respond to the user in {{ .Language }}.
 	
-	The problem: %s`
+	The problem: {{ .Problem }}
+	{{- if .Labels }}
+
+	The labels of the alert:
+	{{- range $name, $value := .Labels }}
+	- {{ $name }}: {{ $value }}
+	{{- end }}
+	{{- end }}`
 )
+
+// AnalyzeRequest is a message to analyze in a thread.
+type AnalyzeRequest struct {
+	Message  string
+	Language string
+	ThreadID string
+	// Labels of the alert that triggered the analysis, if any.
+	Labels map[string]string
+	// History returns the previous turns of the thread from the database,
+	// for the backends which lost the thread, e.g. after a restart or on
+	// another replica. It is nil without a database.
+	History func() ([]Turn, error)
+}
+
+// Turn is a message of a thread and the answer of Ava.
+type Turn struct {
+	Input    string
+	Response string
+}
+
+type AnalyzeResponse struct {
+	Text  string
+	Usage Usage
+}
+
+// Usage is the number of tokens consumed by an analysis.
+type Usage struct {
+	Model            string `json:"model"`
+	PromptTokens     int    `json:"promptTokens"`
+	CompletionTokens int    `json:"completionTokens"`
+	// Cost is the estimated cost in USD, computed from the price table of the model.
+	Cost float64 `json:"cost"`
+}
+
+func (u *Usage) Add(promptTokens, completionTokens int) {
+	u.PromptTokens += promptTokens
+	u.CompletionTokens += completionTokens
+}
@@ -0,0 +1,175 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/matthisholleville/ava/pkg/ai/types"
+	"github.com/matthisholleville/ava/pkg/approval"
+	"go.uber.org/zap"
+)
+
+// ResolveAction  Approve or reject an action, the approver is the caller.
+type ResolveAction struct {
+	Reason string `json:"reason,omitempty" example:"The pod is still serving traffic"`
+}
+
+// Action godoc
+// @Summary Approve an action
+// @Description used to approve an executor waiting for an approval
+// @Tags Action
+// @Accept json
+// @Produce json
+// @Router /actions/{id}/approve [post]
+// @Security BearerAuth
+//
+//	@Param		id	path	string				true	"ID"
+//	@Param		_			body	ResolveAction	false	"Reason"
+//
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+func (s *Server) approveActionHandler(echo echo.Context) error {
+	return s.resolveAction(echo, true)
+}
+
+// Action godoc
+// @Summary Reject an action
+// @Description used to reject an executor waiting for an approval, the reason is sent to Ava
+// @Tags Action
+// @Accept json
+// @Produce json
+// @Router /actions/{id}/reject [post]
+// @Security BearerAuth
+//
+//	@Param		id	path	string				true	"ID"
+//	@Param		_			body	ResolveAction	false	"Reason"
+//
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+func (s *Server) rejectActionHandler(echo echo.Context) error {
+	return s.resolveAction(echo, false)
+}
+
+func (s *Server) resolveAction(echo echo.Context, approved bool) error {
+	id := echo.Param("id")
+	var data ResolveAction
+
+	if err := echo.Bind(&data); err != nil {
+		s.logger.Error("reading the request body failed", zap.Error(err))
+		return s.ErrorResponseWithCode(echo, err.Error(), http.StatusBadRequest)
+	}
+
+	if err := s.approvals.Resolve(id, approved, caller(echo), data.Reason); err != nil {
+		if errors.Is(err, approval.ErrActionNotFound) {
+			return s.ErrorResponseWithCode(echo, err.Error(), http.StatusNotFound)
+		}
+		return s.ErrorResponseWithCode(echo, err.Error(), http.StatusInternalServerError)
+	}
+
+	return s.JSONResponseWithCode(echo, fmt.Sprintf("action %s %s", id, decisionStatus(approved)), http.StatusOK)
+}
+
+// Event godoc
+// @Summary Receive a Slack interaction
+// @Description used to approve or reject an action with the buttons of the Slack approval message
+// @Tags Event
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Router /event/slack/interactions [post]
+//
+//	@Param		payload	formData	string	true	"Interaction payload"
+//
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+func (s *Server) slackInteractionHandler(echo echo.Context) error {
+	s.logger.Info("Receiving a Slack interaction")
+
+	interaction, err := s.eventClient.ProcessInteraction(echo.FormValue("payload"))
+	if err != nil {
+		s.logger.Warn(fmt.Sprintf("Interaction ignored: %s", err.Error()))
+		return s.ErrorResponseWithCode(echo, err.Error(), http.StatusBadRequest)
+	}
+
+	slackValidationToken := os.Getenv("SLACK_VALIDATION_TOKEN")
+	if slackValidationToken == "" || interaction.Token != slackValidationToken {
+		s.logger.Error("Invalid slack token")
+		return s.ErrorResponseWithCode(echo, "Invalid token", http.StatusBadRequest)
+	}
+
+	by := fmt.Sprintf("<@%s>", interaction.User)
+	message := fmt.Sprintf("Action %s %s by %s", interaction.ActionID, decisionStatus(interaction.Approved), by)
+	if err := s.approvals.Resolve(interaction.ActionID, interaction.Approved, by, ""); err != nil {
+		message = fmt.Sprintf("Action %s: %s", interaction.ActionID, err.Error())
+	}
+
+	if err := s.eventClient.UpdateApprovalMessage(interaction, message); err != nil {
+		s.logger.Error("updating the approval message failed", zap.Error(err))
+	}
+
+	return s.JSONResponseWithCode(echo, message, http.StatusOK)
+}
+
+func decisionStatus(approved bool) string {
+	if approved {
+		return approval.StatusApproved
+	}
+	return approval.StatusRejected
+}
+
+// approvalNotifier publishes the actions waiting for an approval on the
+// stream of the chat, they are approved with the actions API.
+func (s *Server) approvalNotifier() approval.Notifier {
+	return approval.NotifierFunc(func(action approval.Action) error {
+		s.logger.Info(fmt.Sprintf("Action %s waits for an approval: POST /actions/%s/approve or /actions/%s/reject", action.ID, action.ID, action.ID))
+		s.streams.publish(action.ThreadID, types.Event{
+			Type:      types.EventApprovalRequested,
+			ThreadID:  action.ThreadID,
+			Tool:      action.Executor,
+			Arguments: action.Arguments,
+			ActionID:  action.ID,
+		})
+		return nil
+	})
+}
+
+// slackApprovalNotifier also asks for an approval in the Slack thread.
+func (s *Server) slackApprovalNotifier(channelID, ts string) approval.Notifier {
+	return approval.NotifierFunc(func(action approval.Action) error {
+		if err := s.approvalNotifier().Notify(action); err != nil {
+			return err
+		}
+		return s.eventClient.SendApprovalMessage(channelID, ts, action.ID, approvalMessage(action, s.approvals.Timeout))
+	})
+}
+
+func approvalMessage(action approval.Action, timeout time.Duration) string {
+	return fmt.Sprintf(
+		"*Ava wants to run %s*\n```%s```\nRequested by %s. Without a decision within %s, the default outcome applies.",
+		action.Executor,
+		action.Arguments,
+		action.Requester,
+		timeout,
+	)
+}
@@ -0,0 +1,67 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/matthisholleville/ava/internal/configuration"
+	"github.com/matthisholleville/ava/pkg/approval"
+)
+
+func TestResolveActionRecordsCaller(t *testing.T) {
+	srv := NewMockServer()
+	srv.avaCfg = &configuration.Configuration{
+		API:       configuration.API{Tokens: map[string]string{"jane": "secret"}},
+		Executors: configuration.Executors{Approval: configuration.Approval{Enabled: true}},
+	}
+	srv.registerHandlers()
+
+	// The action is approved from the notifier with the ID published on the
+	// stream, the body claims another approver.
+	notifier := approval.NotifierFunc(func(action approval.Action) error {
+		go func() {
+			for _, authorization := range []string{"", "Bearer secret"} {
+				req := httptest.NewRequest(http.MethodPost, "/actions/"+action.ID+"/approve", strings.NewReader(`{"by":"john"}`))
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				if authorization != "" {
+					req.Header.Set(echo.HeaderAuthorization, authorization)
+				}
+				rec := httptest.NewRecorder()
+				srv.router.ServeHTTP(rec, req)
+
+				expected := http.StatusUnauthorized
+				if authorization != "" {
+					expected = http.StatusOK
+				}
+				if status := rec.Code; status != expected {
+					t.Errorf("handler returned wrong status code: got %v want %v", status, expected)
+				}
+			}
+		}()
+		return nil
+	})
+
+	decision := srv.approvals.Request(context.Background(), approval.Action{Executor: "deletePod"}, notifier)
+
+	if !decision.Approved || decision.By != "jane" {
+		t.Errorf("the name of the token should be recorded as the approver: got %+v", decision)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/matthisholleville/ava/pkg/executors/limits"
+)
+
+type WriteExecutorsStatus struct {
+	Disabled bool `json:"disabled"`
+}
+
+// Admin godoc
+// @Summary Status of the write executors
+// @Description used to know if the kill switch of the write executors is on
+// @Tags Admin
+// @Produce json
+// @Router /admin/executors/write [get]
+// @Security BearerAuth
+//
+// @Success 200 {object} WriteExecutorsStatus
+// @Failure 500 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+func (s *Server) writeExecutorsStatusHandler(echo echo.Context) error {
+	disabled, err := limits.Default().WriteDisabled(echo.Request().Context())
+	if err != nil {
+		s.logger.Error(err.Error())
+		return s.ErrorResponseWithCode(echo, err.Error(), http.StatusInternalServerError)
+	}
+
+	return echo.JSONPretty(http.StatusOK, WriteExecutorsStatus{Disabled: disabled}, "")
+}
+
+// Admin godoc
+// @Summary Disable the write executors
+// @Description used to disable the write executors of every replica immediately, the kill switch
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Router /admin/executors/write/disable [post]
+// @Security BearerAuth
+//
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+func (s *Server) disableWriteExecutorsHandler(echo echo.Context) error {
+	return s.toggleWriteExecutors(echo, true)
+}
+
+// Admin godoc
+// @Summary Enable the write executors
+// @Description used to enable the write executors again
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Router /admin/executors/write/enable [post]
+// @Security BearerAuth
+//
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+func (s *Server) enableWriteExecutorsHandler(echo echo.Context) error {
+	return s.toggleWriteExecutors(echo, false)
+}
+
+func (s *Server) toggleWriteExecutors(echo echo.Context, disabled bool) error {
+	by := caller(echo)
+	if err := limits.Default().SetWriteDisabled(echo.Request().Context(), disabled, by); err != nil {
+		s.logger.Error(err.Error())
+		return s.ErrorResponseWithCode(echo, err.Error(), http.StatusInternalServerError)
+	}
+
+	state := "enabled"
+	if disabled {
+		state = "disabled"
+	}
+	s.logger.Warn(fmt.Sprintf("Write executors %s by %s", state, by))
+	return s.JSONResponseWithCode(echo, fmt.Sprintf("write executors %s", state), http.StatusOK)
+}
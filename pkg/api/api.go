@@ -25,6 +25,13 @@ import (
 	_ "github.com/matthisholleville/ava/docs"
 	"github.com/matthisholleville/ava/internal/configuration"
 	db "github.com/matthisholleville/ava/internal/prisma"
+	"github.com/matthisholleville/ava/pkg/ai/prompt"
+	"github.com/matthisholleville/ava/pkg/approval"
+	"github.com/matthisholleville/ava/pkg/audit"
+	"github.com/matthisholleville/ava/pkg/executors"
+	"github.com/matthisholleville/ava/pkg/executors/limits"
+	"github.com/matthisholleville/ava/pkg/executors/plugin"
+	"github.com/matthisholleville/ava/pkg/executors/policy"
 	"github.com/prometheus/client_golang/prometheus"
 	echoSwagger "github.com/swaggo/echo-swagger"
 
@@ -46,6 +53,11 @@ import (
 // @BasePath /
 // @schemes http https
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description A token of api.tokens, as "Bearer <token>".
+
 var (
 	healthy int32
 	ready   int32
@@ -72,15 +84,55 @@ type Server struct {
 	aiBackend         string
 	aiBackendPassword string
 	enableExecutors   bool
+	streams           *streamHub
+	approvals         *approval.Manager
+	audit             *audit.Recorder
 }
 
 func NewServer(config *Config, logger logger.ILogger, avaCfg *configuration.Configuration) (*Server, error) {
 
+	if err := avaCfg.AI.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := prompt.New(avaCfg.AI.Prompts); err != nil {
+		return nil, err
+	}
+
+	if err := avaCfg.Executors.Approval.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := policy.New(avaCfg.Executors.Policy); err != nil {
+		return nil, err
+	}
+
+	if err := limits.Validate(avaCfg.Executors.Limits); err != nil {
+		return nil, err
+	}
+
+	if err := executors.ValidateCustom(avaCfg.Executors); err != nil {
+		return nil, err
+	}
+
+	if err := plugin.Validate(avaCfg.Executors.Plugins); err != nil {
+		return nil, err
+	}
+
 	dbClient := db.NewClient()
 	if err := dbClient.Prisma.Connect(); err != nil {
 		return nil, err
 	}
 
+	// The limits and the kill switch are shared by the replicas.
+	limits.Configure(limits.NewDBStore(dbClient))
+
+	plugins, err := plugin.Start(avaCfg.Executors.Plugins, logger)
+	if err != nil {
+		return nil, err
+	}
+	plugin.Configure(plugins)
+
 	eventClient, err := events.GetClient(avaCfg.Events.Type)
 	if err != nil {
 		return nil, err
@@ -93,6 +145,11 @@ func NewServer(config *Config, logger logger.ILogger, avaCfg *configuration.Conf
 		}
 	}
 
+	approvals := approval.NewManager(avaCfg.Executors.Approval, logger, dbClient)
+	if err := approvals.Recover(context.Background()); err != nil {
+		logger.Warn("Expiring the pending actions failed", zap.Error(err))
+	}
+
 	srv := &Server{
 		router:            echo.New(),
 		logger:            logger,
@@ -101,9 +158,12 @@ func NewServer(config *Config, logger logger.ILogger, avaCfg *configuration.Conf
 		db:                dbClient,
 		eventClient:       eventClient,
 		avaCfg:            avaCfg,
-		aiBackend:         avaCfg.AI.Type,
+		aiBackend:         avaCfg.AI.Backend(),
 		aiBackendPassword: avaCfg.AI.OpenAI.APIKey,
 		enableExecutors:   avaCfg.Executors.Enabled,
+		streams:           newStreamHub(),
+		approvals:         approvals,
+		audit:             audit.NewRecorder(dbClient, logger),
 	}
 
 	return srv, nil
@@ -124,6 +184,7 @@ func (s *Server) registerHandlers() {
 		chat.POST("", s.createChatHandler)
 		chat.POST("/webhook", s.alertManagerWebhookChatHandler)
 		chat.GET("/:id", s.fetchChatHandler)
+		chat.GET("/:id/stream", s.streamChatHandler)
 		chat.POST("/:id", s.respondChatHandler)
 	}
 
@@ -132,9 +193,30 @@ func (s *Server) registerHandlers() {
 		event := s.router.Group("/event")
 		if s.avaCfg.Events.Type == "slack" {
 			event.POST("/slack", s.slackEventHandler)
+			event.POST("/slack/interactions", s.slackInteractionHandler)
 		}
 	}
 
+	if s.avaCfg.API.Admin.Enabled {
+		s.logger.Debug("Admin API enabled")
+		admin := s.authenticatedGroup("/admin")
+		admin.GET("/executors/write", s.writeExecutorsStatusHandler)
+		admin.POST("/executors/write/disable", s.disableWriteExecutorsHandler)
+		admin.POST("/executors/write/enable", s.enableWriteExecutorsHandler)
+	}
+
+	if s.avaCfg.API.Audit.Enabled {
+		s.logger.Debug("Audit API enabled")
+		s.authenticatedGroup("/audit").GET("", s.listAuditHandler)
+	}
+
+	if s.avaCfg.Executors.Approval.Enabled {
+		s.logger.Debug("Actions API enabled")
+		actions := s.authenticatedGroup("/actions")
+		actions.POST("/:id/approve", s.approveActionHandler)
+		actions.POST("/:id/reject", s.rejectActionHandler)
+	}
+
 	if s.avaCfg.API.Knowledge.Enabled {
 		s.logger.Debug("Knowledge API enabled")
 		knowledge := s.router.Group("/knowledge")
@@ -0,0 +1,105 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/matthisholleville/ava/pkg/audit"
+	"github.com/matthisholleville/ava/pkg/common"
+)
+
+type AuditResponse struct {
+	Calls []audit.Entry `json:"calls"`
+}
+
+// Audit godoc
+// @Summary List the executor calls
+// @Description used to know what Ava did in the cluster, the most recent calls first. With format=jsonl, the calls are exported as JSON lines.
+// @Tags Audit
+// @Produce json
+// @Produce application/x-ndjson
+// @Router /audit [get]
+// @Security BearerAuth
+//
+//	@Param		threadId	query	string	false	"Thread ID"
+//	@Param		executor	query	string	false	"Executor name"
+//	@Param		since		query	string	false	"RFC3339 time or duration before now, e.g. 24h"
+//	@Param		until		query	string	false	"RFC3339 time or duration before now"
+//	@Param		limit		query	int		false	"Maximum number of calls, 1000 at most"
+//	@Param		format		query	string	false	"json or jsonl"
+//
+// @Success 200 {object} AuditResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+func (s *Server) listAuditHandler(echo echo.Context) error {
+	filter, err := auditFilter(echo)
+	if err != nil {
+		return s.ErrorResponseWithCode(echo, err.Error(), http.StatusBadRequest)
+	}
+
+	entries, err := s.audit.List(echo.Request().Context(), filter)
+	if err != nil {
+		s.logger.Error(err.Error())
+		return s.ErrorResponseWithCode(echo, err.Error(), http.StatusInternalServerError)
+	}
+
+	switch echo.QueryParam("format") {
+	case "", "json":
+		return echo.JSONPretty(http.StatusOK, AuditResponse{Calls: entries}, "")
+	case "jsonl":
+		echo.Response().Header().Set("Content-Type", "application/x-ndjson")
+		echo.Response().WriteHeader(http.StatusOK)
+		return audit.WriteJSONLines(echo.Response(), entries)
+	default:
+		return s.ErrorResponseWithCode(echo, "format must be json or jsonl", http.StatusBadRequest)
+	}
+}
+
+func auditFilter(echo echo.Context) (audit.Filter, error) {
+	filter := audit.Filter{
+		ThreadID: echo.QueryParam("threadId"),
+		Executor: echo.QueryParam("executor"),
+	}
+
+	now := time.Now()
+	var err error
+	if filter.Since, err = audit.ParseTime(echo.QueryParam("since"), now); err != nil {
+		return filter, err
+	}
+	if filter.Until, err = audit.ParseTime(echo.QueryParam("until"), now); err != nil {
+		return filter, err
+	}
+
+	if limit := echo.QueryParam("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil {
+			return filter, err
+		}
+	}
+	return filter, nil
+}
+
+// auditor returns the recorder of the executor calls, nil without a
+// database.
+func (s *Server) auditor() common.Auditor {
+	if s.audit == nil {
+		return nil
+	}
+	return s.audit
+}
@@ -0,0 +1,59 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// callerKey is the key of the name of the authenticated caller in the
+// context of the request.
+const callerKey = "caller"
+
+// authenticate lets through the requests with a bearer token of api.tokens.
+// The requests are refused when no token is configured.
+func (s *Server) authenticate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+		if ok && token != "" {
+			for name, expected := range s.avaCfg.API.Tokens {
+				if expected != "" && subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1 {
+					c.Set(callerKey, name)
+					return next(c)
+				}
+			}
+		}
+		return s.ErrorResponseWithCode(c, "invalid or missing token", http.StatusUnauthorized)
+	}
+}
+
+// authenticatedGroup returns a group of routes behind authenticate.
+func (s *Server) authenticatedGroup(prefix string) *echo.Group {
+	if len(s.avaCfg.API.Tokens) == 0 {
+		s.logger.Warn(fmt.Sprintf("%s is enabled but api.tokens is empty, every request will be refused", prefix))
+	}
+	return s.router.Group(prefix, s.authenticate)
+}
+
+// caller returns the name of the token of the authenticated request.
+func caller(c echo.Context) string {
+	name, _ := c.Get(callerKey).(string)
+	return name
+}
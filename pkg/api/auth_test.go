@@ -0,0 +1,134 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/matthisholleville/ava/internal/configuration"
+)
+
+func TestAuthenticate(t *testing.T) {
+	tests := []struct {
+		name          string
+		tokens        map[string]string
+		authorization string
+		status        int
+		caller        string
+	}{
+		{
+			name:   "no token configured",
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:          "empty token configured",
+			tokens:        map[string]string{"jane": ""},
+			authorization: "Bearer ",
+			status:        http.StatusUnauthorized,
+		},
+		{
+			name:   "missing header",
+			tokens: map[string]string{"jane": "secret"},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:          "wrong token",
+			tokens:        map[string]string{"jane": "secret"},
+			authorization: "Bearer wrong",
+			status:        http.StatusUnauthorized,
+		},
+		{
+			name:          "not a bearer token",
+			tokens:        map[string]string{"jane": "secret"},
+			authorization: "secret",
+			status:        http.StatusUnauthorized,
+		},
+		{
+			name:          "valid token",
+			tokens:        map[string]string{"jane": "secret", "john": "other"},
+			authorization: "Bearer secret",
+			status:        http.StatusOK,
+			caller:        "jane",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := NewMockServer()
+			srv.avaCfg = &configuration.Configuration{API: configuration.API{Tokens: test.tokens}}
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/executors/write", nil)
+			if test.authorization != "" {
+				req.Header.Set(echo.HeaderAuthorization, test.authorization)
+			}
+			rec := httptest.NewRecorder()
+
+			var called string
+			handler := srv.authenticate(func(c echo.Context) error {
+				called = caller(c)
+				return c.NoContent(http.StatusOK)
+			})
+			if err := handler(srv.router.NewContext(req, rec)); err != nil {
+				t.Fatalf("handler returned an error: %v", err)
+			}
+			if status := rec.Code; status != test.status {
+				t.Errorf("handler returned wrong status code: got %v want %v", status, test.status)
+			}
+			if called != test.caller {
+				t.Errorf("expected the caller %q, got %q", test.caller, called)
+			}
+		})
+	}
+}
+
+func TestAuthenticatedRoutes(t *testing.T) {
+	srv := NewMockServer()
+	srv.avaCfg = &configuration.Configuration{
+		API: configuration.API{
+			Admin:  configuration.AdminAPI{Enabled: true},
+			Audit:  configuration.AuditAPI{Enabled: true},
+			Tokens: map[string]string{"jane": "secret"},
+		},
+		Executors: configuration.Executors{Approval: configuration.Approval{Enabled: true}},
+	}
+	srv.registerHandlers()
+
+	routes := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, "/admin/executors/write"},
+		{http.MethodPost, "/admin/executors/write/disable"},
+		{http.MethodPost, "/admin/executors/write/enable"},
+		{http.MethodPost, "/actions/1/approve"},
+		{http.MethodPost, "/actions/1/reject"},
+		{http.MethodGet, "/audit"},
+	}
+
+	for _, test := range routes {
+		t.Run(test.method+" "+test.path, func(t *testing.T) {
+			req := httptest.NewRequest(test.method, test.path, nil)
+			rec := httptest.NewRecorder()
+			srv.router.ServeHTTP(rec, req)
+
+			if status := rec.Code; status != http.StatusUnauthorized {
+				t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusUnauthorized)
+			}
+		})
+	}
+}
@@ -15,11 +15,13 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"slices"
 
 	"github.com/labstack/echo/v4"
+	db "github.com/matthisholleville/ava/internal/prisma"
 	"github.com/matthisholleville/ava/pkg/chat"
 	"github.com/matthisholleville/ava/pkg/metrics"
 	"go.uber.org/zap"
@@ -29,6 +31,8 @@ import (
 type CreateNewChat struct {
 	Message  string `json:"message" example:"Pod web-server-5b866987d8-sxmtj in namespace default Crashlooping."`
 	Language string `json:"language,omitempty" example:"en"`
+	// DryRun simulates the write executors, the answer lists the planned actions.
+	DryRun bool `json:"dryRun,omitempty" example:"false"`
 }
 
 type Alert struct {
@@ -84,12 +88,15 @@ func (s *Server) alertManagerWebhookChatHandler(echo echo.Context) error {
 
 	chat, err := chat.NewChat(
 		s.aiBackend,
-		s.aiBackendPassword,
+		s.avaCfg.AI,
 		s.logger,
 		chat.WithLanguage("en"),
 		chat.WithDbClient(s.db),
-		chat.WithPersist(true),
+		chat.WithPersist(s.db != nil),
 		chat.WithConfigureAssistant(s.logger, s.enableExecutors),
+		chat.WithApproval(s.approvals, "alertmanager", s.approvalNotifier()),
+		chat.WithAudit(s.auditor()),
+		chat.WithDryRun(s.avaCfg.Executors.DryRun || s.avaCfg.API.Chat.Webhook.DryRun),
 	)
 	if err != nil {
 		s.logger.Fatal(err.Error())
@@ -121,7 +128,7 @@ func (s *Server) alertManagerWebhookChatHandler(echo echo.Context) error {
 
 		go func() {
 			chatType := "webhook"
-			response, err := chat.Chat(message, threadID)
+			response, err := chat.ChatStream(message, threadID, alert.Labels, s.chatEvents(threadID))
 			if err != nil {
 				metrics.ChatCounter.WithLabelValues("error", chatType).Inc()
 				s.logger.Error(err.Error())
@@ -178,12 +185,15 @@ func (s *Server) createChatHandler(echo echo.Context) error {
 
 	chat, err := chat.NewChat(
 		s.aiBackend,
-		s.aiBackendPassword,
+		s.avaCfg.AI,
 		s.logger,
 		chat.WithLanguage(data.Language),
 		chat.WithDbClient(s.db),
-		chat.WithPersist(true),
+		chat.WithPersist(s.db != nil),
 		chat.WithConfigureAssistant(s.logger, s.enableExecutors),
+		chat.WithApproval(s.approvals, "api", s.approvalNotifier()),
+		chat.WithAudit(s.auditor()),
+		chat.WithDryRun(s.avaCfg.Executors.DryRun || data.DryRun),
 	)
 	if err != nil {
 		s.logger.Fatal(err.Error())
@@ -200,7 +210,7 @@ func (s *Server) createChatHandler(echo echo.Context) error {
 
 	go func() {
 		chatType := "chat"
-		response, err := chat.Chat(data.Message, threadID)
+		response, err := chat.ChatStream(data.Message, threadID, nil, s.chatEvents(threadID))
 		if err != nil {
 			metrics.ChatCounter.WithLabelValues("error", chatType).Inc()
 			s.logger.Error(err.Error())
@@ -223,8 +233,23 @@ func (s *Server) createChatHandler(echo echo.Context) error {
 }
 
 type FetchMessagesResponse struct {
-	Chat     string `json:"chat"`
-	Response string `json:"response"`
+	Chat             string `json:"chat"`
+	Response         string `json:"response"`
+	Model            string `json:"model"`
+	PromptTokens     int    `json:"promptTokens"`
+	CompletionTokens int    `json:"completionTokens"`
+	// Cost is the estimated cost in USD.
+	Cost float64 `json:"cost"`
+}
+
+// FetchChatResponse is the thread with its messages and the totals of its
+// analyses.
+type FetchChatResponse struct {
+	Messages         []FetchMessagesResponse `json:"messages"`
+	PromptTokens     int                     `json:"promptTokens"`
+	CompletionTokens int                     `json:"completionTokens"`
+	// Cost is the estimated cost in USD.
+	Cost float64 `json:"cost"`
 }
 
 // Chat godoc
@@ -237,14 +262,15 @@ type FetchMessagesResponse struct {
 //
 //	@Param		id	path	string				true	"ID"
 //
-// @Success 200 {object} FetchMessagesResponse
+// @Success 200 {object} FetchChatResponse
 // @Failure 500 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
 // @Failure 400 {object} ErrorResponse
 func (s *Server) fetchChatHandler(echo echo.Context) error {
 	id := echo.Param("id")
 	chat, err := chat.NewChat(
 		s.aiBackend,
-		s.aiBackendPassword,
+		s.avaCfg.AI,
 		s.logger,
 		chat.WithDbClient(s.db),
 	)
@@ -252,6 +278,14 @@ func (s *Server) fetchChatHandler(echo echo.Context) error {
 		s.logger.Fatal(err.Error())
 		return s.ErrorResponseWithCode(echo, err.Error(), http.StatusInternalServerError)
 	}
+	thread, err := chat.GetThread(id)
+	if errors.Is(err, db.ErrNotFound) {
+		return s.ErrorResponseWithCode(echo, fmt.Sprintf("thread %s not found", id), http.StatusNotFound)
+	}
+	if err != nil {
+		s.logger.Error(err.Error())
+		return s.ErrorResponseWithCode(echo, err.Error(), http.StatusInternalServerError)
+	}
 	messages, err := chat.FetchChatMessages(id)
 	if err != nil {
 		s.logger.Error(err.Error())
@@ -259,16 +293,25 @@ func (s *Server) fetchChatHandler(echo echo.Context) error {
 	}
 
 	s.logger.Debug(fmt.Sprintf("Preparing response. Number of messages: %d", len(messages)))
-	responses := make([]FetchMessagesResponse, len(messages)-1)
+	responses := make([]FetchMessagesResponse, 0, len(messages))
 	for _, message := range messages {
 		response := FetchMessagesResponse{
-			Chat:     message.Input,
-			Response: message.Response,
+			Chat:             message.Input,
+			Response:         message.Response,
+			Model:            message.Model,
+			PromptTokens:     message.PromptTokens,
+			CompletionTokens: message.CompletionTokens,
+			Cost:             message.Cost,
 		}
 		responses = append(responses, response)
 	}
 
-	echo.JSONPretty(http.StatusOK, responses, "")
+	echo.JSONPretty(http.StatusOK, FetchChatResponse{
+		Messages:         responses,
+		PromptTokens:     thread.PromptTokens,
+		CompletionTokens: thread.CompletionTokens,
+		Cost:             thread.Cost,
+	}, "")
 	return nil
 }
 
@@ -298,12 +341,15 @@ func (s *Server) respondChatHandler(echo echo.Context) error {
 
 	chat, err := chat.NewChat(
 		s.aiBackend,
-		s.aiBackendPassword,
+		s.avaCfg.AI,
 		s.logger,
 		chat.WithLanguage("en"),
 		chat.WithDbClient(s.db),
-		chat.WithPersist(true),
+		chat.WithPersist(s.db != nil),
 		chat.WithConfigureAssistant(s.logger, s.enableExecutors),
+		chat.WithApproval(s.approvals, "api", s.approvalNotifier()),
+		chat.WithAudit(s.auditor()),
+		chat.WithDryRun(s.avaCfg.Executors.DryRun || data.DryRun),
 	)
 	if err != nil {
 		s.logger.Fatal(err.Error())
@@ -318,7 +364,7 @@ func (s *Server) respondChatHandler(echo echo.Context) error {
 
 	go func() {
 		chatType := "response"
-		response, err := chat.Chat(data.Message, dbThread.ID)
+		response, err := chat.ChatStream(data.Message, dbThread.ID, nil, s.chatEvents(dbThread.ID))
 		if err != nil {
 			metrics.ChatCounter.WithLabelValues("error", chatType).Inc()
 			s.logger.Error(err.Error())
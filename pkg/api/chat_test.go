@@ -0,0 +1,242 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/matthisholleville/ava/internal/configuration"
+	db "github.com/matthisholleville/ava/internal/prisma"
+	"github.com/matthisholleville/ava/pkg/ai/scripted"
+	"github.com/matthisholleville/ava/pkg/ai/types"
+	"github.com/matthisholleville/ava/pkg/events"
+	"github.com/matthisholleville/ava/pkg/events/slack"
+	"github.com/spf13/viper"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+const testScript = `
+turns:
+  - input: "web-server"
+    prompt: "(?s)The problem: .*web-server"
+    toolCalls:
+      - name: getPod
+        arguments:
+          podName: web-server
+          namespaceName: default
+        expect: '"name":"web-server"'
+    response: The pod web-server is crashlooping.
+`
+
+// fakeEventClient answers the Slack events without Slack, the messages
+// sent to the channels are recorded.
+type fakeEventClient struct {
+	events.IEvent
+	messages chan string
+}
+
+func (f *fakeEventClient) ProcessEvent(data interface{}) (string, string, error) {
+	return data.(slack.ReceiveSlackEvent).Event.Text, "", nil
+}
+
+func (f *fakeEventClient) PersistEvent(eventID, threadID string) (*db.EventModel, error) {
+	return nil, nil
+}
+
+func (f *fakeEventClient) SendLookingMessage(channelID, ts string) error {
+	return nil
+}
+
+func (f *fakeEventClient) SendMessage(channelID, message, ts string) error {
+	f.messages <- message
+	return nil
+}
+
+func (f *fakeEventClient) SendTechnicalErrorMessage(channelID, ts string) error {
+	f.messages <- "technical error"
+	return nil
+}
+
+// newTestCluster starts a Kubernetes API serving the pod web-server and
+// returns the path of its kubeconfig.
+func newTestCluster(t *testing.T) string {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/version", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(version.Info{Major: "1", Minor: "30", GitVersion: "v1.30.0"})
+	})
+	mux.HandleFunc("/api/v1/namespaces/default/pods/web-server", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(v1.Pod{
+			TypeMeta:   metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "web-server", Namespace: "default"},
+		})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	kubeconfig := fmt.Sprintf(`
+apiVersion: v1
+kind: Config
+clusters:
+  - name: test
+    cluster:
+      server: %s
+contexts:
+  - name: test
+    context:
+      cluster: test
+current-context: test
+`, server.URL)
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	if err := os.WriteFile(path, []byte(kubeconfig), 0600); err != nil {
+		t.Fatalf("unable to write the kubeconfig: %v", err)
+	}
+	return path
+}
+
+// newScriptedServer returns a server without database analysing with the
+// scripted backend in the test cluster.
+func newScriptedServer(t *testing.T) (*Server, *scripted.ScriptedClient) {
+	srv := NewMockServer()
+	viper.Set("logger", srv.logger)
+	viper.Set("executors.k8s.read", true)
+	viper.Set("kubeconfig", newTestCluster(t))
+	t.Cleanup(func() { viper.Set("kubeconfig", "") })
+
+	path := filepath.Join(t.TempDir(), "script.yaml")
+	if err := os.WriteFile(path, []byte(testScript), 0600); err != nil {
+		t.Fatalf("unable to write the script: %v", err)
+	}
+
+	srv.avaCfg = &configuration.Configuration{
+		AI: configuration.AI{Type: "scripted", Scripted: configuration.Scripted{Path: path}},
+	}
+	srv.aiBackend = "scripted"
+	srv.enableExecutors = true
+
+	// The script is shared by path, it tells which turns were played.
+	script := &scripted.ScriptedClient{Configuration: scripted.Configuration{Path: path}}
+	if err := script.Configure(srv.logger); err != nil {
+		t.Fatalf("unable to load the script: %v", err)
+	}
+	return srv, script
+}
+
+// waitForRun returns the last event of the first analysis that ended.
+func waitForRun(t *testing.T, hub *streamHub) types.Event {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		hub.mu.Lock()
+		for _, st := range hub.streams {
+			if st.done {
+				event := st.events[len(st.events)-1]
+				hub.mu.Unlock()
+				return event
+			}
+		}
+		hub.mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("the analysis did not end")
+	return types.Event{}
+}
+
+func TestAlertManagerWebhookChatHandler(t *testing.T) {
+	srv, script := newScriptedServer(t)
+
+	payload := `{"status":"firing","alerts":[{"status":"firing","annotations":{"summary":"KubePodCrashLooping","description":"Pod default/web-server is crashlooping."}}]}`
+	req := httptest.NewRequest(http.MethodPost, "/chat/webhook", strings.NewReader(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	if err := srv.alertManagerWebhookChatHandler(srv.router.NewContext(req, rec)); err != nil {
+		t.Fatalf("handler returned an error: %v", err)
+	}
+	if status := rec.Code; status != http.StatusCreated {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+
+	event := waitForRun(t, srv.streams)
+	if event.Type != types.EventCompleted || event.Text != "The pod web-server is crashlooping." {
+		t.Errorf("the analysis should follow the script: %+v", event)
+	}
+	if err := script.Remaining(); err != nil {
+		t.Errorf("the script should be played: %v", err)
+	}
+}
+
+func TestSlackEventHandler(t *testing.T) {
+	srv, script := newScriptedServer(t)
+	eventClient := &fakeEventClient{messages: make(chan string, 1)}
+	srv.eventClient = eventClient
+	t.Setenv("SLACK_VALIDATION_TOKEN", "token")
+
+	tests := []struct {
+		name   string
+		token  string
+		status int
+	}{
+		{
+			name:   "invalid token",
+			token:  "invalid",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "valid token",
+			token:  "token",
+			status: http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			payload := fmt.Sprintf(`{"token":%q,"event":{"type":"app_mention","user":"U1","channel":"C1","ts":"1.0","text":"Pod web-server in namespace default Crashlooping."}}`, test.token)
+			req := httptest.NewRequest(http.MethodPost, "/event/slack", strings.NewReader(payload))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			if err := srv.slackEventHandler(srv.router.NewContext(req, rec)); err != nil {
+				t.Fatalf("handler returned an error: %v", err)
+			}
+			if status := rec.Code; status != test.status {
+				t.Errorf("handler returned wrong status code: got %v want %v", status, test.status)
+			}
+		})
+	}
+
+	select {
+	case message := <-eventClient.messages:
+		if message != "The pod web-server is crashlooping." {
+			t.Errorf("the answer of the script should be sent to Slack: %q", message)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("no answer was sent to Slack")
+	}
+	if err := script.Remaining(); err != nil {
+		t.Errorf("the script should be played once: %v", err)
+	}
+}
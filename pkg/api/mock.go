@@ -19,6 +19,8 @@ import (
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/matthisholleville/ava/internal/configuration"
+	"github.com/matthisholleville/ava/pkg/approval"
 	"github.com/matthisholleville/ava/pkg/logger"
 )
 
@@ -32,9 +34,11 @@ func NewMockServer() *Server {
 	logger := logger.InitLogger("raw", "debug")
 
 	return &Server{
-		router: echo.New(),
-		logger: logger,
-		config: config,
-		ctx:    context.Background(),
+		router:    echo.New(),
+		logger:    logger,
+		config:    config,
+		ctx:       context.Background(),
+		streams:   newStreamHub(),
+		approvals: approval.NewManager(configuration.Approval{}, logger, nil),
 	}
 }
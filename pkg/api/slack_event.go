@@ -15,11 +15,14 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
 
 	"github.com/labstack/echo/v4"
+	"github.com/matthisholleville/ava/pkg/ai/types"
 	"github.com/matthisholleville/ava/pkg/chat"
 	"github.com/matthisholleville/ava/pkg/events/slack"
 	"github.com/matthisholleville/ava/pkg/metrics"
@@ -64,15 +67,23 @@ func (s *Server) slackEventHandler(echo echo.Context) error {
 			return
 		}
 
+		requester := "slack"
+		if data.Event.User != "" {
+			requester = fmt.Sprintf("<@%s>", data.Event.User)
+		}
+
 		// Check if executors are enabled and set default value to false
 		chat, err := chat.NewChat(
 			s.aiBackend,
-			s.aiBackendPassword,
+			s.avaCfg.AI,
 			s.logger,
 			chat.WithLanguage("en"),
 			chat.WithDbClient(s.db),
-			chat.WithPersist(true),
+			chat.WithPersist(s.db != nil),
 			chat.WithConfigureAssistant(s.logger, s.enableExecutors),
+			chat.WithApproval(s.approvals, requester, s.slackApprovalNotifier(data.Event.Channel, data.Event.TS)),
+			chat.WithAudit(s.auditor()),
+			chat.WithDryRun(s.avaCfg.Executors.DryRun),
 		)
 		if err != nil {
 			s.logger.Fatal(err.Error())
@@ -99,10 +110,14 @@ func (s *Server) slackEventHandler(echo echo.Context) error {
 		}
 
 		chatType := "slackEvent"
-		response, err := chat.Chat(message, threadID)
+		response, err := chat.ChatStream(message, threadID, nil, s.chatEvents(threadID))
 		if err != nil {
 			metrics.ChatCounter.WithLabelValues("error", chatType).Inc()
 			s.logger.Error("Chat response processing failed", zap.Error(err))
+			if message, ok := runErrorMessage(err); ok {
+				s.eventClient.SendMessage(data.Event.Channel, message, data.Event.TS)
+				return
+			}
 			s.eventClient.SendTechnicalErrorMessage(data.Event.Channel, data.Event.TS)
 			return
 		}
@@ -120,7 +135,7 @@ func (s *Server) slackEventHandler(echo echo.Context) error {
 		}
 
 		// Send the response to the slack channel
-		err = s.eventClient.SendMessage(data.Event.Channel, response, data.Event.TS)
+		err = s.eventClient.SendMessage(data.Event.Channel, response.Text, data.Event.TS)
 		if err != nil {
 			s.logger.Error("sending message to slack failed", zap.Error(err))
 			s.eventClient.SendTechnicalErrorMessage(data.Event.Channel, data.Event.TS)
@@ -131,3 +146,19 @@ func (s *Server) slackEventHandler(echo echo.Context) error {
 
 	return echo.JSONPretty(http.StatusOK, data.Challenge, "")
 }
+
+// runErrorMessage returns the message reported to the user when the analysis
+// ended without an answer.
+func runErrorMessage(err error) (string, bool) {
+	var maxStepsError *types.MaxStepsError
+	var runError *types.RunError
+	switch {
+	case errors.As(err, &maxStepsError):
+		return maxStepsError.Error(), true
+	case errors.As(err, &runError):
+		return fmt.Sprintf("Ava could not finish the analysis: %s", runError.Error()), true
+	case errors.Is(err, context.DeadlineExceeded):
+		return "Ava gave up, the analysis took too long", true
+	}
+	return "", false
+}
@@ -0,0 +1,194 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/matthisholleville/ava/pkg/ai/types"
+)
+
+const (
+	// maxStreamEvents bounds the events kept to replay the current run to
+	// late subscribers.
+	maxStreamEvents = 1000
+	// streamRetention is how long the events of a finished run are kept.
+	streamRetention   = 5 * time.Minute
+	subscriberBuffer  = 64
+	keepAliveInterval = 15 * time.Second
+)
+
+// streamHub fans out the progress events of the analyses, per thread.
+type streamHub struct {
+	mu      sync.Mutex
+	streams map[string]*stream
+}
+
+type stream struct {
+	events      []types.Event
+	done        bool
+	subscribers map[chan types.Event]struct{}
+}
+
+func newStreamHub() *streamHub {
+	return &streamHub{
+		streams: map[string]*stream{},
+	}
+}
+
+func (h *streamHub) publish(threadID string, event types.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	st, ok := h.streams[threadID]
+	if !ok || event.Type == types.EventRunStarted {
+		// A new run replaces the events of the previous one.
+		subscribers := map[chan types.Event]struct{}{}
+		if ok {
+			subscribers = st.subscribers
+		}
+		st = &stream{subscribers: subscribers}
+		h.streams[threadID] = st
+	}
+
+	if len(st.events) < maxStreamEvents {
+		st.events = append(st.events, event)
+	}
+
+	for subscriber := range st.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			// The subscriber does not keep up, its stream ends.
+			delete(st.subscribers, subscriber)
+			close(subscriber)
+		}
+	}
+
+	if event.IsTerminal() {
+		st.done = true
+		for subscriber := range st.subscribers {
+			delete(st.subscribers, subscriber)
+			close(subscriber)
+		}
+		time.AfterFunc(streamRetention, func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			if h.streams[threadID] == st {
+				delete(h.streams, threadID)
+			}
+		})
+	}
+}
+
+// subscribe returns the events of the current run of the thread, starting
+// with the ones already published. The channel is closed when the run is over.
+func (h *streamHub) subscribe(threadID string) (<-chan types.Event, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	st, ok := h.streams[threadID]
+	if !ok {
+		st = &stream{subscribers: map[chan types.Event]struct{}{}}
+		h.streams[threadID] = st
+	}
+
+	subscriber := make(chan types.Event, len(st.events)+subscriberBuffer)
+	for _, event := range st.events {
+		subscriber <- event
+	}
+
+	if st.done {
+		close(subscriber)
+		return subscriber, func() {}
+	}
+
+	st.subscribers[subscriber] = struct{}{}
+	return subscriber, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := st.subscribers[subscriber]; ok {
+			delete(st.subscribers, subscriber)
+			close(subscriber)
+		}
+		if len(st.events) == 0 && len(st.subscribers) == 0 && h.streams[threadID] == st {
+			delete(h.streams, threadID)
+		}
+	}
+}
+
+// chatEvents returns a channel whose events are published on the stream of
+// the thread.
+func (s *Server) chatEvents(threadID string) chan<- types.Event {
+	events := make(chan types.Event)
+	go func() {
+		for event := range events {
+			s.streams.publish(threadID, event)
+		}
+	}()
+	return events
+}
+
+// Chat godoc
+// @Summary Stream the progress of an analysis
+// @Description Server-Sent Events describing the analysis in progress in the chat: runStarted, toolCall, toolOutput, textDelta, then completed or failed
+// @Tags Chat
+// @Produce text/event-stream
+// @Router /chat/{id}/stream [get]
+//
+//	@Param		id	path	string				true	"ID"
+//
+// @Success 200 {object} types.Event
+func (s *Server) streamChatHandler(echo echo.Context) error {
+	id := echo.Param("id")
+
+	events, unsubscribe := s.streams.subscribe(id)
+	defer unsubscribe()
+
+	response := echo.Response()
+	response.Header().Set("Content-Type", "text/event-stream")
+	response.Header().Set("Cache-Control", "no-cache")
+	response.Header().Set("Connection", "keep-alive")
+	response.WriteHeader(http.StatusOK)
+	response.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-echo.Request().Context().Done():
+			return nil
+		case <-keepAlive.C:
+			fmt.Fprint(response, ": keepalive\n\n")
+			response.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(response, "event: %s\ndata: %s\n\n", event.Type, data)
+			response.Flush()
+		}
+	}
+}
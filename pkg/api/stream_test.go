@@ -0,0 +1,57 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/matthisholleville/ava/pkg/ai/types"
+)
+
+func TestStreamChatHandler(t *testing.T) {
+	srv := NewMockServer()
+
+	events := srv.chatEvents("thread")
+	events <- types.Event{Type: types.EventRunStarted, ThreadID: "thread"}
+	events <- types.ToolCallEvent("getPod", `{"podName":"web"}`)
+	events <- types.Event{Type: types.EventCompleted, ThreadID: "thread", Text: "done"}
+	close(events)
+
+	req := httptest.NewRequest(http.MethodGet, "/chat/thread/stream", nil)
+	rec := httptest.NewRecorder()
+	c := srv.router.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("thread")
+
+	// Subscribing may happen before the forwarding goroutine published the
+	// events, in which case the handler waits for them.
+	if err := srv.streamChatHandler(c); err != nil {
+		t.Fatalf("handler returned an error: %v", err)
+	}
+
+	if status := rec.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	body := rec.Body.String()
+	for _, want := range []string{"event: runStarted\n", "event: toolCall\n", `"tool":"getPod"`, "event: completed\n"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("stream does not contain %q: %s", want, body)
+		}
+	}
+}
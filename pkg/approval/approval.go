@@ -0,0 +1,418 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package approval pauses the write executors until a human approves or
+// rejects them.
+package approval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/matthisholleville/ava/internal/configuration"
+	db "github.com/matthisholleville/ava/internal/prisma"
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/matthisholleville/ava/pkg/logger"
+	"github.com/matthisholleville/ava/pkg/metrics"
+	"github.com/matthisholleville/ava/pkg/redact"
+	"go.uber.org/zap"
+)
+
+const (
+	DEFAULT_TIMEOUT       = 5 * time.Minute
+	DEFAULT_SQL_TIMEOUT   = 5 * time.Second
+	DEFAULT_POLL_INTERVAL = 2 * time.Second
+
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+	StatusExpired  = "expired"
+
+	actionIDLength = 16
+)
+
+var ErrActionNotFound = errors.New("action not found or already decided")
+
+// Action is an executor call waiting for a decision.
+type Action struct {
+	ID        string
+	Executor  string
+	Arguments string
+	ThreadID  string
+	// Requester is the user or the alert that started the analysis.
+	Requester string
+}
+
+type Decision struct {
+	Approved bool
+	Status   string
+	By       string
+	Reason   string
+}
+
+// Notifier asks the humans to decide about an action, e.g. with a Slack
+// message. The decision is given later to Manager.Resolve.
+type Notifier interface {
+	Notify(action Action) error
+}
+
+type NotifierFunc func(action Action) error
+
+func (f NotifierFunc) Notify(action Action) error {
+	return f(action)
+}
+
+// Manager keeps the pending actions. The decisions are resolved through
+// the database when there is one, so an action can be decided on any
+// replica of the server, and in memory otherwise.
+type Manager struct {
+	Timeout time.Duration
+	// DefaultOutcome is applied when nobody decided before the timeout.
+	DefaultOutcome string
+	// PollInterval is the interval between the reads of the decision in
+	// the database.
+	PollInterval time.Duration
+	logger       logger.ILogger
+	store        store
+
+	mu      sync.Mutex
+	pending map[string]chan Decision
+}
+
+// store persists the actions and their decisions.
+type store interface {
+	create(ctx context.Context, action Action) error
+	// decide saves the decision of a pending action, it returns false if
+	// the action was already decided.
+	decide(ctx context.Context, id string, decision Decision) (bool, error)
+	find(ctx context.Context, id string) (Decision, error)
+	// expire decides the actions pending since before the given time.
+	expire(ctx context.Context, before time.Time, decision Decision) (int, error)
+}
+
+// NewManager returns a manager persisting the actions in db, if not nil.
+func NewManager(config configuration.Approval, logger logger.ILogger, client *db.PrismaClient) *Manager {
+	var s store
+	if client != nil {
+		s = &dbStore{db: client}
+	}
+	return newManager(config, logger, s)
+}
+
+func newManager(config configuration.Approval, logger logger.ILogger, s store) *Manager {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = DEFAULT_TIMEOUT
+	}
+
+	defaultOutcome := StatusRejected
+	if config.DefaultOutcome == "approve" {
+		defaultOutcome = StatusApproved
+	}
+
+	return &Manager{
+		Timeout:        timeout,
+		DefaultOutcome: defaultOutcome,
+		PollInterval:   DEFAULT_POLL_INTERVAL,
+		logger:         logger,
+		store:          s,
+		pending:        make(map[string]chan Decision),
+	}
+}
+
+func NewActionID() string {
+	return fmt.Sprintf("action_%s", common.GenerateRandomString(actionIDLength))
+}
+
+// Recover expires the actions left pending by a replica that stopped: the
+// analysis waiting for them stopped with it.
+func (m *Manager) Recover(ctx context.Context) error {
+	if m.store == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, DEFAULT_SQL_TIMEOUT)
+	defer cancel()
+	count, err := m.store.expire(ctx, time.Now().Add(-m.Timeout), Decision{
+		Status: StatusExpired,
+		Reason: "Ava stopped before a decision",
+	})
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		m.logger.Info(fmt.Sprintf("%d pending actions expired", count))
+	}
+	return nil
+}
+
+// Request persists the action, notifies the humans and waits for a
+// decision. The default outcome is applied after the timeout and the action
+// is rejected if ctx is cancelled.
+func (m *Manager) Request(ctx context.Context, action Action, notifier Notifier) Decision {
+	if action.ID == "" {
+		action.ID = NewActionID()
+	}
+
+	decisions := make(chan Decision, 1)
+	m.mu.Lock()
+	m.pending[action.ID] = decisions
+	m.mu.Unlock()
+
+	var decision Decision
+	if err := m.create(action); err != nil {
+		m.logger.Error("Persisting the action failed", zap.String("action", action.ID), zap.Error(err))
+		m.take(action.ID)
+		decision = Decision{Status: StatusRejected, Reason: "the action could not be saved"}
+	} else if err := notifier.Notify(action); err != nil {
+		m.logger.Error("Notifying the approvers failed", zap.String("action", action.ID), zap.Error(err))
+		decision = m.expire(action.ID, decisions, Decision{Status: StatusRejected, Reason: "nobody could be asked to approve it"})
+	} else {
+		decision = m.wait(ctx, action.ID, decisions)
+	}
+
+	m.logger.Info(fmt.Sprintf("Action %s %s", action.ID, decision.Status), zap.String("executor", action.Executor))
+	metrics.ApprovalCounter.WithLabelValues(action.Executor, decision.Status).Inc()
+	return decision
+}
+
+// wait returns the decision given to this replica, or read in the database
+// when another replica received it.
+func (m *Manager) wait(ctx context.Context, id string, decisions <-chan Decision) Decision {
+	timer := time.NewTimer(m.Timeout)
+	defer timer.Stop()
+
+	var poll <-chan time.Time
+	if m.store != nil {
+		ticker := time.NewTicker(m.PollInterval)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+
+	for {
+		select {
+		case decision := <-decisions:
+			return decision
+		case <-poll:
+			if decision, ok := m.decided(id, decisions); ok {
+				return decision
+			}
+		case <-timer.C:
+			return m.expire(id, decisions, Decision{
+				Approved: m.DefaultOutcome == StatusApproved,
+				Status:   StatusExpired,
+				Reason:   fmt.Sprintf("nobody decided within %s", m.Timeout),
+			})
+		case <-ctx.Done():
+			return m.expire(id, decisions, Decision{Status: StatusRejected, Reason: "the analysis was cancelled"})
+		}
+	}
+}
+
+// decided reads the decision of the action in the database.
+func (m *Manager) decided(id string, decisions <-chan Decision) (Decision, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), DEFAULT_SQL_TIMEOUT)
+	defer cancel()
+	decision, err := m.store.find(ctx, id)
+	if err != nil {
+		m.logger.Warn("Error reading the decision", zap.String("action", id), zap.Error(err))
+		return Decision{}, false
+	}
+	if decision.Status == StatusPending {
+		return Decision{}, false
+	}
+	if _, ok := m.take(id); !ok {
+		// Resolved on this replica at the same time.
+		return <-decisions, true
+	}
+	return decision, true
+}
+
+// Resolve gives the decision of a human about a pending action. The action
+// may wait on another replica, it reads the decision in the database.
+func (m *Manager) Resolve(id string, approved bool, by, reason string) error {
+	decision := Decision{Approved: approved, Status: StatusRejected, By: by, Reason: reason}
+	if approved {
+		decision.Status = StatusApproved
+	}
+	if !approved && reason == "" {
+		decision.Reason = fmt.Sprintf("rejected by %s", by)
+	}
+
+	if m.store != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), DEFAULT_SQL_TIMEOUT)
+		defer cancel()
+		decided, err := m.store.decide(ctx, id, decision)
+		if err != nil {
+			return err
+		}
+		if !decided {
+			return ErrActionNotFound
+		}
+		if decisions, ok := m.take(id); ok {
+			decisions <- decision
+		}
+		return nil
+	}
+
+	decisions, ok := m.take(id)
+	if !ok {
+		return ErrActionNotFound
+	}
+	decisions <- decision
+	return nil
+}
+
+// expire applies the decision unless a human decided at the same time.
+func (m *Manager) expire(id string, decisions <-chan Decision, decision Decision) Decision {
+	if _, ok := m.take(id); !ok {
+		return <-decisions
+	}
+	if m.store == nil {
+		return decision
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DEFAULT_SQL_TIMEOUT)
+	defer cancel()
+	decided, err := m.store.decide(ctx, id, decision)
+	if err != nil {
+		m.logger.Warn("Error persisting decision", zap.String("action", id), zap.Error(err))
+		return decision
+	}
+	if decided {
+		return decision
+	}
+	// Decided on another replica since the last read.
+	if found, err := m.store.find(ctx, id); err == nil {
+		return found
+	}
+	return decision
+}
+
+// take removes the action from the pending ones, it returns false if the
+// action was not pending.
+func (m *Manager) take(id string) (chan Decision, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	decisions, ok := m.pending[id]
+	delete(m.pending, id)
+	return decisions, ok
+}
+
+func (m *Manager) create(action Action) error {
+	if m.store == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DEFAULT_SQL_TIMEOUT)
+	defer cancel()
+	return m.store.create(ctx, action)
+}
+
+type dbStore struct {
+	db *db.PrismaClient
+}
+
+func (s *dbStore) create(ctx context.Context, action Action) error {
+	_, err := s.db.Action.CreateOne(
+		db.Action.ID.Set(action.ID),
+		db.Action.Executor.Set(action.Executor),
+		db.Action.Arguments.Set(redact.String(action.Arguments)),
+		db.Action.Thread.Link(
+			db.Thread.ID.Equals(action.ThreadID),
+		),
+		db.Action.Requester.Set(action.Requester),
+	).Exec(ctx)
+	return err
+}
+
+func (s *dbStore) decide(ctx context.Context, id string, decision Decision) (bool, error) {
+	// Only a pending action is updated: the first decision wins between
+	// the replicas.
+	result, err := s.db.Action.FindMany(
+		db.Action.ID.Equals(id),
+		db.Action.Status.Equals(StatusPending),
+	).Update(
+		db.Action.Status.Set(decision.Status),
+		db.Action.DecidedBy.Set(decision.By),
+		db.Action.Reason.Set(decision.Reason),
+	).Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+func (s *dbStore) find(ctx context.Context, id string) (Decision, error) {
+	action, err := s.db.Action.FindUnique(
+		db.Action.ID.Equals(id),
+	).Exec(ctx)
+	if err != nil {
+		return Decision{}, err
+	}
+	return Decision{
+		Approved: action.Status == StatusApproved,
+		Status:   action.Status,
+		By:       action.DecidedBy,
+		Reason:   action.Reason,
+	}, nil
+}
+
+func (s *dbStore) expire(ctx context.Context, before time.Time, decision Decision) (int, error) {
+	result, err := s.db.Action.FindMany(
+		db.Action.Status.Equals(StatusPending),
+		db.Action.CreatedAt.Lt(before),
+	).Update(
+		db.Action.Status.Set(decision.Status),
+		db.Action.Reason.Set(decision.Reason),
+	).Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return result.Count, nil
+}
+
+// Approver returns the approver of the executors of an analysis.
+func (m *Manager) Approver(threadID, requester string, notifier Notifier) common.Approver {
+	return approver{
+		manager:   m,
+		threadID:  threadID,
+		requester: requester,
+		notifier:  notifier,
+	}
+}
+
+type approver struct {
+	manager   *Manager
+	threadID  string
+	requester string
+	notifier  Notifier
+}
+
+func (a approver) Approve(ctx context.Context, executor, arguments string) (bool, string, string) {
+	decision := a.manager.Request(ctx, Action{
+		Executor:  executor,
+		Arguments: arguments,
+		ThreadID:  a.threadID,
+		Requester: a.requester,
+	}, a.notifier)
+	by := decision.By
+	if decision.Status == StatusExpired {
+		by = "timeout"
+	}
+	return decision.Approved, by, decision.Reason
+}
@@ -0,0 +1,148 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approval
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/matthisholleville/ava/internal/configuration"
+	"github.com/matthisholleville/ava/pkg/logger"
+)
+
+func TestRequestResolved(t *testing.T) {
+	m := NewManager(configuration.Approval{}, logger.InitLogger("raw", "error"), nil)
+
+	tests := []struct {
+		approved bool
+		status   string
+		reason   string
+	}{
+		{true, StatusApproved, ""},
+		{false, StatusRejected, "rejected by jane"},
+	}
+
+	for _, test := range tests {
+		notifier := NotifierFunc(func(action Action) error {
+			go m.Resolve(action.ID, test.approved, "jane", "")
+			return nil
+		})
+
+		decision := m.Request(context.Background(), Action{Executor: "deletePod"}, notifier)
+
+		if decision.Approved != test.approved || decision.Status != test.status || decision.Reason != test.reason {
+			t.Errorf("wrong decision: got %+v", decision)
+		}
+	}
+}
+
+func TestRequestExpires(t *testing.T) {
+	m := NewManager(configuration.Approval{
+		Timeout:        10 * time.Millisecond,
+		DefaultOutcome: "approve",
+	}, logger.InitLogger("raw", "error"), nil)
+
+	var id string
+	notifier := NotifierFunc(func(action Action) error {
+		id = action.ID
+		return nil
+	})
+
+	decision := m.Request(context.Background(), Action{Executor: "deletePod"}, notifier)
+
+	if !decision.Approved || decision.Status != StatusExpired {
+		t.Errorf("the default outcome should apply: got %+v", decision)
+	}
+	if err := m.Resolve(id, false, "jane", ""); !errors.Is(err, ErrActionNotFound) {
+		t.Errorf("an expired action should not be resolved: got %v", err)
+	}
+}
+
+// memoryStore is the database shared by the replicas.
+type memoryStore struct {
+	mu      sync.Mutex
+	actions map[string]Decision
+}
+
+func (s *memoryStore) create(_ context.Context, action Action) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.actions[action.ID] = Decision{Status: StatusPending}
+	return nil
+}
+
+func (s *memoryStore) decide(_ context.Context, id string, decision Decision) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.actions[id].Status != StatusPending {
+		return false, nil
+	}
+	s.actions[id] = decision
+	return true, nil
+}
+
+func (s *memoryStore) find(_ context.Context, id string) (Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.actions[id], nil
+}
+
+func (s *memoryStore) expire(context.Context, time.Time, Decision) (int, error) {
+	return 0, nil
+}
+
+func TestRequestResolvedByAnotherReplica(t *testing.T) {
+	store := &memoryStore{actions: make(map[string]Decision)}
+	waiting := newManager(configuration.Approval{}, logger.InitLogger("raw", "error"), store)
+	waiting.PollInterval = 10 * time.Millisecond
+	other := newManager(configuration.Approval{}, logger.InitLogger("raw", "error"), store)
+
+	notifier := NotifierFunc(func(action Action) error {
+		go func() {
+			if err := other.Resolve(action.ID, true, "jane", ""); err != nil {
+				t.Errorf("the action should be resolved on any replica: %v", err)
+			}
+		}()
+		return nil
+	})
+
+	decision := waiting.Request(context.Background(), Action{Executor: "deletePod"}, notifier)
+
+	if !decision.Approved || decision.Status != StatusApproved || decision.By != "jane" {
+		t.Errorf("the decision should be read in the database: got %+v", decision)
+	}
+}
+
+func TestRequestExpiresOnce(t *testing.T) {
+	store := &memoryStore{actions: make(map[string]Decision)}
+	m := newManager(configuration.Approval{Timeout: 10 * time.Millisecond}, logger.InitLogger("raw", "error"), store)
+	other := newManager(configuration.Approval{}, logger.InitLogger("raw", "error"), store)
+
+	var id string
+	decision := m.Request(context.Background(), Action{Executor: "deletePod"}, NotifierFunc(func(action Action) error {
+		id = action.ID
+		return nil
+	}))
+
+	if decision.Status != StatusExpired || store.actions[id].Status != StatusExpired {
+		t.Errorf("the expiry should be saved: got %+v", decision)
+	}
+	if err := other.Resolve(id, true, "jane", ""); !errors.Is(err, ErrActionNotFound) {
+		t.Errorf("an expired action should not be resolved on another replica: got %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit records every executor call in the database, to know what
+// Ava did in the cluster and why.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	db "github.com/matthisholleville/ava/internal/prisma"
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/matthisholleville/ava/pkg/logger"
+	"github.com/matthisholleville/ava/pkg/redact"
+	"go.uber.org/zap"
+)
+
+const (
+	DEFAULT_SQL_TIMEOUT = 5 * time.Second
+	DEFAULT_MAX_RESULTS = 1000
+	// MaxOutputBytes bounds the output saved with a call.
+	MaxOutputBytes = 4096
+)
+
+// Entry is a recorded executor call.
+type Entry struct {
+	ID         int    `json:"id"`
+	ThreadID   string `json:"threadId"`
+	RunID      string `json:"runId"`
+	ToolCallID string `json:"toolCallId"`
+	Executor   string `json:"executor"`
+	Arguments  string `json:"arguments"`
+	Output     string `json:"output"`
+	DurationMs int    `json:"durationMs"`
+	Error      string `json:"error,omitempty"`
+	Approver   string `json:"approver,omitempty"`
+	DryRun     bool   `json:"dryRun"`
+	// Affected are the objects changed by the call, as kind/namespace/name.
+	Affected  []string  `json:"affected,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Filter selects the calls to list, the zero values match every call.
+type Filter struct {
+	ThreadID string
+	Executor string
+	Since    time.Time
+	Until    time.Time
+	Limit    int
+}
+
+type Recorder struct {
+	db     *db.PrismaClient
+	logger logger.ILogger
+}
+
+func NewRecorder(client *db.PrismaClient, logger logger.ILogger) *Recorder {
+	return &Recorder{db: client, logger: logger}
+}
+
+// Audit saves the call with its redacted arguments and truncated output. A
+// failure is logged, it does not fail the call.
+func (r *Recorder) Audit(ctx context.Context, call common.ExecutorCall) {
+	r.logger.Info(fmt.Sprintf("Executor call %s", call.Executor),
+		zap.String("threadId", call.ThreadID),
+		zap.String("toolCallId", call.ToolCallID),
+		zap.Duration("duration", call.Duration),
+		zap.String("error", call.Error),
+	)
+
+	// The call is recorded even if the analysis was just cancelled.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), DEFAULT_SQL_TIMEOUT)
+	defer cancel()
+
+	_, err := r.db.ExecutorCall.CreateOne(
+		db.ExecutorCall.Executor.Set(call.Executor),
+		db.ExecutorCall.Arguments.Set(redact.String(call.Arguments)),
+		db.ExecutorCall.ThreadID.Set(call.ThreadID),
+		db.ExecutorCall.RunID.Set(call.RunID),
+		db.ExecutorCall.ToolCallID.Set(call.ToolCallID),
+		db.ExecutorCall.Output.Set(Truncate(call.Output, MaxOutputBytes)),
+		db.ExecutorCall.DurationMs.Set(int(call.Duration.Milliseconds())),
+		db.ExecutorCall.Error.Set(call.Error),
+		db.ExecutorCall.Approver.Set(call.Approver),
+		db.ExecutorCall.DryRun.Set(call.DryRun),
+		db.ExecutorCall.Affected.Set(affected(call.Affected)),
+	).Exec(ctx)
+	if err != nil {
+		r.logger.Error(fmt.Sprintf("Saving the executor call %s failed: %s", call.Executor, err.Error()))
+	}
+}
+
+// List returns the calls matching the filter, the most recent first.
+func (r *Recorder) List(ctx context.Context, filter Filter) ([]Entry, error) {
+	ctx, cancel := context.WithTimeout(ctx, DEFAULT_SQL_TIMEOUT)
+	defer cancel()
+
+	limit := filter.Limit
+	if limit <= 0 || limit > DEFAULT_MAX_RESULTS {
+		limit = DEFAULT_MAX_RESULTS
+	}
+
+	calls, err := r.db.ExecutorCall.FindMany(
+		db.ExecutorCall.ThreadID.EqualsIfPresent(ifPresent(filter.ThreadID)),
+		db.ExecutorCall.Executor.EqualsIfPresent(ifPresent(filter.Executor)),
+		db.ExecutorCall.CreatedAt.GteIfPresent(ifPresent(filter.Since)),
+		db.ExecutorCall.CreatedAt.LteIfPresent(ifPresent(filter.Until)),
+	).OrderBy(
+		db.ExecutorCall.CreatedAt.Order(db.SORT_ORDER_DESC),
+	).Take(limit).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(calls))
+	for _, call := range calls {
+		entries = append(entries, Entry{
+			ID:         call.ID,
+			ThreadID:   call.ThreadID,
+			RunID:      call.RunID,
+			ToolCallID: call.ToolCallID,
+			Executor:   call.Executor,
+			Arguments:  call.Arguments,
+			Output:     call.Output,
+			DurationMs: call.DurationMs,
+			Error:      call.Error,
+			Approver:   call.Approver,
+			DryRun:     call.DryRun,
+			CreatedAt:  call.CreatedAt,
+		})
+		if call.Affected != "" {
+			entries[len(entries)-1].Affected = strings.Split(call.Affected, ",")
+		}
+	}
+	return entries, nil
+}
+
+func affected(objects []common.ObjectRef) string {
+	refs := make([]string, 0, len(objects))
+	for _, object := range objects {
+		refs = append(refs, object.String())
+	}
+	return strings.Join(refs, ",")
+}
+
+// ifPresent returns nil for the zero value, to skip the filter.
+func ifPresent[T comparable](value T) *T {
+	var zero T
+	if value == zero {
+		return nil
+	}
+	return &value
+}
+
+// WriteJSONLines writes one JSON object per entry.
+func WriteJSONLines(w io.Writer, entries []Entry) error {
+	encoder := json.NewEncoder(w)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Truncate bounds the text to maxBytes, keeping valid UTF-8.
+func Truncate(text string, maxBytes int) string {
+	if len(text) <= maxBytes {
+		return text
+	}
+	kept := strings.ToValidUTF8(text[:maxBytes], "")
+	return fmt.Sprintf("%s\n[... truncated: %d of %d bytes saved]", kept, len(kept), len(text))
+}
+
+// ParseTime parses a RFC3339 time or a duration before now, e.g. 24h.
+func ParseTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected a RFC3339 time or a duration such as 24h", value)
+	}
+	return now.Add(-d), nil
+}
@@ -0,0 +1,92 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matthisholleville/ava/pkg/common"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		text     string
+		maxBytes int
+		expected string
+	}{
+		{"short", 10, "short"},
+		{"exactly", 7, "exactly"},
+		{"truncated", 4, "trun\n[... truncated: 4 of 9 bytes saved]"},
+		// é is 2 bytes, the rune cut in half is dropped.
+		{"aéb", 2, "a\n[... truncated: 1 of 4 bytes saved]"},
+		{"ééé", 3, "é\n[... truncated: 2 of 6 bytes saved]"},
+	}
+
+	for _, test := range tests {
+		if got := Truncate(test.text, test.maxBytes); got != test.expected {
+			t.Errorf("Truncate(%q, %d) = %q, expected %q", test.text, test.maxBytes, got, test.expected)
+		}
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Time
+		invalid  bool
+	}{
+		{"", time.Time{}, false},
+		{"24h", now.Add(-24 * time.Hour), false},
+		{"90m", now.Add(-90 * time.Minute), false},
+		{"2025-02-28T08:30:00Z", time.Date(2025, 2, 28, 8, 30, 0, 0, time.UTC), false},
+		{"yesterday", time.Time{}, true},
+		{"2025-02-28", time.Time{}, true},
+	}
+
+	for _, test := range tests {
+		got, err := ParseTime(test.value, now)
+		if test.invalid {
+			if err == nil {
+				t.Errorf("ParseTime(%q) should fail", test.value)
+			}
+			continue
+		}
+		if err != nil || !got.Equal(test.expected) {
+			t.Errorf("ParseTime(%q) = %v, %v, expected %v", test.value, got, err, test.expected)
+		}
+	}
+}
+
+func TestAffected(t *testing.T) {
+	tests := []struct {
+		objects  []common.ObjectRef
+		expected string
+	}{
+		{nil, ""},
+		{[]common.ObjectRef{{Kind: "Node", Name: "node-1"}}, "Node/node-1"},
+		{[]common.ObjectRef{
+			{Kind: "Pod", Namespace: "default", Name: "web-1"},
+			{Kind: "Pod", Namespace: "default", Name: "web-2"},
+		}, "Pod/default/web-1,Pod/default/web-2"},
+	}
+
+	for _, test := range tests {
+		if got := affected(test.objects); got != test.expected {
+			t.Errorf("affected(%+v) = %q, expected %q", test.objects, got, test.expected)
+		}
+	}
+}
@@ -16,13 +16,20 @@ package chat
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/matthisholleville/ava/internal/configuration"
 	db "github.com/matthisholleville/ava/internal/prisma"
 	"github.com/matthisholleville/ava/pkg/ai"
+	"github.com/matthisholleville/ava/pkg/ai/types"
+	"github.com/matthisholleville/ava/pkg/approval"
 	"github.com/matthisholleville/ava/pkg/common"
 	"github.com/matthisholleville/ava/pkg/kubernetes"
 	"github.com/matthisholleville/ava/pkg/logger"
+	"github.com/matthisholleville/ava/pkg/metrics"
+	"github.com/matthisholleville/ava/pkg/redact"
 	"github.com/spf13/viper"
 )
 
@@ -30,16 +37,29 @@ const (
 	DEFAULT_SQL_TIMEOUT = 5 * time.Second
 	DEFAULT_MAX_RESULTS = 1000
 	DEFAULT_LANGUAGE    = "en"
+	DEFAULT_RUN_TIMEOUT = 10 * time.Minute
+
+	runIDLength = 16
 )
 
 type Chat struct {
-	Context   context.Context
-	Language  string
-	AIClient  ai.IAI
-	K8SClient *kubernetes.Client
-	logger    logger.ILogger
-	db        *db.PrismaClient
-	Persist   bool
+	Context    context.Context
+	RunTimeout time.Duration
+	Pricing    map[string]configuration.Price
+	Language   string
+	AIClient   ai.IAI
+	K8SClient  *kubernetes.Client
+	logger     logger.ILogger
+	db         *db.PrismaClient
+	Persist    bool
+	// Approvals pauses the write executors until they are approved.
+	Approvals *approval.Manager
+	Requester string
+	notifier  approval.Notifier
+	// DryRun simulates the write executors and lists them in the answer.
+	DryRun bool
+	// Auditor records the executor calls of the analyses.
+	Auditor common.Auditor
 }
 
 type Option func(*Chat)
@@ -55,6 +75,14 @@ func WithLanguage(language string) Option {
 	}
 }
 
+// WithContext sets the parent context of the analyses, cancelling it
+// cancels the running analysis.
+func WithContext(ctx context.Context) Option {
+	return func(i *Chat) {
+		i.Context = ctx
+	}
+}
+
 func WithDbClient(db *db.PrismaClient) Option {
 	return func(i *Chat) {
 		i.db = db
@@ -67,6 +95,28 @@ func WithPersist(persist bool) Option {
 	}
 }
 
+func WithAudit(auditor common.Auditor) Option {
+	return func(i *Chat) {
+		i.Auditor = auditor
+	}
+}
+
+func WithDryRun(dryRun bool) Option {
+	return func(i *Chat) {
+		i.DryRun = dryRun
+	}
+}
+
+// WithApproval asks the approvers notified by notifier to approve the
+// write executors on behalf of the requester.
+func WithApproval(manager *approval.Manager, requester string, notifier approval.Notifier) Option {
+	return func(i *Chat) {
+		i.Approvals = manager
+		i.Requester = requester
+		i.notifier = notifier
+	}
+}
+
 func WithConfigureAssistant(logger logger.ILogger, enableExecutors bool) Option {
 	return func(i *Chat) {
 		err := i.AIClient.ConfigureAssistant(logger, enableExecutors)
@@ -78,11 +128,11 @@ func WithConfigureAssistant(logger logger.ILogger, enableExecutors bool) Option
 
 func NewChat(
 	backend string,
-	password string,
+	aiConfig configuration.AI,
 	logger logger.ILogger,
 	opts ...Option,
 ) (*Chat, error) {
-	aiClient, err := ai.NewAI(backend, ai.WithPassword(password))
+	aiClient, err := ai.NewAI(backend, ai.WithConfiguration(aiConfig))
 	if err != nil {
 		return nil, err
 	}
@@ -94,13 +144,20 @@ func NewChat(
 		return nil, err
 	}
 
+	runTimeout := aiConfig.RunTimeout
+	if runTimeout <= 0 {
+		runTimeout = DEFAULT_RUN_TIMEOUT
+	}
+
 	client := &Chat{
-		Context:   context.Background(),
-		Language:  DEFAULT_LANGUAGE,
-		AIClient:  aiClient,
-		K8SClient: k8sClient,
-		logger:    logger,
-		db:        nil,
+		Context:    context.Background(),
+		RunTimeout: runTimeout,
+		Pricing:    aiConfig.Pricing,
+		Language:   DEFAULT_LANGUAGE,
+		AIClient:   aiClient,
+		K8SClient:  k8sClient,
+		logger:     logger,
+		db:         nil,
 	}
 
 	for _, opt := range opts {
@@ -110,6 +167,13 @@ func NewChat(
 	return client, nil
 }
 
+// KeepsHistory reports whether the history of the threads outlives the
+// process. The openai backend keeps the threads on its server, the other
+// backends rebuild the history from the persisted chats.
+func (c *Chat) KeepsHistory() bool {
+	return c.AIClient.GetName() == "openai" || (c.db != nil && c.Persist)
+}
+
 func (c *Chat) InitChat() (string, error) {
 	c.logger.Info("Creates a new thread")
 	threadID, err := c.AIClient.CreateThread()
@@ -128,18 +192,102 @@ func (c *Chat) InitChat() (string, error) {
 	return *threadID, nil
 }
 
-func (c *Chat) Chat(message, threadID string) (string, error) {
+// Chat analyzes the message in the thread. The labels of the alert that
+// triggered the analysis, if any, are made available to the prompt templates.
+func (c *Chat) Chat(message, threadID string, labels map[string]string) (types.AnalyzeResponse, error) {
+	return c.ChatStream(message, threadID, labels, nil)
+}
+
+// ChatStream is Chat sending the progress of the analysis to events, from
+// runStarted to completed or failed. events is closed once the analysis is
+// over.
+func (c *Chat) ChatStream(message, threadID string, labels map[string]string, events chan<- types.Event) (types.AnalyzeResponse, error) {
+	if events != nil {
+		defer close(events)
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, c.RunTimeout)
+	defer cancel()
+
+	executor := c.executor(ctx, threadID, labels)
 
 	c.logger.Info("Analyzes the message")
-	return c.AIClient.Analyze(
-		message,
-		c.Language,
-		threadID,
-		common.Executor{
-			Client:  c.K8SClient,
-			Context: c.Context,
-		},
-	)
+	types.Emit(events, types.Event{Type: types.EventRunStarted, ThreadID: threadID})
+	request := types.AnalyzeRequest{
+		Message:  message,
+		Language: c.Language,
+		ThreadID: threadID,
+		Labels:   labels,
+	}
+	if c.db != nil && c.Persist {
+		request.History = func() ([]types.Turn, error) {
+			return c.turns(threadID)
+		}
+	}
+	response, err := c.AIClient.AnalyzeStream(ctx, request, executor, events)
+
+	response.Usage.Cost = c.cost(response.Usage)
+	recordUsage(response.Usage)
+
+	if err != nil {
+		types.Emit(events, types.Event{Type: types.EventFailed, ThreadID: threadID, Error: err.Error()})
+		return response, err
+	}
+
+	if c.DryRun {
+		response.Text += planSummary(executor.Plan.Actions())
+	}
+
+	types.Emit(events, types.Event{Type: types.EventCompleted, ThreadID: threadID, Text: response.Text})
+	return response, nil
+}
+
+func (c *Chat) executor(ctx context.Context, threadID string, labels map[string]string) common.Executor {
+	executor := common.Executor{
+		Client:      c.K8SClient,
+		Context:     ctx,
+		DryRun:      c.DryRun,
+		Plan:        &common.Plan{},
+		AlertLabels: labels,
+		Auditor:     c.Auditor,
+		ThreadID:    threadID,
+		// The backends running the tools remotely replace it with their run.
+		RunID: fmt.Sprintf("run_%s", common.GenerateRandomString(runIDLength)),
+	}
+	if c.Approvals != nil {
+		executor.Approver = c.Approvals.Approver(threadID, c.Requester, c.notifier)
+	}
+	return executor
+}
+
+// planSummary lists the actions simulated by a dry run.
+func planSummary(actions []string) string {
+	if len(actions) == 0 {
+		return "\n\nDry run: no action was planned."
+	}
+
+	var summary strings.Builder
+	summary.WriteString("\n\nDry run, planned actions:")
+	for _, action := range actions {
+		summary.WriteString("\n- ")
+		summary.WriteString(action)
+	}
+	return summary.String()
+}
+
+// cost estimates the cost of the usage from the price table of the model.
+func (c *Chat) cost(usage types.Usage) float64 {
+	price, ok := c.Pricing[strings.ToLower(usage.Model)]
+	if !ok {
+		return 0
+	}
+	return (float64(usage.PromptTokens)*price.Prompt + float64(usage.CompletionTokens)*price.Completion) / 1_000_000
+}
+
+func recordUsage(usage types.Usage) {
+	metrics.TokenCounter.WithLabelValues(usage.Model, "prompt").Add(float64(usage.PromptTokens))
+	metrics.TokenCounter.WithLabelValues(usage.Model, "completion").Add(float64(usage.CompletionTokens))
+	metrics.CostCounter.WithLabelValues(usage.Model).Add(usage.Cost)
 }
 
 func (c *Chat) FetchChatMessages(threadID string) ([]db.ChatModel, error) {
@@ -147,9 +295,25 @@ func (c *Chat) FetchChatMessages(threadID string) ([]db.ChatModel, error) {
 	defer cancel()
 	return c.db.Chat.FindMany(
 		db.Chat.ThreadID.Equals(threadID),
+	).OrderBy(
+		db.Chat.ID.Order(db.SORT_ORDER_ASC),
 	).Take(DEFAULT_MAX_RESULTS).Exec(ctx)
 }
 
+// turns returns the persisted messages of the thread and their answers,
+// the oldest first.
+func (c *Chat) turns(threadID string) ([]types.Turn, error) {
+	messages, err := c.FetchChatMessages(threadID)
+	if err != nil {
+		return nil, err
+	}
+	turns := make([]types.Turn, 0, len(messages))
+	for _, message := range messages {
+		turns = append(turns, types.Turn{Input: message.Input, Response: message.Response})
+	}
+	return turns, nil
+}
+
 func (c *Chat) GetThread(threadID string) (*db.ThreadModel, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), DEFAULT_SQL_TIMEOUT)
 	defer cancel()
@@ -178,14 +342,32 @@ func (c *Chat) FindThreadUnique(
 		).Exec(ctx)
 }
 
-func (c *Chat) PersistChat(chat, response, threadID string) (*db.ChatModel, error) {
+// PersistChat saves the redacted message with its usage and adds the usage
+// to the totals of the thread.
+func (c *Chat) PersistChat(chat string, response types.AnalyzeResponse, threadID string) (*db.ChatModel, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), DEFAULT_SQL_TIMEOUT)
 	defer cancel()
-	return c.db.Chat.CreateOne(
-		db.Chat.Input.Set(chat),
+	chatModel, err := c.db.Chat.CreateOne(
+		db.Chat.Input.Set(redact.String(chat)),
 		db.Chat.Thread.Link(
 			db.Thread.ID.Equals(threadID),
 		),
-		db.Chat.Response.Set(response),
+		db.Chat.Response.Set(redact.String(response.Text)),
+		db.Chat.Model.Set(response.Usage.Model),
+		db.Chat.PromptTokens.Set(response.Usage.PromptTokens),
+		db.Chat.CompletionTokens.Set(response.Usage.CompletionTokens),
+		db.Chat.Cost.Set(response.Usage.Cost),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.db.Thread.FindUnique(
+		db.Thread.ID.Equals(threadID),
+	).Update(
+		db.Thread.PromptTokens.Increment(response.Usage.PromptTokens),
+		db.Thread.CompletionTokens.Increment(response.Usage.CompletionTokens),
+		db.Thread.Cost.Increment(response.Usage.Cost),
 	).Exec(ctx)
+	return chatModel, err
 }
@@ -0,0 +1,68 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chat
+
+import (
+	"testing"
+
+	db "github.com/matthisholleville/ava/internal/prisma"
+	"github.com/matthisholleville/ava/pkg/ai"
+	"github.com/matthisholleville/ava/pkg/ai/anthropic"
+	"github.com/matthisholleville/ava/pkg/ai/completion"
+	"github.com/matthisholleville/ava/pkg/ai/openai"
+)
+
+func TestKeepsHistory(t *testing.T) {
+	tests := []struct {
+		name     string
+		client   ai.IAI
+		db       *db.PrismaClient
+		persist  bool
+		expected bool
+	}{
+		{
+			name:     "openai keeps the threads on its server",
+			client:   &openai.OpenAIClient{},
+			expected: true,
+		},
+		{
+			name:     "completion without database",
+			client:   &completion.CompletionClient{},
+			expected: false,
+		},
+		{
+			name:     "anthropic with a database but no persistence",
+			client:   &anthropic.AnthropicClient{},
+			db:       db.NewClient(),
+			expected: false,
+		},
+		{
+			name:     "completion with persisted chats",
+			client:   &completion.CompletionClient{},
+			db:       db.NewClient(),
+			persist:  true,
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chat := &Chat{AIClient: test.client, db: test.db, Persist: test.persist}
+			if keeps := chat.KeepsHistory(); keeps != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, keeps)
+			}
+		})
+	}
+}
@@ -16,11 +16,142 @@ package common
 
 import (
 	"context"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/matthisholleville/ava/pkg/kubernetes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type Executor struct {
 	Client  *kubernetes.Client
 	Context context.Context
+	// Approver is asked to approve the write executors, if approvals are
+	// enabled.
+	Approver Approver
+	// DryRun simulates the write executors, they are sent to the API server
+	// with DryRun: All and recorded in Plan.
+	DryRun bool
+	Plan   *Plan
+	// AlertLabels are the labels of the alert being analyzed, if any.
+	AlertLabels map[string]string
+	// Auditor records the executor calls, identified by the thread, the run
+	// and the tool call of the model.
+	Auditor    Auditor
+	ThreadID   string
+	RunID      string
+	ToolCallID string
+}
+
+// WithToolCall returns the executor of a tool call of the model.
+func (e Executor) WithToolCall(runID, toolCallID string) Executor {
+	if runID != "" {
+		e.RunID = runID
+	}
+	e.ToolCallID = toolCallID
+	return e
+}
+
+// DryRunOptions returns the DryRun field of the Kubernetes write options.
+func (e Executor) DryRunOptions() []string {
+	if e.DryRun {
+		return []string{metav1.DryRunAll}
+	}
+	return nil
+}
+
+// Plan records the actions simulated by a dry run.
+type Plan struct {
+	mu      sync.Mutex
+	actions []string
+}
+
+func (p *Plan) Add(action string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.actions = append(p.actions, action)
+}
+
+func (p *Plan) Actions() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string{}, p.actions...)
+}
+
+// Approver asks a human whether the executor can run with the arguments.
+// It blocks until a decision is made and returns the reason of a rejection.
+type Approver interface {
+	Approve(ctx context.Context, executor, arguments string) (approved bool, by string, reason string)
+}
+
+// ExecutorCall is the record of an executor call, whether it ran or not.
+type ExecutorCall struct {
+	ThreadID   string
+	RunID      string
+	ToolCallID string
+	Executor   string
+	Arguments  string
+	// Output is the output sent to the model.
+	Output   string
+	Duration time.Duration
+	// Error is the reason why the call failed or did not run.
+	Error string
+	// Approver is the human who approved the call, if any.
+	Approver string
+	DryRun   bool
+	// Affected are the objects changed by the call.
+	Affected []ObjectRef
+}
+
+// Auditor records the executor calls.
+type Auditor interface {
+	Audit(ctx context.Context, call ExecutorCall)
+}
+
+// Result is the result of an executor call.
+type Result struct {
+	// Content is sent to the model.
+	Content string
+	// IsError is true if the call failed, Content is then the error.
+	IsError  bool
+	Metadata Metadata
+}
+
+// Metadata describes a call to the humans, it is not sent to the model.
+type Metadata struct {
+	// Affected are the objects changed, or that would be changed by a dry
+	// run.
+	Affected []ObjectRef
+}
+
+// ObjectRef identifies a Kubernetes object.
+type ObjectRef struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+func (o ObjectRef) String() string {
+	if o.Namespace == "" {
+		return fmt.Sprintf("%s/%s", o.Kind, o.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s", o.Kind, o.Namespace, o.Name)
+}
+
+// TextResult returns a successful result.
+func TextResult(content string) Result {
+	return Result{Content: content}
+}
+
+// ErrorResult returns a failed result, the message describes what failed.
+func ErrorResult(message string, err error) Result {
+	switch {
+	case err == nil:
+	case message == "":
+		message = err.Error()
+	default:
+		message = fmt.Sprintf("%s: %s", message, err.Error())
+	}
+	return Result{Content: message, IsError: true}
 }
@@ -19,6 +19,7 @@ import (
 
 	db "github.com/matthisholleville/ava/internal/prisma"
 	"github.com/matthisholleville/ava/pkg/events/slack"
+	"github.com/matthisholleville/ava/pkg/events/types"
 	"github.com/matthisholleville/ava/pkg/logger"
 )
 
@@ -47,4 +48,10 @@ type IEvent interface {
 	PersistEvent(eventID, threadID string) (*db.EventModel, error)
 	SendTechnicalErrorMessage(channelID, ts string) error
 	SendLookingMessage(channelID, ts string) error
+	// SendApprovalMessage asks to approve or reject the action with buttons.
+	SendApprovalMessage(channelID, ts, actionID, message string) error
+	// ProcessInteraction returns the decision of a click on an approval button.
+	ProcessInteraction(payload string) (types.Interaction, error)
+	// UpdateApprovalMessage replaces the buttons of the approval message with the decision.
+	UpdateApprovalMessage(interaction types.Interaction, message string) error
 }
@@ -16,6 +16,7 @@ package slack
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
@@ -31,6 +32,9 @@ import (
 
 const (
 	DEFAULT_SQL_TIMEOUT = 5 * time.Second
+
+	approveActionID = "approve"
+	rejectActionID  = "reject"
 )
 
 type SlackClient struct {
@@ -291,3 +295,66 @@ func (s *SlackClient) SendTechnicalErrorMessage(channelID, ts string) error {
 func (s *SlackClient) SendLookingMessage(channelID, ts string) error {
 	return s.SendMessage(channelID, ":eyes:", ts)
 }
+
+// SendApprovalMessage sends the message with Approve and Reject buttons
+// whose value is the ID of the action.
+func (s *SlackClient) SendApprovalMessage(channelID, ts, actionID, message string) error {
+	message = s.reformatMessage(message)
+
+	_, _, err := s.Client.PostMessage(
+		channelID,
+		slack.MsgOptionText(message, false),
+		slack.MsgOptionBlocks(
+			slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, message, false, false), nil, nil),
+			slack.NewActionBlock(
+				actionID,
+				slack.NewButtonBlockElement(approveActionID, actionID, slack.NewTextBlockObject(slack.PlainTextType, "Approve", false, false)).WithStyle(slack.StylePrimary),
+				slack.NewButtonBlockElement(rejectActionID, actionID, slack.NewTextBlockObject(slack.PlainTextType, "Reject", false, false)).WithStyle(slack.StyleDanger),
+			),
+		),
+		slack.MsgOptionTS(ts),
+	)
+	return err
+}
+
+// ProcessInteraction parses the payload sent by Slack when a user clicks on
+// an approval button.
+func (s *SlackClient) ProcessInteraction(payload string) (types.Interaction, error) {
+	var callback slack.InteractionCallback
+	if err := json.Unmarshal([]byte(payload), &callback); err != nil {
+		return types.Interaction{}, err
+	}
+
+	if callback.Type != slack.InteractionTypeBlockActions || len(callback.ActionCallback.BlockActions) == 0 {
+		return types.Interaction{}, fmt.Errorf("interaction %s ignored", callback.Type)
+	}
+
+	action := callback.ActionCallback.BlockActions[0]
+	if action.ActionID != approveActionID && action.ActionID != rejectActionID {
+		return types.Interaction{}, fmt.Errorf("action %s ignored", action.ActionID)
+	}
+
+	return types.Interaction{
+		Token:     callback.Token,
+		ActionID:  action.Value,
+		Approved:  action.ActionID == approveActionID,
+		User:      callback.User.ID,
+		ChannelID: callback.Channel.ID,
+		MessageTS: callback.Message.Timestamp,
+	}, nil
+}
+
+// UpdateApprovalMessage replaces the approval message and its buttons.
+func (s *SlackClient) UpdateApprovalMessage(interaction types.Interaction, message string) error {
+	message = s.reformatMessage(message)
+
+	_, _, _, err := s.Client.UpdateMessage(
+		interaction.ChannelID,
+		interaction.MessageTS,
+		slack.MsgOptionText(message, false),
+		slack.MsgOptionBlocks(
+			slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, message, false, false), nil, nil),
+		),
+	)
+	return err
+}
@@ -19,3 +19,13 @@ const TechnicalErrorMessage = `
 Looks like I’m experiencing a slight technical issue and can’t assist you right now.
 Don’t worry, I’m rebooting my circuits (and grabbing a coffee ☕)! Be back soon!
 `
+
+// Interaction is the decision of a user clicking an approval button.
+type Interaction struct {
+	Token     string
+	ActionID  string
+	Approved  bool
+	User      string
+	ChannelID string
+	MessageTS string
+}
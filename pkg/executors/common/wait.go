@@ -15,15 +15,15 @@
 package common
 
 import (
-	"encoding/json"
-	"strconv"
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/matthisholleville/ava/pkg/common"
 )
 
 type Wait struct {
-	Time string `json:"time"`
+	Time int `json:"time" jsonschema:"required,minimum=0,maximum=600" description:"Time to wait in seconds"`
 }
 
 func (Wait) GetName() string {
@@ -34,34 +34,14 @@ func (Wait) GetDescription() string {
 	return "Wait for a specified time in seconds"
 }
 
-func (Wait) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"time": {
-			"type": "string"
-			}
-		}
-	}
-	`
-}
+func (waitInfo Wait) Run(ctx context.Context, e common.Executor) common.Result {
+	timer := time.NewTimer(time.Duration(waitInfo.Time) * time.Second)
+	defer timer.Stop()
 
-func (Wait) Exec(e common.Executor, jsonString string) string {
-	// execute a check on the url and return the status code
-	var waitInfo Wait
-	err := json.Unmarshal([]byte(jsonString), &waitInfo)
-	if err != nil {
-		return "Error while retrieving the wait parameter:" + err.Error()
+	select {
+	case <-timer.C:
+		return common.TextResult(fmt.Sprintf("Waited for %d seconds", waitInfo.Time))
+	case <-ctx.Done():
+		return common.ErrorResult(fmt.Sprintf("the wait of %d seconds was interrupted", waitInfo.Time), ctx.Err())
 	}
-
-	// Convert the wait time to an integer
-	waitSeconds, err := strconv.Atoi(waitInfo.Time)
-	if err != nil {
-		return "Error while parsing the wait time: " + err.Error()
-	}
-
-	// Wait for the specified time
-	time.Sleep(time.Duration(waitSeconds) * time.Second)
-	return "Waited for " + waitInfo.Time + " seconds"
 }
@@ -15,84 +15,112 @@
 package executors
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"github.com/matthisholleville/ava/internal/configuration"
 	"github.com/matthisholleville/ava/pkg/common"
 	commonExecutorsPkg "github.com/matthisholleville/ava/pkg/executors/common"
 	"github.com/matthisholleville/ava/pkg/executors/kubernetes"
+	"github.com/matthisholleville/ava/pkg/executors/limits"
+	"github.com/matthisholleville/ava/pkg/executors/params"
+	"github.com/matthisholleville/ava/pkg/executors/plugin"
+	"github.com/matthisholleville/ava/pkg/executors/policy"
+	"github.com/matthisholleville/ava/pkg/executors/script"
 	"github.com/matthisholleville/ava/pkg/executors/web"
 	"github.com/matthisholleville/ava/pkg/logger"
+	"github.com/matthisholleville/ava/pkg/metrics"
 	"github.com/spf13/viper"
 )
 
 var (
-	k8sReadExecutors = map[string]IExecutor{
-		"describeService":            kubernetes.DescribeService{},
-		"getClusterRole":             kubernetes.GetClusterRole{},
-		"getCronJob":                 kubernetes.GetCronJob{},
-		"getConfigMap":               kubernetes.GetConfigMap{},
-		"getCrd":                     kubernetes.GetCRD{},
-		"getDaemonSet":               kubernetes.GetDaemonSet{},
-		"getDeployment":              kubernetes.GetDeployment{},
-		"getEndpointSlices":          kubernetes.GetEndpointSlice{},
-		"getHPA":                     kubernetes.GetHPA{},
-		"getIngress":                 kubernetes.GetIngress{},
-		"getLimitRange":              kubernetes.GetLimitRange{},
-		"getJob":                     kubernetes.GetJob{},
-		"getNode":                    kubernetes.GetNode{},
-		"getPod":                     kubernetes.GetPod{},
-		"getPdb":                     kubernetes.GetPDB{},
-		"getPersistentVolume":        kubernetes.GetPersistentVolume{},
-		"getPersistentVolumeClaim":   kubernetes.GetPersistentVolumeClaim{},
-		"getRole":                    kubernetes.GetRole{},
-		"getRoleBinding":             kubernetes.GetRoleBinding{},
-		"getServiceAccount":          kubernetes.GetServiceAccount{},
-		"getSecret":                  kubernetes.GetSecret{},
-		"getStorageClass":            kubernetes.GetStorageClass{},
-		"getStatefulSet":             kubernetes.GetStatefulSet{},
-		"listClusterRoles":           kubernetes.ListClusterRoles{},
-		"listCrds":                   kubernetes.ListCRDs{},
-		"listCronJobs":               kubernetes.ListCronJobs{},
-		"listConfigMaps":             kubernetes.ListConfigMaps{},
-		"listDaemonSets":             kubernetes.ListDaemonSets{},
-		"listDeployments":            kubernetes.ListDeployments{},
-		"listEndpointSlices":         kubernetes.ListEndpointSlices{},
-		"listIngresses":              kubernetes.ListIngresses{},
-		"listJobs":                   kubernetes.ListJobs{},
-		"listLimitRanges":            kubernetes.ListLimitRanges{},
-		"listNamespaces":             kubernetes.ListNamespaces{},
-		"listServicesAccounts":       kubernetes.ListServicesAccounts{},
-		"listSecrets":                kubernetes.ListSecrets{},
-		"listStorageClasses":         kubernetes.ListStorageClasses{},
-		"listStatefulSets":           kubernetes.ListStatefulSets{},
-		"listPods":                   kubernetes.ListPods{},
-		"listPersistentVolumes":      kubernetes.ListPersistentVolumes{},
-		"listPersistentVolumeClaims": kubernetes.ListPersistentVolumeClaims{},
-		"listPdbs":                   kubernetes.ListPDBs{},
-		"listRoles":                  kubernetes.ListRoles{},
-		"listRoleBindings":           kubernetes.ListRoleBindings{},
-		"podLogs":                    kubernetes.PodLogs{},
-		"topPods":                    kubernetes.TopPods{},
-	}
-
-	k8sWriteExecutors = map[string]IExecutor{
-		"deletePod":         kubernetes.DeletePod{},
-		"rolloutDeployment": kubernetes.RolloutDeployment{},
-	}
-
-	webExecutors = map[string]IExecutor{
-		"getUrl": web.GetUrl{},
-	}
-
-	commonExecutors = map[string]IExecutor{
-		"wait": commonExecutorsPkg.Wait{},
+	k8sReadExecutors = map[string]IExecutorV2{
+		"describeDeployment":         params.New[kubernetes.DescribeDeployment](),
+		"describeNode":               params.New[kubernetes.DescribeNode](),
+		"describePod":                params.New[kubernetes.DescribePod](),
+		"describeService":            params.New[kubernetes.DescribeService](),
+		"getClusterRole":             params.New[kubernetes.GetClusterRole](),
+		"getCronJob":                 params.New[kubernetes.GetCronJob](),
+		"getConfigMap":               params.New[kubernetes.GetConfigMap](),
+		"getCrd":                     params.New[kubernetes.GetCRD](),
+		"getDaemonSet":               params.New[kubernetes.GetDaemonSet](),
+		"getDeployment":              params.New[kubernetes.GetDeployment](),
+		"getEndpointSlices":          params.New[kubernetes.GetEndpointSlice](),
+		"getHPA":                     params.New[kubernetes.GetHPA](),
+		"getIngress":                 params.New[kubernetes.GetIngress](),
+		"getLimitRange":              params.New[kubernetes.GetLimitRange](),
+		"getJob":                     params.New[kubernetes.GetJob](),
+		"getNode":                    params.New[kubernetes.GetNode](),
+		"getPod":                     params.New[kubernetes.GetPod](),
+		"getPdb":                     params.New[kubernetes.GetPDB](),
+		"getPersistentVolume":        params.New[kubernetes.GetPersistentVolume](),
+		"getPersistentVolumeClaim":   params.New[kubernetes.GetPersistentVolumeClaim](),
+		"getResource":                params.New[kubernetes.GetResource](),
+		"getRole":                    params.New[kubernetes.GetRole](),
+		"getRoleBinding":             params.New[kubernetes.GetRoleBinding](),
+		"getServiceAccount":          params.New[kubernetes.GetServiceAccount](),
+		"getSecret":                  params.New[kubernetes.GetSecret](),
+		"getStorageClass":            params.New[kubernetes.GetStorageClass](),
+		"getStatefulSet":             params.New[kubernetes.GetStatefulSet](),
+		"listClusterRoles":           params.New[kubernetes.ListClusterRoles](),
+		"listCrds":                   params.New[kubernetes.ListCRDs](),
+		"listCronJobs":               params.New[kubernetes.ListCronJobs](),
+		"listConfigMaps":             params.New[kubernetes.ListConfigMaps](),
+		"listDaemonSets":             params.New[kubernetes.ListDaemonSets](),
+		"listDeployments":            params.New[kubernetes.ListDeployments](),
+		"listEndpointSlices":         params.New[kubernetes.ListEndpointSlices](),
+		"listEvents":                 params.New[kubernetes.ListEvents](),
+		"listIngresses":              params.New[kubernetes.ListIngresses](),
+		"listJobs":                   params.New[kubernetes.ListJobs](),
+		"listLimitRanges":            params.New[kubernetes.ListLimitRanges](),
+		"listNamespaces":             params.New[kubernetes.ListNamespaces](),
+		"listServicesAccounts":       params.New[kubernetes.ListServicesAccounts](),
+		"listSecrets":                params.New[kubernetes.ListSecrets](),
+		"listStorageClasses":         params.New[kubernetes.ListStorageClasses](),
+		"listStatefulSets":           params.New[kubernetes.ListStatefulSets](),
+		"listPods":                   params.New[kubernetes.ListPods](),
+		"listPersistentVolumes":      params.New[kubernetes.ListPersistentVolumes](),
+		"listPersistentVolumeClaims": params.New[kubernetes.ListPersistentVolumeClaims](),
+		"listResources":              params.New[kubernetes.ListResources](),
+		"listPdbs":                   params.New[kubernetes.ListPDBs](),
+		"listRoles":                  params.New[kubernetes.ListRoles](),
+		"listRoleBindings":           params.New[kubernetes.ListRoleBindings](),
+		"podLogs":                    params.New[kubernetes.PodLogs](),
+		"topPods":                    params.New[kubernetes.TopPods](),
+		"waitForRollout":             params.New[kubernetes.WaitForRollout](),
+		"waitForPodReady":            params.New[kubernetes.WaitForPodReady](),
+	}
+
+	k8sWriteExecutors = map[string]IExecutorV2{
+		"deletePod":          params.New[kubernetes.DeletePod](),
+		"rolloutDeployment":  params.New[kubernetes.RolloutDeployment](),
+		"rollbackDeployment": params.New[kubernetes.RollbackDeployment](),
+		"scaleDeployment":    params.New[kubernetes.ScaleDeployment](),
+		"scaleStatefulSet":   params.New[kubernetes.ScaleStatefulSet](),
+		"restartStatefulSet": params.New[kubernetes.RestartStatefulSet](),
+		"restartDaemonSet":   params.New[kubernetes.RestartDaemonSet](),
+		"cordonNode":         params.New[kubernetes.CordonNode](),
+		"uncordonNode":       params.New[kubernetes.UncordonNode](),
+		"drainNode":          params.New[kubernetes.DrainNode](),
+		"suspendCronJob":     params.New[kubernetes.SuspendCronJob](),
+		"resumeCronJob":      params.New[kubernetes.ResumeCronJob](),
+	}
+
+	webExecutors = map[string]IExecutorV2{
+		"getUrl": params.New[web.GetUrl](),
+	}
+
+	commonExecutors = map[string]IExecutorV2{
+		"wait": params.New[commonExecutorsPkg.Wait](),
 	}
 )
 
-func GetExecutors() map[string]IExecutor {
+func GetExecutors() map[string]IExecutorV2 {
 	logger := viper.Get("logger").(logger.ILogger)
 	configuration := configuration.LoadConfiguration(logger)
 
-	executors := make(map[string]IExecutor)
+	executors := make(map[string]IExecutorV2)
 
 	if configuration.Executors.K8S.Read {
 		for key, value := range k8sReadExecutors {
@@ -118,12 +146,215 @@ func GetExecutors() map[string]IExecutor {
 		}
 	}
 
+	for _, config := range configuration.Executors.HTTP {
+		executor, err := web.NewHTTPExecutor(config)
+		if err != nil {
+			logger.Error(err.Error())
+			continue
+		}
+		if _, ok := executors[config.Name]; ok {
+			logger.Error(fmt.Sprintf("HTTP executor %s is ignored, an executor has the same name", config.Name))
+			continue
+		}
+		executors[config.Name] = executor
+	}
+
+	for _, config := range configuration.Executors.Scripts.Executors {
+		executor, err := script.NewScriptExecutor(config, configuration.Executors.Scripts.Sandbox)
+		if err != nil {
+			logger.Error(err.Error())
+			continue
+		}
+		if _, ok := executors[config.Name]; ok {
+			logger.Error(fmt.Sprintf("Script executor %s is ignored, an executor has the same name", config.Name))
+			continue
+		}
+		executors[config.Name] = executor
+	}
+
+	for _, executor := range plugin.Default().Executors() {
+		if _, ok := executors[executor.GetName()]; ok {
+			logger.Error(fmt.Sprintf("Executor %s of the plugin %s is ignored, an executor has the same name", executor.GetName(), executor.Plugin()))
+			continue
+		}
+		executors[executor.GetName()] = executor
+	}
+
 	return executors
 }
 
-type IExecutor interface {
-	Exec(executor common.Executor, podName string) string
+// ValidateCustom checks the HTTP and script executors of the
+// configuration.
+func ValidateCustom(config configuration.Executors) error {
+	names := make(map[string]bool)
+	for _, registry := range []map[string]IExecutorV2{k8sReadExecutors, k8sWriteExecutors, webExecutors, commonExecutors} {
+		for name := range registry {
+			names[name] = true
+		}
+	}
+	unique := func(name string) error {
+		if names[name] {
+			return fmt.Errorf("executor %s: an executor has the same name", name)
+		}
+		names[name] = true
+		return nil
+	}
+
+	for _, c := range config.HTTP {
+		if _, err := web.NewHTTPExecutor(c); err != nil {
+			return err
+		}
+		if err := unique(c.Name); err != nil {
+			return err
+		}
+	}
+
+	if len(config.Scripts.Executors) > 0 {
+		if err := script.Check(config.Scripts.Sandbox); err != nil {
+			return err
+		}
+	}
+	for _, c := range config.Scripts.Executors {
+		if _, err := script.NewScriptExecutor(c, config.Scripts.Sandbox); err != nil {
+			return err
+		}
+		if err := unique(c.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Execute runs the executor requested by the model with its JSON arguments
+// and returns the output to send back to the model, redacted, compacted and
+// bounded. The policy is evaluated first, then the kill switch of the write
+// executors. During a dry run, the write executors are simulated without
+// limits nor approval. Every call is given to the auditor, if any.
+func Execute(e common.Executor, name, arguments string) string {
+	call := common.ExecutorCall{
+		ThreadID:   e.ThreadID,
+		RunID:      e.RunID,
+		ToolCallID: e.ToolCallID,
+		Executor:   name,
+		Arguments:  arguments,
+	}
+
+	start := time.Now()
+	call.Output = execute(e, &call)
+	call.Duration = time.Since(start)
+
+	if e.Auditor != nil {
+		e.Auditor.Audit(e.Context, call)
+	}
+	return call.Output
+}
+
+func execute(e common.Executor, call *common.ExecutorCall) string {
+	name, arguments := call.Executor, call.Arguments
+	executor, ok := GetExecutors()[name]
+	if !ok {
+		call.Error = "not found or not enabled"
+		return fmt.Sprintf("Executor %s not found or not enabled", name)
+	}
+
+	if err := executor.Validate(arguments); err != nil {
+		call.Error = err.Error()
+		return params.ErrorOutput(name, err)
+	}
+
+	write := isWrite(name, executor)
+	call.DryRun = e.DryRun && write
+
+	logger := viper.Get("logger").(logger.ILogger)
+	configuration := configuration.LoadConfiguration(logger)
+
+	engine, err := policy.New(configuration.Executors.Policy)
+	if err != nil {
+		return refuse(call, fmt.Sprintf("was denied, the policy is invalid: %s", err.Error()))
+	}
+	if reason, allowed := evaluatePolicy(e, engine, name, arguments); !allowed {
+		return refuse(call, reason)
+	}
+
+	if write && !e.DryRun {
+		// The write executors fail closed when the kill switch cannot be read.
+		disabled, err := limits.Default().WriteDisabled(e.Context)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Reading the kill switch failed: %s", err.Error()))
+		}
+		if err != nil || disabled {
+			metrics.ExecutorBlockedCounter.WithLabelValues(name, "killSwitch").Inc()
+			return refuse(call, "is blocked, the write executors are disabled")
+		}
+	}
+
+	if write && e.DryRun {
+		metrics.ExecutorCounter.WithLabelValues(name).Inc()
+		result := run(e, executor, arguments, timeout(configuration.Executors, name))
+		call.Affected = result.Metadata.Affected
+		if e.Plan != nil && !result.IsError {
+			e.Plan.Add(fmt.Sprintf("%s %s", name, arguments))
+		}
+		return fmt.Sprintf("Dry run, nothing was changed: %s", format(call, result, configuration.Executors.Output))
+	}
+
+	release, message, ok := limits.Check(e.Context, limits.Default(), configuration.Executors.Limits, name, limitKey(e, arguments))
+	if !ok {
+		metrics.ExecutorBlockedCounter.WithLabelValues(name, "limit").Inc()
+		return refuse(call, fmt.Sprintf("is blocked, %s", message))
+	}
+
+	if write && configuration.Executors.Approval.Enabled {
+		if e.Approver == nil {
+			release()
+			return refuse(call, "requires an approval but nobody can approve it")
+		}
+		approved, by, reason := e.Approver.Approve(e.Context, name, arguments)
+		call.Approver = by
+		if !approved {
+			release()
+			return refuse(call, fmt.Sprintf("was not approved: %s", reason))
+		}
+	}
+
+	metrics.ExecutorCounter.WithLabelValues(name).Inc()
+	result := run(e, executor, arguments, timeout(configuration.Executors, name))
+	call.Affected = result.Metadata.Affected
+
+	return format(call, result, configuration.Executors.Output)
+}
+
+// refuse records why the call did not run and returns the message sent to
+// the model.
+func refuse(call *common.ExecutorCall, reason string) string {
+	call.Error = reason
+	return fmt.Sprintf("Executor %s %s. Do not retry it, tell the user what you wanted to do instead.", call.Executor, reason)
+}
+
+// IsWriteExecutor returns true if the executor modifies the cluster or
+// something else, e.g. an HTTP executor marked as write.
+func IsWriteExecutor(name string) bool {
+	executor, ok := GetExecutors()[name]
+	return ok && isWrite(name, executor)
+}
+
+func isWrite(name string, executor IExecutorV2) bool {
+	if _, ok := k8sWriteExecutors[name]; ok {
+		return true
+	}
+	writer, ok := executor.(interface{ Write() bool })
+	return ok && writer.Write()
+}
+
+// IExecutorV2 is the interface of the executors. Run must return once ctx
+// is done, ctx is bounded by the timeout of the executor.
+type IExecutorV2 interface {
+	Run(ctx context.Context, executor common.Executor, arguments string) common.Result
+	// GetParams returns the JSON schema of the arguments.
 	GetParams() string
+	// Validate checks the arguments before the policy, the limits and the
+	// approval, the error is a *params.ValidationError.
+	Validate(arguments string) error
 	GetDescription() string
 	GetName() string
 }
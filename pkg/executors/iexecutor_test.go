@@ -0,0 +1,285 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executors
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matthisholleville/ava/internal/configuration"
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/matthisholleville/ava/pkg/kubernetes"
+	"github.com/matthisholleville/ava/pkg/logger"
+	"github.com/spf13/viper"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func setupExecutors(t *testing.T) *fake.Clientset {
+	t.Helper()
+	viper.Reset()
+	viper.Set("logger", logger.InitLogger("raw", "error"))
+	viper.Set("executors.enabled", true)
+	viper.Set("executors.k8s.write", true)
+	viper.Set("executors.k8s.read", true)
+
+	return fake.NewSimpleClientset(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+	})
+}
+
+func TestExecuteDryRun(t *testing.T) {
+	client := setupExecutors(t)
+
+	var dryRun []string
+	client.PrependReactor("delete", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		dryRun = action.(k8stesting.DeleteActionImpl).GetDeleteOptions().DryRun
+		return false, nil, nil
+	})
+
+	plan := &common.Plan{}
+	result := Execute(common.Executor{
+		Client:  &kubernetes.Client{Client: client},
+		Context: context.Background(),
+		DryRun:  true,
+		Plan:    plan,
+	}, "deletePod", `{"podName":"web","namespaceName":"default"}`)
+
+	if !slices.Equal(dryRun, []string{metav1.DryRunAll}) {
+		t.Errorf("the deletion should be sent with DryRun: All, got %v", dryRun)
+	}
+	if !strings.HasPrefix(result, "Dry run") {
+		t.Errorf("the output should tell the model it was a dry run: %s", result)
+	}
+	if actions := plan.Actions(); len(actions) != 1 || !strings.HasPrefix(actions[0], "deletePod ") {
+		t.Errorf("the action should be planned, got %v", actions)
+	}
+}
+
+type recordingAuditor struct {
+	calls []common.ExecutorCall
+}
+
+func (a *recordingAuditor) Audit(_ context.Context, call common.ExecutorCall) {
+	a.calls = append(a.calls, call)
+}
+
+func TestExecuteAudit(t *testing.T) {
+	client := setupExecutors(t)
+	viper.Set("executors.policy.rules", []map[string]interface{}{
+		{"name": "no-deletion", "effect": "deny", "executors": []string{"deletePod"}},
+	})
+
+	auditor := &recordingAuditor{}
+	e := common.Executor{
+		Client:   &kubernetes.Client{Client: client},
+		Context:  context.Background(),
+		Auditor:  auditor,
+		ThreadID: "thread_1",
+		RunID:    "run_1",
+	}
+
+	Execute(e.WithToolCall("", "call_1"), "getPod", `{"podName":"web","namespaceName":"default"}`)
+	Execute(e.WithToolCall("run_2", "call_2"), "deletePod", `{"podName":"web","namespaceName":"default"}`)
+
+	if len(auditor.calls) != 2 {
+		t.Fatalf("every call should be audited, got %d", len(auditor.calls))
+	}
+
+	get, deletion := auditor.calls[0], auditor.calls[1]
+	if get.ThreadID != "thread_1" || get.RunID != "run_1" || get.ToolCallID != "call_1" || get.Error != "" || get.Output == "" {
+		t.Errorf("unexpected record of getPod: %+v", get)
+	}
+	if deletion.RunID != "run_2" || !strings.Contains(deletion.Error, "no-deletion") {
+		t.Errorf("the denial should be recorded: %+v", deletion)
+	}
+}
+
+func TestExecutorsParams(t *testing.T) {
+	for _, registry := range []map[string]IExecutorV2{k8sReadExecutors, k8sWriteExecutors, webExecutors, commonExecutors} {
+		for name, executor := range registry {
+			if executor.GetName() != name {
+				t.Errorf("executor %s is named %s, the model could not call it", name, executor.GetName())
+			}
+			if !json.Valid([]byte(executor.GetParams())) {
+				t.Errorf("the parameters of %s are not valid JSON: %s", name, executor.GetParams())
+			}
+		}
+	}
+}
+
+func TestExecuteInvalidArguments(t *testing.T) {
+	client := setupExecutors(t)
+
+	deleted := false
+	client.PrependReactor("delete", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		deleted = true
+		return false, nil, nil
+	})
+
+	result := Execute(common.Executor{
+		Client:  &kubernetes.Client{Client: client},
+		Context: context.Background(),
+	}, "deletePod", `{"podName":"web"}`)
+
+	if deleted || !strings.Contains(result, `"field":"namespaceName"`) {
+		t.Errorf("the call should be refused before running: %s", result)
+	}
+}
+
+func TestExecuteTimeout(t *testing.T) {
+	setupExecutors(t)
+	viper.Set("executors.common.enabled", true)
+	// The timeout of all the executors does not shorten wait, its override
+	// does.
+	viper.Set("executors.timeout", "20ms")
+	viper.Set("executors.timeouts", []map[string]interface{}{{"executor": "wait", "timeout": "20ms"}})
+
+	auditor := &recordingAuditor{}
+	start := time.Now()
+	result := Execute(common.Executor{
+		Context: context.Background(),
+		Auditor: auditor,
+	}, "wait", `{"time":60}`)
+
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("the wait should be interrupted by the timeout")
+	}
+	if !strings.HasPrefix(result, `{"executor":"wait","error":`) {
+		t.Errorf("the output should be a structured error: %s", result)
+	}
+	if len(auditor.calls) != 1 || auditor.calls[0].Error == "" {
+		t.Errorf("the error should be audited: %+v", auditor.calls)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	overrides := []configuration.ExecutorTimeout{{Executor: "waitFor*", Timeout: 20 * time.Minute}}
+	tests := []struct {
+		config   configuration.Executors
+		executor string
+		expected time.Duration
+	}{
+		{configuration.Executors{}, "getPod", DEFAULT_TIMEOUT},
+		{configuration.Executors{}, "waitForRollout", waitTimeout},
+		{configuration.Executors{Timeout: 30 * time.Second}, "getPod", 30 * time.Second},
+		{configuration.Executors{Timeout: 30 * time.Second}, "wait", waitTimeout},
+		{configuration.Executors{Timeout: 20 * time.Millisecond}, "waitForPodReady", waitTimeout},
+		{configuration.Executors{Timeout: time.Hour}, "waitForRollout", time.Hour},
+		{configuration.Executors{Timeout: 30 * time.Second, Timeouts: overrides}, "waitForPodReady", 20 * time.Minute},
+		{configuration.Executors{Timeouts: overrides}, "getPod", DEFAULT_TIMEOUT},
+	}
+
+	for _, test := range tests {
+		if got := timeout(test.config, test.executor); got != test.expected {
+			t.Errorf("timeout of %s with %+v: got %s, expected %s", test.executor, test.config, got, test.expected)
+		}
+	}
+}
+
+func TestExecuteAffectedObjects(t *testing.T) {
+	client := setupExecutors(t)
+
+	auditor := &recordingAuditor{}
+	Execute(common.Executor{
+		Client:  &kubernetes.Client{Client: client},
+		Context: context.Background(),
+		Auditor: auditor,
+	}, "deletePod", `{"podName":"web","namespaceName":"default"}`)
+
+	want := []common.ObjectRef{{Kind: "Pod", Namespace: "default", Name: "web"}}
+	if len(auditor.calls) != 1 || !slices.Equal(auditor.calls[0].Affected, want) {
+		t.Errorf("the deleted pod should be recorded: %+v", auditor.calls)
+	}
+}
+
+func TestExecuteHTTPWriteExecutor(t *testing.T) {
+	setupExecutors(t)
+	viper.Set("executors.http", []map[string]interface{}{
+		{"name": "restartService", "method": "POST", "url": "http://127.0.0.1:1/restart", "write": true},
+		{"name": "getPod", "url": "http://127.0.0.1:1/pods"},
+	})
+
+	if !IsWriteExecutor("restartService") {
+		t.Errorf("restartService should be a write executor")
+	}
+	if err := ValidateCustom(configuration.Executors{HTTP: []configuration.HTTPExecutor{{Name: "getPod", URL: "http://127.0.0.1:1/pods"}}}); err == nil {
+		t.Errorf("an HTTP executor should not replace a built-in executor")
+	}
+
+	plan := &common.Plan{}
+	result := Execute(common.Executor{Context: context.Background(), DryRun: true, Plan: plan}, "restartService", `{}`)
+	if !strings.HasPrefix(result, "Dry run") || len(plan.Actions()) != 1 {
+		t.Errorf("the call should be simulated: %s", result)
+	}
+}
+
+func TestExecuteGenericResources(t *testing.T) {
+	setupExecutors(t)
+
+	gvk := schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout"}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(gvk, meta.RESTScopeNamespace)
+
+	rollout := func(name, app string) *unstructured.Unstructured {
+		object := &unstructured.Unstructured{}
+		object.SetGroupVersionKind(gvk)
+		object.SetNamespace("default")
+		object.SetName(name)
+		object.SetLabels(map[string]string{"app": app})
+		return object
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}: "RolloutList"},
+		rollout("web", "web"), rollout("api", "api"))
+
+	auditor := &recordingAuditor{}
+	e := common.Executor{
+		Client:  &kubernetes.Client{Client: fake.NewSimpleClientset(), DynamicClient: dynamicClient, RESTMapper: mapper},
+		Context: context.Background(),
+		Auditor: auditor,
+	}
+
+	if result := Execute(e, "getResource", `{"resource":"Rollout","namespaceName":"default","resourceName":"web"}`); !strings.Contains(result, `"name":"web"`) {
+		t.Errorf("the rollout should be found by its kind: %s", result)
+	}
+	result := Execute(e, "listResources", `{"resource":"rollouts.argoproj.io","labelSelector":"app=api"}`)
+	if !strings.Contains(result, "api") || strings.Contains(result, "web") {
+		t.Errorf("the rollouts should be listed by their plural and filtered: %s", result)
+	}
+	if result := Execute(e, "getResource", `{"resource":"scaledobjects","resourceName":"web"}`); !strings.Contains(result, "not served") {
+		t.Errorf("an unknown resource should be reported: %s", result)
+	}
+	if result := Execute(e, "getResource", `{"resource":"Rollout","namespaceName":"default","resourceName":"db"}`); !strings.Contains(result, "not found") {
+		t.Errorf("a missing rollout should be reported: %s", result)
+	}
+
+	for i, call := range auditor.calls {
+		if failed := call.Error != ""; failed != (i >= 2) {
+			t.Errorf("call %d of %s should be recorded as failed: %t, got %+v", i, call.Executor, i >= 2, call)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubectl/pkg/drain"
+)
+
+type CordonNode struct {
+	NodeName string `json:"nodeName" jsonschema:"required" description:"Name of the node"`
+}
+
+func (CordonNode) GetName() string {
+	return "cordonNode"
+}
+
+func (CordonNode) GetDescription() string {
+	return "Cordon a node so that no new pod is scheduled on it"
+}
+
+func (cordonInfo CordonNode) Run(ctx context.Context, e common.Executor) common.Result {
+	return cordon(ctx, e, cordonInfo.NodeName, true)
+}
+
+type UncordonNode struct {
+	NodeName string `json:"nodeName" jsonschema:"required" description:"Name of the node"`
+}
+
+func (UncordonNode) GetName() string {
+	return "uncordonNode"
+}
+
+func (UncordonNode) GetDescription() string {
+	return "Uncordon a node so that pods can be scheduled on it again"
+}
+
+func (uncordonInfo UncordonNode) Run(ctx context.Context, e common.Executor) common.Result {
+	return cordon(ctx, e, uncordonInfo.NodeName, false)
+}
+
+// cordon marks a node unschedulable or schedulable and reports its state
+// before and after.
+func cordon(ctx context.Context, e common.Executor, nodeName string, unschedulable bool) common.Result {
+	node, err := e.Client.GetClient().CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return common.ErrorResult("unable to retrieve the node", err)
+	}
+	state, err := setUnschedulable(ctx, e, node, unschedulable)
+	if err != nil {
+		return common.ErrorResult("unable to update the node", err)
+	}
+
+	result := common.TextResult(state)
+	result.Metadata.Affected = []common.ObjectRef{{Kind: "Node", Name: nodeName}}
+	return result
+}
+
+// setUnschedulable updates the node unless it is already in the desired
+// state and describes the change.
+func setUnschedulable(ctx context.Context, e common.Executor, node *corev1.Node, unschedulable bool) (string, error) {
+	schedulable := func(unschedulable bool) string {
+		if unschedulable {
+			return "unschedulable"
+		}
+		return "schedulable"
+	}
+	verb := "cordoned"
+	if !unschedulable {
+		verb = "uncordoned"
+	}
+
+	helper := drain.NewCordonHelper(node)
+	if !helper.UpdateIfRequired(unschedulable) {
+		return fmt.Sprintf("Node %s already %s", node.Name, schedulable(unschedulable)), nil
+	}
+	if err, patchErr := helper.PatchOrReplaceWithContext(ctx, e.Client.GetClient(), e.DryRun); err != nil {
+		if patchErr != nil {
+			return "", fmt.Errorf("%w, patch error: %s", err, patchErr.Error())
+		}
+		return "", err
+	}
+	if e.DryRun {
+		verb = "would be " + verb
+	}
+	return fmt.Sprintf("Node %s %s, %s before, %s after", node.Name, verb, schedulable(!unschedulable), schedulable(unschedulable)), nil
+}
@@ -0,0 +1,97 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type SuspendCronJob struct {
+	CronJobName   string `json:"cronJobName" jsonschema:"required" description:"Name of the cronjob"`
+	NamespaceName string `json:"namespaceName" jsonschema:"required" description:"Namespace of the cronjob"`
+}
+
+func (SuspendCronJob) GetName() string {
+	return "suspendCronJob"
+}
+
+func (SuspendCronJob) GetDescription() string {
+	return "Suspend a cronjob so that it does not create new jobs. The running jobs are not stopped"
+}
+
+func (suspendInfo SuspendCronJob) Run(ctx context.Context, e common.Executor) common.Result {
+	return suspendCronJob(ctx, e, suspendInfo.NamespaceName, suspendInfo.CronJobName, true)
+}
+
+type ResumeCronJob struct {
+	CronJobName   string `json:"cronJobName" jsonschema:"required" description:"Name of the cronjob"`
+	NamespaceName string `json:"namespaceName" jsonschema:"required" description:"Namespace of the cronjob"`
+}
+
+func (ResumeCronJob) GetName() string {
+	return "resumeCronJob"
+}
+
+func (ResumeCronJob) GetDescription() string {
+	return "Resume a suspended cronjob"
+}
+
+func (resumeInfo ResumeCronJob) Run(ctx context.Context, e common.Executor) common.Result {
+	return suspendCronJob(ctx, e, resumeInfo.NamespaceName, resumeInfo.CronJobName, false)
+}
+
+// suspendCronJob sets spec.suspend of a cronjob and reports its state
+// before and after, with its active jobs.
+func suspendCronJob(ctx context.Context, e common.Executor, namespace, name string, suspend bool) common.Result {
+	cronJobs := e.Client.GetClient().BatchV1().CronJobs(namespace)
+	cronJob, err := cronJobs.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return common.ErrorResult("unable to retrieve the cronjob", err)
+	}
+	suspended := cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend
+	state := func(suspended bool) string {
+		if suspended {
+			return "suspended"
+		}
+		return "active"
+	}
+	lastSchedule := "never"
+	if cronJob.Status.LastScheduleTime != nil {
+		lastSchedule = cronJob.Status.LastScheduleTime.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	details := fmt.Sprintf("schedule %q, last scheduled: %s, %d active jobs", cronJob.Spec.Schedule, lastSchedule, len(cronJob.Status.Active))
+
+	if suspended == suspend {
+		return common.TextResult(fmt.Sprintf("CronJob %s already %s, %s", name, state(suspend), details))
+	}
+
+	patch := []byte(fmt.Sprintf(`{"spec":{"suspend":%t}}`, suspend))
+	if _, err := cronJobs.Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{DryRun: e.DryRunOptions()}); err != nil {
+		return common.ErrorResult("failed to update the cronjob", err)
+	}
+
+	verb := "updated"
+	if e.DryRun {
+		verb = "would be updated"
+	}
+	result := common.TextResult(fmt.Sprintf("CronJob %s %s, %s before, %s after, %s", name, verb, state(suspended), state(suspend), details))
+	result.Metadata.Affected = []common.ObjectRef{{Kind: "CronJob", Namespace: namespace, Name: name}}
+	return result
+}
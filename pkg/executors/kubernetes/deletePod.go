@@ -15,7 +15,7 @@
 package kubernetes
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 
 	"github.com/matthisholleville/ava/pkg/common"
@@ -23,8 +23,8 @@ import (
 )
 
 type DeletePod struct {
-	PodName       string `json:"podName"`
-	NamespaceName string `json:"namespaceName"`
+	PodName       string `json:"podName" jsonschema:"required" description:"Name of the pod"`
+	NamespaceName string `json:"namespaceName" jsonschema:"required" description:"Namespace of the pod"`
 }
 
 func (DeletePod) GetName() string {
@@ -35,31 +35,18 @@ func (DeletePod) GetDescription() string {
 	return "Delete a pod"
 }
 
-func (DeletePod) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"podName": {
-			"type": "string"
-			},
-			"namespaceName": {
-			"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (DeletePod) Exec(e common.Executor, jsonString string) string {
-	var podInfo GetPod
-	err := json.Unmarshal([]byte(jsonString), &podInfo)
+func (podInfo DeletePod) Run(ctx context.Context, e common.Executor) common.Result {
+	err := e.Client.GetClient().CoreV1().Pods(podInfo.NamespaceName).Delete(ctx, podInfo.PodName, metav1.DeleteOptions{
+		DryRun: e.DryRunOptions(),
+	})
 	if err != nil {
-		return "Error while retrieving the podName parameter:" + err.Error()
+		return common.ErrorResult("unable to delete the pod", err)
 	}
-	err = e.Client.GetClient().CoreV1().Pods(podInfo.NamespaceName).Delete(e.Context, podInfo.PodName, metav1.DeleteOptions{})
-	if err != nil {
-		return "Unable to retrieve pod information." + err.Error()
+
+	result := common.TextResult(fmt.Sprintf("Pod %s deleted", podInfo.PodName))
+	if e.DryRun {
+		result.Content = fmt.Sprintf("Pod %s would be deleted", podInfo.PodName)
 	}
-	return fmt.Sprintf("Pod %s deleted", podInfo.PodName)
+	result.Metadata.Affected = []common.ObjectRef{{Kind: "Pod", Namespace: podInfo.NamespaceName, Name: podInfo.PodName}}
+	return result
 }
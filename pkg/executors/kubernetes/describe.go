@@ -0,0 +1,188 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes"
+)
+
+// describeEvents is the number of events of a described object.
+const describeEvents = 20
+
+// describer writes a summary like kubectl describe.
+type describer struct {
+	strings.Builder
+	now time.Time
+}
+
+func newDescriber() *describer {
+	return &describer{now: time.Now()}
+}
+
+// line writes a line indented by level.
+func (d *describer) line(level int, format string, args ...interface{}) {
+	d.WriteString(strings.Repeat("  ", level))
+	fmt.Fprintf(d, format, args...)
+	d.WriteString("\n")
+}
+
+// age returns the time elapsed since t, like kubectl.
+func (d *describer) age(t time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(d.now.Sub(t)) + " ago"
+}
+
+func (d *describer) labels(level int, title string, labels map[string]string) {
+	if len(labels) == 0 {
+		d.line(level, "%s: <none>", title)
+		return
+	}
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, labels[key]))
+	}
+	d.line(level, "%s: %s", title, strings.Join(pairs, ", "))
+}
+
+// ownerChain writes the controllers of an object up to the top one, e.g. a
+// ReplicaSet and its Deployment.
+func (d *describer) ownerChain(ctx context.Context, client kubernetes.Interface, namespace string, owners []metav1.OwnerReference) {
+	var chain []string
+	for depth := 0; depth < 5; depth++ {
+		owner := metav1.GetControllerOfNoCopy(&metav1.ObjectMeta{OwnerReferences: owners})
+		if owner == nil {
+			break
+		}
+		chain = append(chain, fmt.Sprintf("%s/%s", owner.Kind, owner.Name))
+
+		get, ok := ownerGetters[owner.Kind]
+		if !ok {
+			break
+		}
+		object, err := get(ctx, client, namespace, owner.Name)
+		if err != nil {
+			chain = append(chain, fmt.Sprintf("<%s>", err.Error()))
+			break
+		}
+		owners = object.GetOwnerReferences()
+	}
+
+	if len(chain) == 0 {
+		d.line(0, "Controlled By: <none>")
+		return
+	}
+	d.line(0, "Controlled By: %s", strings.Join(chain, " <- "))
+}
+
+// ownerGetters fetch the controllers which have a controller themselves.
+var ownerGetters = map[string]func(ctx context.Context, client kubernetes.Interface, namespace, name string) (metav1.Object, error){
+	"ReplicaSet": func(ctx context.Context, client kubernetes.Interface, namespace, name string) (metav1.Object, error) {
+		return client.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	},
+	"Job": func(ctx context.Context, client kubernetes.Interface, namespace, name string) (metav1.Object, error) {
+		return client.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	},
+}
+
+// events writes the last events of an object.
+func (d *describer) events(ctx context.Context, client kubernetes.Interface, namespace, kind, name string, uid types.UID) {
+	selector := fields.Set{"involvedObject.kind": kind, "involvedObject.name": name}.AsSelector().String()
+	list, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: selector})
+	if err != nil {
+		d.line(0, "Events: <%s>", err.Error())
+		return
+	}
+
+	var events []corev1.Event
+	for _, event := range list.Items {
+		involved := event.InvolvedObject
+		if involved.Kind == kind && involved.Name == name && (uid == "" || involved.UID == "" || involved.UID == uid) {
+			events = append(events, event)
+		}
+	}
+	if len(events) == 0 {
+		d.line(0, "Events: <none>")
+		return
+	}
+
+	sortEvents(events)
+	if len(events) > describeEvents {
+		events = events[len(events)-describeEvents:]
+	}
+	d.line(0, "Events:")
+	for _, event := range events {
+		d.line(1, "%s %s %s (x%d): %s", d.age(lastSeen(event)), event.Type, event.Reason, max(event.Count, 1), strings.TrimSpace(event.Message))
+	}
+}
+
+// lastSeen returns the last time an event happened.
+func lastSeen(event corev1.Event) time.Time {
+	switch {
+	case event.Series != nil:
+		return event.Series.LastObservedTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// sortEvents sorts the events from the oldest to the last seen.
+func sortEvents(events []corev1.Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return lastSeen(events[i]).Before(lastSeen(events[j]))
+	})
+}
+
+type condition struct {
+	Type, Status, Reason, Message string
+}
+
+func (d *describer) conditions(conditions []condition) {
+	if len(conditions) == 0 {
+		return
+	}
+	d.line(0, "Conditions:")
+	for _, c := range conditions {
+		line := fmt.Sprintf("%s=%s", c.Type, c.Status)
+		if c.Reason != "" {
+			line += " " + c.Reason
+		}
+		if c.Message != "" {
+			line += ": " + c.Message
+		}
+		d.line(1, "%s", line)
+	}
+}
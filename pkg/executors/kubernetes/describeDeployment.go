@@ -0,0 +1,136 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// revisionAnnotation is the revision of the ReplicaSets of a Deployment.
+const revisionAnnotation = "deployment.kubernetes.io/revision"
+
+type DescribeDeployment struct {
+	DeploymentName string `json:"deploymentName" jsonschema:"required" description:"Name of the deployment"`
+	NamespaceName  string `json:"namespaceName" jsonschema:"required" description:"Namespace of the deployment"`
+}
+
+func (DescribeDeployment) GetName() string {
+	return "describeDeployment"
+}
+
+func (DescribeDeployment) GetDescription() string {
+	return "Describe a deployment like kubectl describe: replicas, strategy, conditions, images, ReplicaSets by revision and the recent events"
+}
+
+func (deploymentInfo DescribeDeployment) Run(ctx context.Context, e common.Executor) common.Result {
+	client := e.Client.GetClient()
+	deployment, err := client.AppsV1().Deployments(deploymentInfo.NamespaceName).Get(ctx, deploymentInfo.DeploymentName, metav1.GetOptions{})
+	if err != nil {
+		return common.ErrorResult("unable to get the deployment", err)
+	}
+
+	d := newDescriber()
+	d.line(0, "Name: %s", deployment.Name)
+	d.line(0, "Namespace: %s", deployment.Namespace)
+	d.line(0, "Created: %s", d.age(deployment.CreationTimestamp.Time))
+	d.labels(0, "Labels", deployment.Labels)
+	d.line(0, "Selector: %s", metav1.FormatLabelSelector(deployment.Spec.Selector))
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	d.line(0, "Replicas: %d desired, %d updated, %d total, %d available, %d unavailable",
+		desired, deployment.Status.UpdatedReplicas, deployment.Status.Replicas, deployment.Status.AvailableReplicas, deployment.Status.UnavailableReplicas)
+	d.line(0, "Strategy: %s", deployment.Spec.Strategy.Type)
+	if rolling := deployment.Spec.Strategy.RollingUpdate; rolling != nil {
+		d.line(1, "Max Unavailable: %s, Max Surge: %s", rolling.MaxUnavailable, rolling.MaxSurge)
+	}
+	d.line(0, "Paused: %t", deployment.Spec.Paused)
+	d.line(0, "Revision: %s", valueOr(deployment.Annotations[revisionAnnotation], "<none>"))
+
+	d.line(0, "Pod Template:")
+	d.labels(1, "Labels", deployment.Spec.Template.Labels)
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		d.line(1, "%s: %s", container.Name, container.Image)
+	}
+
+	var conditions []condition
+	for _, c := range deployment.Status.Conditions {
+		conditions = append(conditions, condition{string(c.Type), string(c.Status), c.Reason, c.Message})
+	}
+	d.conditions(conditions)
+
+	owned, err := ownedReplicaSets(ctx, client, deployment)
+	if err != nil {
+		d.line(0, "ReplicaSets: <%s>", err.Error())
+	} else {
+		d.line(0, "ReplicaSets:")
+		for _, rs := range owned {
+			d.line(1, "%s: revision %s, %d/%d ready, %s, created %s", rs.Name, valueOr(rs.Annotations[revisionAnnotation], "?"),
+				rs.Status.ReadyReplicas, rs.Status.Replicas, templateImages(rs.Spec.Template), d.age(rs.CreationTimestamp.Time))
+		}
+	}
+
+	d.events(ctx, client, deployment.Namespace, "Deployment", deployment.Name, deployment.UID)
+	return common.TextResult(d.String())
+}
+
+// ownedReplicaSets returns the ReplicaSets controlled by a deployment, the
+// last revision first.
+func ownedReplicaSets(ctx context.Context, client kubernetes.Interface, deployment *appsv1.Deployment) ([]appsv1.ReplicaSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	replicaSets, err := client.AppsV1().ReplicaSets(deployment.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	owned := replicaSets.Items[:0]
+	for _, rs := range replicaSets.Items {
+		if owner := metav1.GetControllerOfNoCopy(&rs); owner != nil && owner.UID == deployment.UID {
+			owned = append(owned, rs)
+		}
+	}
+	sort.Slice(owned, func(i, j int) bool {
+		return replicaSetRevision(owned[i]) > replicaSetRevision(owned[j])
+	})
+	return owned, nil
+}
+
+func replicaSetRevision(rs appsv1.ReplicaSet) int {
+	revision, _ := strconv.Atoi(rs.Annotations[revisionAnnotation])
+	return revision
+}
+
+// templateImages returns the images of the containers of a pod template.
+func templateImages(template corev1.PodTemplateSpec) string {
+	images := make([]string, 0, len(template.Spec.Containers))
+	for _, container := range template.Spec.Containers {
+		images = append(images, container.Image)
+	}
+	return strings.Join(images, ",")
+}
@@ -0,0 +1,149 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// nodeRolePrefix is the prefix of the labels of the roles of a node.
+const nodeRolePrefix = "node-role.kubernetes.io/"
+
+type DescribeNode struct {
+	NodeName string `json:"nodeName" jsonschema:"required" description:"Name of the node"`
+}
+
+func (DescribeNode) GetName() string {
+	return "describeNode"
+}
+
+func (DescribeNode) GetDescription() string {
+	return "Describe a node like kubectl describe: roles, conditions, taints, capacity, the pods and their allocated resources, and the recent events"
+}
+
+func (nodeInfo DescribeNode) Run(ctx context.Context, e common.Executor) common.Result {
+	client := e.Client.GetClient()
+	node, err := client.CoreV1().Nodes().Get(ctx, nodeInfo.NodeName, metav1.GetOptions{})
+	if err != nil {
+		return common.ErrorResult("unable to get the node", err)
+	}
+
+	d := newDescriber()
+	d.line(0, "Name: %s", node.Name)
+	var roles []string
+	for label := range node.Labels {
+		if role, ok := strings.CutPrefix(label, nodeRolePrefix); ok {
+			roles = append(roles, role)
+		}
+	}
+	sort.Strings(roles)
+	d.line(0, "Roles: %s", valueOr(strings.Join(roles, ", "), "<none>"))
+	d.line(0, "Created: %s", d.age(node.CreationTimestamp.Time))
+	d.labels(0, "Labels", node.Labels)
+	d.line(0, "Unschedulable: %t", node.Spec.Unschedulable)
+
+	var taints []string
+	for _, taint := range node.Spec.Taints {
+		taints = append(taints, taint.ToString())
+	}
+	d.line(0, "Taints: %s", valueOr(strings.Join(taints, ", "), "<none>"))
+
+	var addresses []string
+	for _, address := range node.Status.Addresses {
+		addresses = append(addresses, fmt.Sprintf("%s=%s", address.Type, address.Address))
+	}
+	d.line(0, "Addresses: %s", strings.Join(addresses, ", "))
+
+	info := node.Status.NodeInfo
+	d.line(0, "System: kubelet %s, %s %s, %s, runtime %s", info.KubeletVersion, info.OperatingSystem, info.Architecture, info.OSImage, info.ContainerRuntimeVersion)
+
+	var conditions []condition
+	for _, c := range node.Status.Conditions {
+		conditions = append(conditions, condition{string(c.Type), string(c.Status), c.Reason, c.Message})
+	}
+	d.conditions(conditions)
+
+	d.line(0, "Capacity: %s", resourceList(node.Status.Capacity))
+	d.line(0, "Allocatable: %s", resourceList(node.Status.Allocatable))
+
+	pods, err := client.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.AndSelectors(
+			fields.OneTermEqualSelector("spec.nodeName", node.Name),
+			fields.OneTermNotEqualSelector("status.phase", string(corev1.PodSucceeded)),
+			fields.OneTermNotEqualSelector("status.phase", string(corev1.PodFailed)),
+		).String(),
+	})
+	if err != nil {
+		d.line(0, "Pods: <%s>", err.Error())
+	} else {
+		d.nodePods(node, pods.Items)
+	}
+
+	d.events(ctx, client, "", "Node", node.Name, "")
+	return common.TextResult(d.String())
+}
+
+// nodePods writes the pods running on the node and the resources they
+// request, out of the allocatable ones.
+func (d *describer) nodePods(node *corev1.Node, pods []corev1.Pod) {
+	requests, limits := corev1.ResourceList{}, corev1.ResourceList{}
+	var running []corev1.Pod
+	for _, pod := range pods {
+		if pod.Spec.NodeName != node.Name || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		running = append(running, pod)
+		for _, container := range pod.Spec.Containers {
+			addResources(requests, container.Resources.Requests)
+			addResources(limits, container.Resources.Limits)
+		}
+	}
+
+	d.line(0, "Pods: %d", len(running))
+	for _, pod := range running {
+		d.line(1, "%s/%s: %s", pod.Namespace, pod.Name, pod.Status.Phase)
+	}
+
+	d.line(0, "Allocated Resources:")
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		allocatable := node.Status.Allocatable[name]
+		request, limit := requests[name], limits[name]
+		d.line(1, "%s: requests %s (%s), limits %s (%s)", name, request.String(), percent(request, allocatable), limit.String(), percent(limit, allocatable))
+	}
+}
+
+func addResources(total, resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		sum := total[name]
+		sum.Add(quantity)
+		total[name] = sum
+	}
+}
+
+func percent(quantity, total resource.Quantity) string {
+	if total.IsZero() {
+		return "?"
+	}
+	return fmt.Sprintf("%d%%", quantity.MilliValue()*100/total.MilliValue())
+}
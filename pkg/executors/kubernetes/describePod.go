@@ -0,0 +1,146 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type DescribePod struct {
+	PodName       string `json:"podName" jsonschema:"required" description:"Name of the pod"`
+	NamespaceName string `json:"namespaceName" jsonschema:"required" description:"Namespace of the pod"`
+}
+
+func (DescribePod) GetName() string {
+	return "describePod"
+}
+
+func (DescribePod) GetDescription() string {
+	return "Describe a pod like kubectl describe: status, controllers, conditions, containers with their last termination and the recent events. Start here when a pod crashloops or is pending"
+}
+
+func (podInfo DescribePod) Run(ctx context.Context, e common.Executor) common.Result {
+	client := e.Client.GetClient()
+	pod, err := client.CoreV1().Pods(podInfo.NamespaceName).Get(ctx, podInfo.PodName, metav1.GetOptions{})
+	if err != nil {
+		return common.ErrorResult("unable to get the pod", err)
+	}
+
+	d := newDescriber()
+	d.line(0, "Name: %s", pod.Name)
+	d.line(0, "Namespace: %s", pod.Namespace)
+	d.line(0, "Node: %s", valueOr(pod.Spec.NodeName, "<none>"))
+	if pod.Status.StartTime != nil {
+		d.line(0, "Started: %s", d.age(pod.Status.StartTime.Time))
+	}
+	d.labels(0, "Labels", pod.Labels)
+	status := string(pod.Status.Phase)
+	if pod.DeletionTimestamp != nil {
+		status = fmt.Sprintf("Terminating (since %s)", d.age(pod.DeletionTimestamp.Time))
+	}
+	d.line(0, "Status: %s", status)
+	if pod.Status.Reason != "" || pod.Status.Message != "" {
+		d.line(0, "Reason: %s %s", pod.Status.Reason, pod.Status.Message)
+	}
+	d.line(0, "IP: %s", valueOr(pod.Status.PodIP, "<none>"))
+	d.line(0, "QoS Class: %s", pod.Status.QOSClass)
+	d.ownerChain(ctx, client, pod.Namespace, pod.OwnerReferences)
+
+	d.containers("Init Containers", pod.Spec.InitContainers, pod.Status.InitContainerStatuses)
+	d.containers("Containers", pod.Spec.Containers, pod.Status.ContainerStatuses)
+
+	var conditions []condition
+	for _, c := range pod.Status.Conditions {
+		conditions = append(conditions, condition{string(c.Type), string(c.Status), c.Reason, c.Message})
+	}
+	d.conditions(conditions)
+
+	d.events(ctx, client, pod.Namespace, "Pod", pod.Name, pod.UID)
+	return common.TextResult(d.String())
+}
+
+func (d *describer) containers(title string, containers []corev1.Container, statuses []corev1.ContainerStatus) {
+	if len(containers) == 0 {
+		return
+	}
+	byName := make(map[string]corev1.ContainerStatus, len(statuses))
+	for _, status := range statuses {
+		byName[status.Name] = status
+	}
+
+	d.line(0, "%s:", title)
+	for _, container := range containers {
+		d.line(1, "%s:", container.Name)
+		d.line(2, "Image: %s", container.Image)
+		if status, ok := byName[container.Name]; ok {
+			d.line(2, "State: %s", d.containerState(status.State))
+			if status.LastTerminationState.Terminated != nil {
+				d.line(2, "Last State: %s", d.containerState(status.LastTerminationState))
+			}
+			d.line(2, "Ready: %t", status.Ready)
+			d.line(2, "Restart Count: %d", status.RestartCount)
+		}
+		if len(container.Resources.Requests) > 0 {
+			d.line(2, "Requests: %s", resourceList(container.Resources.Requests))
+		}
+		if len(container.Resources.Limits) > 0 {
+			d.line(2, "Limits: %s", resourceList(container.Resources.Limits))
+		}
+	}
+}
+
+func (d *describer) containerState(state corev1.ContainerState) string {
+	switch {
+	case state.Running != nil:
+		return fmt.Sprintf("Running, started %s", d.age(state.Running.StartedAt.Time))
+	case state.Waiting != nil:
+		return strings.TrimSpace(fmt.Sprintf("Waiting %s %s", state.Waiting.Reason, state.Waiting.Message))
+	case state.Terminated != nil:
+		t := state.Terminated
+		result := fmt.Sprintf("Terminated %s, exit code %d, finished %s", t.Reason, t.ExitCode, d.age(t.FinishedAt.Time))
+		if t.Signal != 0 {
+			result += fmt.Sprintf(", signal %d", t.Signal)
+		}
+		if t.Message != "" {
+			result += ": " + strings.TrimSpace(t.Message)
+		}
+		return result
+	default:
+		return "<unknown>"
+	}
+}
+
+func resourceList(resources corev1.ResourceList) string {
+	pairs := make([]string, 0, len(resources))
+	for name, quantity := range resources {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", name, quantity.String()))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+func valueOr(value, empty string) string {
+	if value == "" {
+		return empty
+	}
+	return value
+}
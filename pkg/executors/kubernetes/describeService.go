@@ -22,8 +22,8 @@ import (
 )
 
 type DescribeService struct {
-	ServiceName   string `json:"serviceName"`
-	NamespaceName string `json:"namespaceName"`
+	ServiceName   string `json:"serviceName" jsonschema:"required" description:"Name of the service"`
+	NamespaceName string `json:"namespaceName" jsonschema:"required" description:"Namespace of the service"`
 }
 
 func (DescribeService) GetName() string {
@@ -34,28 +34,7 @@ func (DescribeService) GetDescription() string {
 	return "Describe details of a service"
 }
 
-func (DescribeService) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"serviceName": {
-				"type": "string"
-			},
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (DescribeService) Exec(e common.Executor, jsonString string) string {
-	var serviceInfo DescribeService
-	err := json.Unmarshal([]byte(jsonString), &serviceInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (serviceInfo DescribeService) Run(e common.Executor) string {
 	service, err := e.Client.GetClient().CoreV1().Services(serviceInfo.NamespaceName).Get(e.Context, serviceInfo.ServiceName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to describe service: " + err.Error()
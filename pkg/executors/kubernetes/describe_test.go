@@ -0,0 +1,159 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/matthisholleville/ava/pkg/kubernetes"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// crashingPod is a pod of a Deployment restarting after an OOM kill.
+func crashingPod() common.Executor {
+	now := metav1.NewTime(time.Now())
+	controller := true
+	objects := []runtime.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "deployment"},
+			Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+		},
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "web-6d4", Namespace: "default",
+				Labels:          map[string]string{"app": "web"},
+				Annotations:     map[string]string{revisionAnnotation: "3"},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", UID: "deployment", Controller: &controller}},
+			},
+			Spec: appsv1.ReplicaSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "web", Image: "web:1.2"},
+				{Name: "proxy", Image: "envoy:1.31"},
+			}}}},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{nodeRolePrefix + "worker": ""}},
+			Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "web-6d4-x", Namespace: "default", UID: "pod",
+				Labels:          map[string]string{"app": "web"},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-6d4", Controller: &controller}},
+			},
+			Spec: corev1.PodSpec{NodeName: "node-1", Containers: []corev1.Container{{
+				Name:      "app",
+				Image:     "web:1.2",
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")}},
+			}}},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:                 "app",
+					State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137, FinishedAt: now}},
+					RestartCount:         7,
+				}},
+			},
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "web-6d4-x.1", Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web-6d4-x", Namespace: "default", UID: "pod"},
+			Reason:         "BackOff",
+			Type:           corev1.EventTypeWarning,
+			Count:          12,
+			LastTimestamp:  now,
+			Message:        "Back-off restarting failed container app",
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "other.1", Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "other", Namespace: "default"},
+			Reason:         "Pulled",
+			Type:           corev1.EventTypeNormal,
+			LastTimestamp:  metav1.NewTime(now.Add(-2 * time.Hour)),
+		},
+	}
+
+	return common.Executor{
+		Client:  &kubernetes.Client{Client: fake.NewSimpleClientset(objects...)},
+		Context: context.Background(),
+	}
+}
+
+func TestDescribePod(t *testing.T) {
+	e := crashingPod()
+	result := DescribePod{PodName: "web-6d4-x", NamespaceName: "default"}.Run(context.Background(), e)
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content)
+	}
+
+	for _, want := range []string{
+		"Controlled By: ReplicaSet/web-6d4 <- Deployment/web",
+		"State: Waiting CrashLoopBackOff",
+		"Last State: Terminated OOMKilled, exit code 137",
+		"Restart Count: 7",
+		"Warning BackOff (x12): Back-off restarting failed container app",
+	} {
+		if !strings.Contains(result.Content, want) {
+			t.Errorf("the description should contain %q:\n%s", want, result.Content)
+		}
+	}
+	if strings.Contains(result.Content, "Pulled") {
+		t.Errorf("the events of the other objects should be left out:\n%s", result.Content)
+	}
+}
+
+func TestDescribeDeploymentAndNode(t *testing.T) {
+	e := crashingPod()
+
+	result := DescribeDeployment{DeploymentName: "web", NamespaceName: "default"}.Run(context.Background(), e)
+	if result.IsError || !strings.Contains(result.Content, "web-6d4: revision 3") || !strings.Contains(result.Content, "web:1.2,envoy:1.31") {
+		t.Errorf("the ReplicaSets should be listed by revision with the images of all their containers: %+v", result)
+	}
+
+	result = DescribeNode{NodeName: "node-1"}.Run(context.Background(), e)
+	if result.IsError || !strings.Contains(result.Content, "Roles: worker") || !strings.Contains(result.Content, "default/web-6d4-x") || !strings.Contains(result.Content, "cpu: requests 500m (25%)") {
+		t.Errorf("the pods of the node and their requests should be described: %+v", result)
+	}
+}
+
+func TestListEvents(t *testing.T) {
+	e := crashingPod()
+
+	result := ListEvents{NamespaceName: "default", Since: "1h"}.Run(context.Background(), e)
+	if result.IsError || !strings.Contains(result.Content, "Pod/web-6d4-x") || strings.Contains(result.Content, "Pulled") {
+		t.Errorf("only the recent events should be listed: %+v", result)
+	}
+
+	result = ListEvents{InvolvedObjectName: "other"}.Run(context.Background(), e)
+	if result.IsError || !strings.Contains(result.Content, "default/Pod/other") || strings.Contains(result.Content, "BackOff") {
+		t.Errorf("the events should be filtered by object: %+v", result)
+	}
+
+	if result := (ListEvents{Since: "yesterday"}).Run(context.Background(), e); !result.IsError {
+		t.Errorf("an invalid duration should be refused: %+v", result)
+	}
+}
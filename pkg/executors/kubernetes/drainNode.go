@@ -0,0 +1,157 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/drain"
+)
+
+type DrainNode struct {
+	NodeName           string `json:"nodeName" jsonschema:"required" description:"Name of the node"`
+	DeleteEmptyDirData bool   `json:"deleteEmptyDirData" description:"Evict the pods using emptyDir volumes, their data is lost"`
+}
+
+func (DrainNode) GetName() string {
+	return "drainNode"
+}
+
+func (DrainNode) GetDescription() string {
+	return "Cordon a node and evict its pods, like kubectl drain. DaemonSet pods are ignored and the evictions blocked by a PodDisruptionBudget are reported, not forced"
+}
+
+func (drainInfo DrainNode) Run(ctx context.Context, e common.Executor) common.Result {
+	client := e.Client.GetClient()
+	node, err := client.CoreV1().Nodes().Get(ctx, drainInfo.NodeName, metav1.GetOptions{})
+	if err != nil {
+		return common.ErrorResult("unable to retrieve the node", err)
+	}
+
+	helper := &drain.Helper{
+		Ctx:                 ctx,
+		Client:              client,
+		GracePeriodSeconds:  -1,
+		IgnoreAllDaemonSets: true,
+		DeleteEmptyDirData:  drainInfo.DeleteEmptyDirData,
+		Out:                 io.Discard,
+		ErrOut:              io.Discard,
+	}
+	if e.DryRun {
+		helper.DryRunStrategy = cmdutil.DryRunServer
+	}
+
+	// Check the pods before cordoning so that a node which cannot be drained
+	// is left untouched.
+	list, errs := helper.GetPodsForDeletion(node.Name)
+	if len(errs) > 0 {
+		messages := make([]string, 0, len(errs))
+		for _, err := range errs {
+			messages = append(messages, err.Error())
+		}
+		return common.ErrorResult("unable to drain the node: "+strings.Join(messages, "; "), nil)
+	}
+	// Deleting the pods would bypass the PodDisruptionBudgets.
+	evictionVersion, err := drain.CheckEvictionSupport(client)
+	if err != nil {
+		return common.ErrorResult("unable to check the eviction support", err)
+	}
+	if evictionVersion.Empty() {
+		return common.ErrorResult("the cluster does not support evictions", nil)
+	}
+
+	before := nodePodCount(ctx, client, node.Name)
+	state, err := setUnschedulable(ctx, e, node, true)
+	if err != nil {
+		return common.ErrorResult("unable to cordon the node", err)
+	}
+
+	var evicted, blocked, failed []string
+	affected := []common.ObjectRef{{Kind: "Node", Name: node.Name}}
+	for _, pod := range list.Pods() {
+		name := pod.Namespace + "/" + pod.Name
+		err := helper.EvictPod(pod, evictionVersion)
+		switch {
+		case err == nil:
+			evicted = append(evicted, name)
+			affected = append(affected, common.ObjectRef{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name})
+		case apierrors.IsNotFound(err):
+		case apierrors.IsTooManyRequests(err):
+			blocked = append(blocked, fmt.Sprintf("%s: %s", name, err.Error()))
+		default:
+			failed = append(failed, fmt.Sprintf("%s: %s", name, err.Error()))
+		}
+	}
+
+	verb := "Evicted"
+	if e.DryRun {
+		verb = "Would evict"
+	}
+	lines := []string{state, fmt.Sprintf("%s %d pods%s", verb, len(evicted), podNames(evicted))}
+	if len(blocked) > 0 {
+		lines = append(lines, fmt.Sprintf("Blocked by a PodDisruptionBudget, retry later: %d pods%s", len(blocked), podNames(blocked)))
+	}
+	if len(failed) > 0 {
+		lines = append(lines, fmt.Sprintf("Failed: %d pods%s", len(failed), podNames(failed)))
+	}
+	if warnings := list.Warnings(); warnings != "" {
+		lines = append(lines, "Ignored: "+warnings)
+	}
+	lines = append(lines, fmt.Sprintf("Pods on the node: %s before, %s after", before, nodePodCount(ctx, client, node.Name)))
+
+	result := common.TextResult(strings.Join(lines, "\n"))
+	result.IsError = len(failed) > 0
+	result.Metadata.Affected = affected
+	return result
+}
+
+func podNames(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	return ":\n  " + strings.Join(names, "\n  ")
+}
+
+// nodePodCount describes the pods on a node, the ones being evicted or
+// deleted apart.
+func nodePodCount(ctx context.Context, client kubernetes.Interface, nodeName string) string {
+	pods, err := client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return "<" + err.Error() + ">"
+	}
+	running, terminating := 0, 0
+	for _, pod := range pods.Items {
+		switch {
+		case pod.Spec.NodeName != nodeName || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed:
+		case pod.DeletionTimestamp != nil:
+			terminating++
+		default:
+			running++
+		}
+	}
+	return fmt.Sprintf("%d running, %d terminating", running, terminating)
+}
@@ -22,7 +22,7 @@ import (
 )
 
 type GetClusterRole struct {
-	ClusterRoleName string `json:"clusterRoleName"`
+	ClusterRoleName string `json:"clusterRoleName" jsonschema:"required" description:"Name of the cluster role"`
 }
 
 func (GetClusterRole) GetName() string {
@@ -33,25 +33,7 @@ func (GetClusterRole) GetDescription() string {
 	return "Retrieve details of a ClusterRole"
 }
 
-func (GetClusterRole) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"clusterRoleName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetClusterRole) Exec(e common.Executor, jsonString string) string {
-	var crInfo GetClusterRole
-	err := json.Unmarshal([]byte(jsonString), &crInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (crInfo GetClusterRole) Run(e common.Executor) string {
 	clusterRole, err := e.Client.GetClient().RbacV1().ClusterRoles().Get(e.Context, crInfo.ClusterRoleName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to retrieve ClusterRole information: " + err.Error()
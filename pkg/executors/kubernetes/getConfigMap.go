@@ -22,8 +22,8 @@ import (
 )
 
 type GetConfigMap struct {
-	NamespaceName string `json:"namespaceName"`
-	ConfigMapName   string `json:"configMapName"`
+	NamespaceName string `json:"namespaceName" jsonschema:"required" description:"Namespace of the config map"`
+	ConfigMapName string `json:"configMapName" jsonschema:"required" description:"Name of the config map"`
 }
 
 func (GetConfigMap) GetName() string {
@@ -34,28 +34,7 @@ func (GetConfigMap) GetDescription() string {
 	return "Retrieve details of a configmap"
 }
 
-func (GetConfigMap) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"configMapName": {
-				"type": "string"
-			},
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetConfigMap) Exec(e common.Executor, jsonString string) string {
-	var cmInfos GetConfigMap
-	err := json.Unmarshal([]byte(jsonString), &cmInfos)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (cmInfos GetConfigMap) Run(e common.Executor) string {
 	cronJobs, err := e.Client.GetClient().CoreV1().ConfigMaps(cmInfos.NamespaceName).Get(e.Context, cmInfos.ConfigMapName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to get configmap: " + err.Error()
@@ -22,36 +22,18 @@ import (
 )
 
 type GetCRD struct {
-	CRDName string `json:"crdName"`
+	CRDName string `json:"crdName" jsonschema:"required" description:"Name of the CustomResourceDefinition"`
 }
 
 func (GetCRD) GetName() string {
-	return "getCRD"
+	return "getCrd"
 }
 
 func (GetCRD) GetDescription() string {
 	return "Retrieve details of a CustomResourceDefinition (CRD)"
 }
 
-func (GetCRD) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"crdName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetCRD) Exec(e common.Executor, jsonString string) string {
-	var crdInfo GetCRD
-	err := json.Unmarshal([]byte(jsonString), &crdInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (crdInfo GetCRD) Run(e common.Executor) string {
 	crd, err := e.Client.GetApiExtensionClient().ApiextensionsV1().CustomResourceDefinitions().Get(e.Context, crdInfo.CRDName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to retrieve CRD information: " + err.Error()
@@ -22,8 +22,8 @@ import (
 )
 
 type GetCronJob struct {
-	NamespaceName string `json:"namespaceName"`
-	CronJobName   string `json:"cronJobName"`
+	NamespaceName string `json:"namespaceName" jsonschema:"required" description:"Namespace of the cron job"`
+	CronJobName   string `json:"cronJobName" jsonschema:"required" description:"Name of the cron job"`
 }
 
 func (GetCronJob) GetName() string {
@@ -34,28 +34,7 @@ func (GetCronJob) GetDescription() string {
 	return "Retrieve details of a cronjob"
 }
 
-func (GetCronJob) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"cronJobName": {
-				"type": "string"
-			},
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetCronJob) Exec(e common.Executor, jsonString string) string {
-	var cronJobInfo GetCronJob
-	err := json.Unmarshal([]byte(jsonString), &cronJobInfo)
-	if err != nil {
-		return "Error while retrieving the NamespaceName parameter: " + err.Error()
-	}
+func (cronJobInfo GetCronJob) Run(e common.Executor) string {
 	cronJobs, err := e.Client.GetClient().BatchV1().CronJobs(cronJobInfo.NamespaceName).Get(e.Context, cronJobInfo.CronJobName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to get cronjob: " + err.Error()
@@ -22,8 +22,8 @@ import (
 )
 
 type GetDaemonSet struct {
-	DaemonSetName string `json:"daemonSetName"`
-	NamespaceName string `json:"namespaceName"`
+	DaemonSetName string `json:"daemonSetName" jsonschema:"required" description:"Name of the daemon set"`
+	NamespaceName string `json:"namespaceName" jsonschema:"required" description:"Namespace of the daemon set"`
 }
 
 func (GetDaemonSet) GetName() string {
@@ -34,28 +34,7 @@ func (GetDaemonSet) GetDescription() string {
 	return "Retrieve details of a daemonset"
 }
 
-func (GetDaemonSet) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"daemonSetName": {
-				"type": "string"
-			},
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetDaemonSet) Exec(e common.Executor, jsonString string) string {
-	var dsInfo GetDaemonSet
-	err := json.Unmarshal([]byte(jsonString), &dsInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (dsInfo GetDaemonSet) Run(e common.Executor) string {
 	ds, err := e.Client.GetClient().AppsV1().DaemonSets(dsInfo.NamespaceName).Get(e.Context, dsInfo.DaemonSetName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to retrieve daemonset information: " + err.Error()
@@ -22,8 +22,8 @@ import (
 )
 
 type GetDeployment struct {
-	DeploymentName string `json:"deploymentName"`
-	NamespaceName  string `json:"namespaceName"`
+	DeploymentName string `json:"deploymentName" jsonschema:"required" description:"Name of the deployment"`
+	NamespaceName  string `json:"namespaceName" jsonschema:"required" description:"Namespace of the deployment"`
 }
 
 func (GetDeployment) GetName() string {
@@ -34,28 +34,7 @@ func (GetDeployment) GetDescription() string {
 	return "Retrieve details of a deployment"
 }
 
-func (GetDeployment) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"deploymentName": {
-				"type": "string"
-			},
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetDeployment) Exec(e common.Executor, jsonString string) string {
-	var deploymentInfo GetDeployment
-	err := json.Unmarshal([]byte(jsonString), &deploymentInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (deploymentInfo GetDeployment) Run(e common.Executor) string {
 	deployment, err := e.Client.GetClient().AppsV1().Deployments(deploymentInfo.NamespaceName).Get(e.Context, deploymentInfo.DeploymentName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to retrieve deployment information: " + err.Error()
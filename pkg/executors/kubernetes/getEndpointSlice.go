@@ -22,40 +22,19 @@ import (
 )
 
 type GetEndpointSlice struct {
-	NamespaceName     string `json:"namespaceName"`
-	EndpointSliceName string `json:"endpointSliceName"`
+	NamespaceName     string `json:"namespaceName" jsonschema:"required" description:"Namespace of the endpoint slice"`
+	EndpointSliceName string `json:"endpointSliceName" jsonschema:"required" description:"Name of the endpoint slice"`
 }
 
 func (GetEndpointSlice) GetName() string {
-	return "getEndpointSlice"
+	return "getEndpointSlices"
 }
 
 func (GetEndpointSlice) GetDescription() string {
 	return "Retrieve details of an EndpointSlice"
 }
 
-func (GetEndpointSlice) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			},
-			"endpointSliceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetEndpointSlice) Exec(e common.Executor, jsonString string) string {
-	var esInfo GetEndpointSlice
-	err := json.Unmarshal([]byte(jsonString), &esInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (esInfo GetEndpointSlice) Run(e common.Executor) string {
 	endpointSlice, err := e.Client.GetClient().DiscoveryV1().EndpointSlices(esInfo.NamespaceName).Get(e.Context, esInfo.EndpointSliceName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to retrieve EndpointSlice information: " + err.Error()
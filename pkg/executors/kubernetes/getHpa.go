@@ -22,7 +22,7 @@ import (
 )
 
 type GetHPA struct {
-	NamespaceName string `json:"namespaceName"`
+	NamespaceName string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
 }
 
 func (GetHPA) GetName() string {
@@ -33,25 +33,7 @@ func (GetHPA) GetDescription() string {
 	return "Retrieve the status of Horizontal Pod Autoscalers in a namespace"
 }
 
-func (GetHPA) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetHPA) Exec(e common.Executor, jsonString string) string {
-	var hpaInfo GetHPA
-	err := json.Unmarshal([]byte(jsonString), &hpaInfo)
-	if err != nil {
-		return "Error while retrieving the NamespaceName parameter: " + err.Error()
-	}
+func (hpaInfo GetHPA) Run(e common.Executor) string {
 	hpaList, err := e.Client.GetClient().AutoscalingV1().HorizontalPodAutoscalers(hpaInfo.NamespaceName).List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to retrieve HPA information: " + err.Error()
@@ -22,8 +22,8 @@ import (
 )
 
 type GetIngress struct {
-	NamespaceName string `json:"namespaceName"`
-	IngressName   string `json:"ingressName"`
+	NamespaceName string `json:"namespaceName" jsonschema:"required" description:"Namespace of the ingress"`
+	IngressName   string `json:"ingressName" jsonschema:"required" description:"Name of the ingress"`
 }
 
 func (GetIngress) GetName() string {
@@ -34,28 +34,7 @@ func (GetIngress) GetDescription() string {
 	return "Retrieve details of an Ingress"
 }
 
-func (GetIngress) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			},
-			"ingressName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetIngress) Exec(e common.Executor, jsonString string) string {
-	var ingressInfo GetIngress
-	err := json.Unmarshal([]byte(jsonString), &ingressInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (ingressInfo GetIngress) Run(e common.Executor) string {
 	ingress, err := e.Client.GetClient().NetworkingV1().Ingresses(ingressInfo.NamespaceName).Get(e.Context, ingressInfo.IngressName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to retrieve Ingress information: " + err.Error()
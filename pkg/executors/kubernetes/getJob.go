@@ -22,8 +22,8 @@ import (
 )
 
 type GetJob struct {
-	NamespaceName string `json:"namespaceName"`
-	JobName       string `json:"jobName"`
+	NamespaceName string `json:"namespaceName" jsonschema:"required" description:"Namespace of the job"`
+	JobName       string `json:"jobName" jsonschema:"required" description:"Name of the job"`
 }
 
 func (GetJob) GetName() string {
@@ -34,28 +34,7 @@ func (GetJob) GetDescription() string {
 	return "Retrieve details of a job"
 }
 
-func (GetJob) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"jobName": {
-				"type": "string"
-			},
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetJob) Exec(e common.Executor, jsonString string) string {
-	var cronJobInfo GetJob
-	err := json.Unmarshal([]byte(jsonString), &cronJobInfo)
-	if err != nil {
-		return "Error while retrieving the parameters: " + err.Error()
-	}
+func (cronJobInfo GetJob) Run(e common.Executor) string {
 	cronJobs, err := e.Client.GetClient().BatchV1().Jobs(cronJobInfo.NamespaceName).Get(e.Context, cronJobInfo.JobName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to get job: " + err.Error()
@@ -22,8 +22,8 @@ import (
 )
 
 type GetLimitRange struct {
-	NamespaceName  string `json:"namespaceName"`
-	LimitRangeName string `json:"limitRangeName"`
+	NamespaceName  string `json:"namespaceName" jsonschema:"required" description:"Namespace of the limit range"`
+	LimitRangeName string `json:"limitRangeName" jsonschema:"required" description:"Name of the limit range"`
 }
 
 func (GetLimitRange) GetName() string {
@@ -34,28 +34,7 @@ func (GetLimitRange) GetDescription() string {
 	return "Retrieve details of a LimitRange"
 }
 
-func (GetLimitRange) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			},
-			"limitRangeName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetLimitRange) Exec(e common.Executor, jsonString string) string {
-	var lrInfo GetLimitRange
-	err := json.Unmarshal([]byte(jsonString), &lrInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (lrInfo GetLimitRange) Run(e common.Executor) string {
 	limitRange, err := e.Client.GetClient().CoreV1().LimitRanges(lrInfo.NamespaceName).Get(e.Context, lrInfo.LimitRangeName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to retrieve LimitRange information: " + err.Error()
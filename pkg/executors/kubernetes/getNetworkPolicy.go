@@ -22,8 +22,8 @@ import (
 )
 
 type GetNetworkPolicy struct {
-	NamespaceName     string `json:"namespaceName"`
-	NetworkPolicyName string `json:"networkPolicyName"`
+	NamespaceName     string `json:"namespaceName" jsonschema:"required" description:"Namespace of the network policy"`
+	NetworkPolicyName string `json:"networkPolicyName" jsonschema:"required" description:"Name of the network policy"`
 }
 
 func (GetNetworkPolicy) GetName() string {
@@ -34,28 +34,7 @@ func (GetNetworkPolicy) GetDescription() string {
 	return "Retrieve details of a NetworkPolicy"
 }
 
-func (GetNetworkPolicy) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			},
-			"networkPolicyName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetNetworkPolicy) Exec(e common.Executor, jsonString string) string {
-	var policyInfo GetNetworkPolicy
-	err := json.Unmarshal([]byte(jsonString), &policyInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (policyInfo GetNetworkPolicy) Run(e common.Executor) string {
 	networkPolicy, err := e.Client.GetClient().NetworkingV1().NetworkPolicies(policyInfo.NamespaceName).Get(e.Context, policyInfo.NetworkPolicyName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to retrieve NetworkPolicy information: " + err.Error()
@@ -22,7 +22,7 @@ import (
 )
 
 type GetNode struct {
-	NodeName string `json:"nodeName"`
+	NodeName string `json:"nodeName" jsonschema:"required" description:"Name of the node"`
 }
 
 func (GetNode) GetName() string {
@@ -33,25 +33,7 @@ func (GetNode) GetDescription() string {
 	return "Get the details of a node"
 }
 
-func (GetNode) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"nodeName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetNode) Exec(e common.Executor, jsonString string) string {
-	var nodeInfo GetNode
-	err := json.Unmarshal([]byte(jsonString), &nodeInfo)
-	if err != nil {
-		return "Error while retrieving the nodeName parameter: " + err.Error()
-	}
+func (nodeInfo GetNode) Run(e common.Executor) string {
 	node, err := e.Client.GetClient().CoreV1().Nodes().Get(e.Context, nodeInfo.NodeName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to retrieve node information: " + err.Error()
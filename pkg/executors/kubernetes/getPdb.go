@@ -22,40 +22,19 @@ import (
 )
 
 type GetPDB struct {
-	NamespaceName string `json:"namespaceName"`
-	PDBName       string `json:"pdbName"`
+	NamespaceName string `json:"namespaceName" jsonschema:"required" description:"Namespace of the PodDisruptionBudget"`
+	PDBName       string `json:"pdbName" jsonschema:"required" description:"Name of the PodDisruptionBudget"`
 }
 
 func (GetPDB) GetName() string {
-	return "getPDB"
+	return "getPdb"
 }
 
 func (GetPDB) GetDescription() string {
 	return "Retrieve details of a PodDisruptionBudget (PDB)"
 }
 
-func (GetPDB) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			},
-			"pdbName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetPDB) Exec(e common.Executor, jsonString string) string {
-	var pdbInfo GetPDB
-	err := json.Unmarshal([]byte(jsonString), &pdbInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (pdbInfo GetPDB) Run(e common.Executor) string {
 	pdb, err := e.Client.GetClient().PolicyV1().PodDisruptionBudgets(pdbInfo.NamespaceName).Get(e.Context, pdbInfo.PDBName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to retrieve PDB information: " + err.Error()
@@ -22,7 +22,7 @@ import (
 )
 
 type GetPersistentVolume struct {
-	PersistentVolumeName string `json:"persistentVolumeName"`
+	PersistentVolumeName string `json:"persistentVolumeName" jsonschema:"required" description:"Name of the persistent volume"`
 }
 
 func (GetPersistentVolume) GetName() string {
@@ -33,25 +33,7 @@ func (GetPersistentVolume) GetDescription() string {
 	return "Retrieve details of a PersistentVolume"
 }
 
-func (GetPersistentVolume) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"persistentVolumeName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetPersistentVolume) Exec(e common.Executor, jsonString string) string {
-	var pvInfo GetPersistentVolume
-	err := json.Unmarshal([]byte(jsonString), &pvInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (pvInfo GetPersistentVolume) Run(e common.Executor) string {
 	persistentVolume, err := e.Client.GetClient().CoreV1().PersistentVolumes().Get(e.Context, pvInfo.PersistentVolumeName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to retrieve PersistentVolume information: " + err.Error()
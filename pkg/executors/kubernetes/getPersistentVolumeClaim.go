@@ -22,8 +22,8 @@ import (
 )
 
 type GetPersistentVolumeClaim struct {
-	NamespaceName         string `json:"namespaceName"`
-	PersistentVolumeClaim string `json:"persistentVolumeClaim"`
+	NamespaceName         string `json:"namespaceName" jsonschema:"required" description:"Namespace of the persistent volume claim"`
+	PersistentVolumeClaim string `json:"persistentVolumeClaim" jsonschema:"required" description:"Name of the persistent volume claim"`
 }
 
 func (GetPersistentVolumeClaim) GetName() string {
@@ -34,28 +34,7 @@ func (GetPersistentVolumeClaim) GetDescription() string {
 	return "Retrieve details of a PersistentVolumeClaim"
 }
 
-func (GetPersistentVolumeClaim) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			},
-			"persistentVolumeClaim": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetPersistentVolumeClaim) Exec(e common.Executor, jsonString string) string {
-	var pvcInfo GetPersistentVolumeClaim
-	err := json.Unmarshal([]byte(jsonString), &pvcInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (pvcInfo GetPersistentVolumeClaim) Run(e common.Executor) string {
 	persistentVolumeClaim, err := e.Client.GetClient().CoreV1().PersistentVolumeClaims(pvcInfo.NamespaceName).Get(e.Context, pvcInfo.PersistentVolumeClaim, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to retrieve PersistentVolumeClaim information: " + err.Error()
@@ -0,0 +1,56 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type GetResource struct {
+	Resource      string `json:"resource" jsonschema:"required" description:"Kind, plural or short name of the resource, e.g. Rollout, scaledobjects, vs or certificates.cert-manager.io"`
+	Group         string `json:"group" description:"API group of the resource, e.g. argoproj.io, to choose between resources with the same name"`
+	Version       string `json:"version" description:"API version of the resource, the preferred version if empty"`
+	NamespaceName string `json:"namespaceName" description:"Namespace of the resource, ignored for a cluster-scoped resource"`
+	ResourceName  string `json:"resourceName" jsonschema:"required" description:"Name of the resource"`
+}
+
+func (GetResource) GetName() string {
+	return "getResource"
+}
+
+func (GetResource) GetDescription() string {
+	return "Retrieve any Kubernetes resource served by the cluster, including the custom resources"
+}
+
+func (resourceInfo GetResource) Run(ctx context.Context, e common.Executor) common.Result {
+	resource, err := resolveResource(e, resourceInfo.Resource, resourceInfo.Group, resourceInfo.Version)
+	if err != nil {
+		return common.ErrorResult("unable to find the resource", err)
+	}
+
+	object, err := resource.in(resourceInfo.NamespaceName).Get(ctx, resourceInfo.ResourceName, metav1.GetOptions{})
+	if err != nil {
+		return common.ErrorResult("unable to retrieve the resource", err)
+	}
+	result, err := json.Marshal(object)
+	if err != nil {
+		return common.ErrorResult("unable to encode the resource", err)
+	}
+	return common.TextResult(string(result))
+}
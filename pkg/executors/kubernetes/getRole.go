@@ -22,8 +22,8 @@ import (
 )
 
 type GetRole struct {
-	NamespaceName string `json:"namespaceName"`
-	RoleName      string `json:"roleName"`
+	NamespaceName string `json:"namespaceName" jsonschema:"required" description:"Namespace of the role"`
+	RoleName      string `json:"roleName" jsonschema:"required" description:"Name of the role"`
 }
 
 func (GetRole) GetName() string {
@@ -34,28 +34,7 @@ func (GetRole) GetDescription() string {
 	return "Retrieve details of a Role"
 }
 
-func (GetRole) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			},
-			"roleName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetRole) Exec(e common.Executor, jsonString string) string {
-	var roleInfo GetRole
-	err := json.Unmarshal([]byte(jsonString), &roleInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (roleInfo GetRole) Run(e common.Executor) string {
 	role, err := e.Client.GetClient().RbacV1().Roles(roleInfo.NamespaceName).Get(e.Context, roleInfo.RoleName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to retrieve Role information: " + err.Error()
@@ -22,8 +22,8 @@ import (
 )
 
 type GetRoleBinding struct {
-	NamespaceName   string `json:"namespaceName"`
-	RoleBindingName string `json:"roleBindingName"`
+	NamespaceName   string `json:"namespaceName" jsonschema:"required" description:"Namespace of the role binding"`
+	RoleBindingName string `json:"roleBindingName" jsonschema:"required" description:"Name of the role binding"`
 }
 
 func (GetRoleBinding) GetName() string {
@@ -34,28 +34,7 @@ func (GetRoleBinding) GetDescription() string {
 	return "Retrieve details of a RoleBinding"
 }
 
-func (GetRoleBinding) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			},
-			"roleBindingName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetRoleBinding) Exec(e common.Executor, jsonString string) string {
-	var rbInfo GetRoleBinding
-	err := json.Unmarshal([]byte(jsonString), &rbInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (rbInfo GetRoleBinding) Run(e common.Executor) string {
 	roleBinding, err := e.Client.GetClient().RbacV1().RoleBindings(rbInfo.NamespaceName).Get(e.Context, rbInfo.RoleBindingName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to retrieve RoleBinding information: " + err.Error()
@@ -22,8 +22,8 @@ import (
 )
 
 type GetSecret struct {
-	NamespaceName string `json:"namespaceName"`
-	SecretName    string `json:"secretName"`
+	NamespaceName string `json:"namespaceName" jsonschema:"required" description:"Namespace of the secret"`
+	SecretName    string `json:"secretName" jsonschema:"required" description:"Name of the secret"`
 }
 
 func (GetSecret) GetName() string {
@@ -34,28 +34,7 @@ func (GetSecret) GetDescription() string {
 	return "Retrieve details of a Secret"
 }
 
-func (GetSecret) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			},
-			"secretName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetSecret) Exec(e common.Executor, jsonString string) string {
-	var secretInfo GetSecret
-	err := json.Unmarshal([]byte(jsonString), &secretInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (secretInfo GetSecret) Run(e common.Executor) string {
 	secret, err := e.Client.GetClient().CoreV1().Secrets(secretInfo.NamespaceName).Get(e.Context, secretInfo.SecretName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to retrieve Secret information: " + err.Error()
@@ -22,8 +22,8 @@ import (
 )
 
 type GetSecurityContext struct {
-	PodName       string `json:"podName"`
-	NamespaceName string `json:"namespaceName"`
+	PodName       string `json:"podName" jsonschema:"required" description:"Name of the pod"`
+	NamespaceName string `json:"namespaceName" jsonschema:"required" description:"Namespace of the pod"`
 }
 
 func (GetSecurityContext) GetName() string {
@@ -34,28 +34,7 @@ func (GetSecurityContext) GetDescription() string {
 	return "Retrieve details of a SecurityContext for a specific Pod"
 }
 
-func (GetSecurityContext) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"podName": {
-				"type": "string"
-			},
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetSecurityContext) Exec(e common.Executor, jsonString string) string {
-	var scInfo GetSecurityContext
-	err := json.Unmarshal([]byte(jsonString), &scInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (scInfo GetSecurityContext) Run(e common.Executor) string {
 	pod, err := e.Client.GetClient().CoreV1().Pods(scInfo.NamespaceName).Get(e.Context, scInfo.PodName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to retrieve Pod information: " + err.Error()
@@ -22,8 +22,8 @@ import (
 )
 
 type GetServiceAccount struct {
-	NamespaceName      string `json:"namespaceName"`
-	ServiceAccountName string `json:"serviceAccountName"`
+	NamespaceName      string `json:"namespaceName" jsonschema:"required" description:"Namespace of the service account"`
+	ServiceAccountName string `json:"serviceAccountName" jsonschema:"required" description:"Name of the service account"`
 }
 
 func (GetServiceAccount) GetName() string {
@@ -34,28 +34,7 @@ func (GetServiceAccount) GetDescription() string {
 	return "Get the detail of a service account"
 }
 
-func (GetServiceAccount) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"serviceAccountName": {
-			"type": "string"
-			},
-			"namespaceName": {
-			"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetServiceAccount) Exec(e common.Executor, jsonString string) string {
-	var saInfos GetServiceAccount
-	err := json.Unmarshal([]byte(jsonString), &saInfos)
-	if err != nil {
-		return "Error while retrieving parameters:" + err.Error()
-	}
+func (saInfos GetServiceAccount) Run(e common.Executor) string {
 	sa, err := e.Client.GetClient().CoreV1().ServiceAccounts(saInfos.NamespaceName).Get(e.Context, saInfos.ServiceAccountName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to get the service account. " + err.Error()
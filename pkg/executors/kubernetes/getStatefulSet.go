@@ -22,8 +22,8 @@ import (
 )
 
 type GetStatefulSet struct {
-	StatefulSetName string `json:"statefulSetName"`
-	NamespaceName   string `json:"namespaceName"`
+	StatefulSetName string `json:"statefulSetName" jsonschema:"required" description:"Name of the stateful set"`
+	NamespaceName   string `json:"namespaceName" jsonschema:"required" description:"Namespace of the stateful set"`
 }
 
 func (GetStatefulSet) GetName() string {
@@ -34,28 +34,7 @@ func (GetStatefulSet) GetDescription() string {
 	return "Retrieve details of a statefulset"
 }
 
-func (GetStatefulSet) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"statefulSetName": {
-				"type": "string"
-			},
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetStatefulSet) Exec(e common.Executor, jsonString string) string {
-	var stsInfo GetStatefulSet
-	err := json.Unmarshal([]byte(jsonString), &stsInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (stsInfo GetStatefulSet) Run(e common.Executor) string {
 	statefulset, err := e.Client.GetClient().AppsV1().StatefulSets(stsInfo.NamespaceName).Get(e.Context, stsInfo.StatefulSetName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to retrieve statefulset information: " + err.Error()
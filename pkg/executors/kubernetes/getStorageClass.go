@@ -22,7 +22,7 @@ import (
 )
 
 type GetStorageClass struct {
-	StorageClassName string `json:"storageClassName"`
+	StorageClassName string `json:"storageClassName" jsonschema:"required" description:"Name of the storage class"`
 }
 
 func (GetStorageClass) GetName() string {
@@ -33,25 +33,7 @@ func (GetStorageClass) GetDescription() string {
 	return "Retrieve details of a StorageClass"
 }
 
-func (GetStorageClass) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"storageClassName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetStorageClass) Exec(e common.Executor, jsonString string) string {
-	var scInfo GetStorageClass
-	err := json.Unmarshal([]byte(jsonString), &scInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (scInfo GetStorageClass) Run(e common.Executor) string {
 	storageClass, err := e.Client.GetClient().StorageV1().StorageClasses().Get(e.Context, scInfo.StorageClassName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to retrieve StorageClass information: " + err.Error()
@@ -22,8 +22,8 @@ import (
 )
 
 type GetPod struct {
-	PodName       string `json:"podName"`
-	NamespaceName string `json:"namespaceName"`
+	PodName       string `json:"podName" jsonschema:"required" description:"Name of the pod"`
+	NamespaceName string `json:"namespaceName" jsonschema:"required" description:"Namespace of the pod"`
 }
 
 func (GetPod) GetName() string {
@@ -34,28 +34,7 @@ func (GetPod) GetDescription() string {
 	return "Get the details of a pod"
 }
 
-func (GetPod) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"podName": {
-			"type": "string"
-			},
-			"namespaceName": {
-			"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (GetPod) Exec(e common.Executor, jsonString string) string {
-	var podInfo GetPod
-	err := json.Unmarshal([]byte(jsonString), &podInfo)
-	if err != nil {
-		return "Error while retrieving the podName parameter:" + err.Error()
-	}
+func (podInfo GetPod) Run(e common.Executor) string {
 	pod, err := e.Client.GetClient().CoreV1().Pods(podInfo.NamespaceName).Get(e.Context, podInfo.PodName, metav1.GetOptions{})
 	if err != nil {
 		return "Unable to retrieve pod information." + err.Error()
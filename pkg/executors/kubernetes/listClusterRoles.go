@@ -31,16 +31,7 @@ func (ListClusterRoles) GetDescription() string {
 	return "List all ClusterRoles in the cluster"
 }
 
-func (ListClusterRoles) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {}
-	}
-	`
-}
-
-func (ListClusterRoles) Exec(e common.Executor, jsonString string) string {
+func (ListClusterRoles) Run(e common.Executor) string {
 	clusterRoles, err := e.Client.GetClient().RbacV1().ClusterRoles().List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list ClusterRoles: " + err.Error()
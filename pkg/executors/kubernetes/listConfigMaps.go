@@ -22,7 +22,7 @@ import (
 )
 
 type ListConfigMaps struct {
-	NamespaceName string `json:"namespaceName"`
+	NamespaceName string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
 }
 
 func (ListConfigMaps) GetName() string {
@@ -33,25 +33,7 @@ func (ListConfigMaps) GetDescription() string {
 	return "List all configmaps in the cluster"
 }
 
-func (ListConfigMaps) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (ListConfigMaps) Exec(e common.Executor, jsonString string) string {
-	var cmInfos ListConfigMaps
-	err := json.Unmarshal([]byte(jsonString), &cmInfos)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (cmInfos ListConfigMaps) Run(e common.Executor) string {
 	cms, err := e.Client.GetClient().CoreV1().ConfigMaps(cmInfos.NamespaceName).List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list configmaps: " + err.Error()
@@ -24,23 +24,14 @@ import (
 type ListCRDs struct{}
 
 func (ListCRDs) GetName() string {
-	return "listCRDs"
+	return "listCrds"
 }
 
 func (ListCRDs) GetDescription() string {
 	return "List all CustomResourceDefinitions (CRDs) in the cluster"
 }
 
-func (ListCRDs) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {}
-	}
-	`
-}
-
-func (ListCRDs) Exec(e common.Executor, jsonString string) string {
+func (ListCRDs) Run(e common.Executor) string {
 	crds, err := e.Client.GetApiExtensionClient().ApiextensionsV1().CustomResourceDefinitions().List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list CRDs: " + err.Error()
@@ -22,7 +22,7 @@ import (
 )
 
 type ListCronJobs struct {
-	NamespaceName string `json:"namespaceName"`
+	NamespaceName string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
 }
 
 func (ListCronJobs) GetName() string {
@@ -33,25 +33,7 @@ func (ListCronJobs) GetDescription() string {
 	return "List all cronjobs in the cluster"
 }
 
-func (ListCronJobs) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (ListCronJobs) Exec(e common.Executor, jsonString string) string {
-	var cronInfos ListCronJobs
-	err := json.Unmarshal([]byte(jsonString), &cronInfos)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (cronInfos ListCronJobs) Run(e common.Executor) string {
 	cronjobs, err := e.Client.GetClient().BatchV1().CronJobs(cronInfos.NamespaceName).List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list cronjobs: " + err.Error()
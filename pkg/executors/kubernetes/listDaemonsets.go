@@ -22,36 +22,18 @@ import (
 )
 
 type ListDaemonSets struct {
-	NamespaceName string `json:"namespaceName"`
+	NamespaceName string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
 }
 
 func (ListDaemonSets) GetName() string {
-	return "listDaemonSet"
+	return "listDaemonSets"
 }
 
 func (ListDaemonSets) GetDescription() string {
 	return "List all DaemonSet in the cluster"
 }
 
-func (ListDaemonSets) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (ListDaemonSets) Exec(e common.Executor, jsonString string) string {
-	var daemonsetInfos ListDaemonSets
-	err := json.Unmarshal([]byte(jsonString), &daemonsetInfos)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (daemonsetInfos ListDaemonSets) Run(e common.Executor) string {
 	ds, err := e.Client.GetClient().AppsV1().StatefulSets(daemonsetInfos.NamespaceName).List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list daemonsetInfos: " + err.Error()
@@ -22,7 +22,7 @@ import (
 )
 
 type ListDeployments struct {
-	NamespaceName string `json:"namespaceName"`
+	NamespaceName string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
 }
 
 func (ListDeployments) GetName() string {
@@ -33,28 +33,7 @@ func (ListDeployments) GetDescription() string {
 	return "List all deployments in the cluster"
 }
 
-func (ListDeployments) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"deploymentName": {
-				"type": "string"
-			},
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (ListDeployments) Exec(e common.Executor, jsonString string) string {
-	var deploymentInfo ListDeployments
-	err := json.Unmarshal([]byte(jsonString), &deploymentInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (deploymentInfo ListDeployments) Run(e common.Executor) string {
 	deployment, err := e.Client.GetClient().AppsV1().Deployments(deploymentInfo.NamespaceName).List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list deployments: " + err.Error()
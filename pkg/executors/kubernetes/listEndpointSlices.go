@@ -22,7 +22,7 @@ import (
 )
 
 type ListEndpointSlices struct {
-	NamespaceName string `json:"namespaceName"`
+	NamespaceName string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
 }
 
 func (ListEndpointSlices) GetName() string {
@@ -33,25 +33,7 @@ func (ListEndpointSlices) GetDescription() string {
 	return "List all EndpointSlices in a namespace"
 }
 
-func (ListEndpointSlices) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (ListEndpointSlices) Exec(e common.Executor, jsonString string) string {
-	var esInfo ListEndpointSlices
-	err := json.Unmarshal([]byte(jsonString), &esInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (esInfo ListEndpointSlices) Run(e common.Executor) string {
 	endpointSlices, err := e.Client.GetClient().DiscoveryV1().EndpointSlices(esInfo.NamespaceName).List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list EndpointSlices: " + err.Error()
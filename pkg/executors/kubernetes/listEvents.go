@@ -0,0 +1,118 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// maxEvents is the number of events listed, the last seen ones.
+const maxEvents = 100
+
+type ListEvents struct {
+	NamespaceName      string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
+	InvolvedObjectKind string `json:"involvedObjectKind" description:"Kind of the object of the events, e.g. Pod"`
+	InvolvedObjectName string `json:"involvedObjectName" description:"Name of the object of the events"`
+	Reason             string `json:"reason" description:"Reason of the events, e.g. BackOff, FailedScheduling or OOMKilling"`
+	Type               string `json:"type" jsonschema:"enum=Normal|Warning" description:"Type of the events"`
+	Since              string `json:"since" description:"Only the events seen during this duration, e.g. 30m or 2h"`
+}
+
+func (ListEvents) GetName() string {
+	return "listEvents"
+}
+
+func (ListEvents) GetDescription() string {
+	return "List the Kubernetes events, the last seen at the end, filtered by namespace, involved object, reason, type and age"
+}
+
+func (eventsInfo ListEvents) Run(ctx context.Context, e common.Executor) common.Result {
+	var since time.Time
+	if eventsInfo.Since != "" {
+		duration, err := time.ParseDuration(eventsInfo.Since)
+		if err != nil || duration <= 0 {
+			return common.ErrorResult(fmt.Sprintf("since must be a positive duration like 30m, got %q", eventsInfo.Since), nil)
+		}
+		since = time.Now().Add(-duration)
+	}
+
+	selector := fields.Set{}
+	for field, value := range map[string]string{
+		"involvedObject.kind": eventsInfo.InvolvedObjectKind,
+		"involvedObject.name": eventsInfo.InvolvedObjectName,
+		"reason":              eventsInfo.Reason,
+		"type":                eventsInfo.Type,
+	} {
+		if value != "" {
+			selector[field] = value
+		}
+	}
+
+	list, err := e.Client.GetClient().CoreV1().Events(eventsInfo.NamespaceName).List(ctx, metav1.ListOptions{
+		FieldSelector: selector.AsSelector().String(),
+	})
+	if err != nil {
+		return common.ErrorResult("unable to list the events", err)
+	}
+
+	var events []corev1.Event
+	for _, event := range list.Items {
+		if !since.IsZero() && lastSeen(event).Before(since) {
+			continue
+		}
+		if !selector.AsSelector().Matches(eventFields(event)) {
+			continue
+		}
+		events = append(events, event)
+	}
+	if len(events) == 0 {
+		return common.TextResult("No events found")
+	}
+
+	sortEvents(events)
+	var b strings.Builder
+	if len(events) > maxEvents {
+		fmt.Fprintf(&b, "%d events found, the last %d are shown\n", len(events), maxEvents)
+		events = events[len(events)-maxEvents:]
+	}
+	d := newDescriber()
+	for _, event := range events {
+		object := fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name)
+		if event.InvolvedObject.Namespace != "" && eventsInfo.NamespaceName == "" {
+			object = event.InvolvedObject.Namespace + "/" + object
+		}
+		fmt.Fprintf(&b, "%s %s %s %s (x%d): %s\n", d.age(lastSeen(event)), event.Type, event.Reason, object, max(event.Count, 1), strings.TrimSpace(event.Message))
+	}
+	return common.TextResult(b.String())
+}
+
+// eventFields are the fields of an event matched by the selector, checked
+// again on the client.
+func eventFields(event corev1.Event) fields.Set {
+	return fields.Set{
+		"involvedObject.kind": event.InvolvedObject.Kind,
+		"involvedObject.name": event.InvolvedObject.Name,
+		"reason":              event.Reason,
+		"type":                event.Type,
+	}
+}
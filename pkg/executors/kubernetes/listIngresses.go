@@ -22,7 +22,7 @@ import (
 )
 
 type ListIngresses struct {
-	NamespaceName string `json:"namespaceName"`
+	NamespaceName string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
 }
 
 func (ListIngresses) GetName() string {
@@ -33,25 +33,7 @@ func (ListIngresses) GetDescription() string {
 	return "List all Ingresses in a namespace"
 }
 
-func (ListIngresses) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (ListIngresses) Exec(e common.Executor, jsonString string) string {
-	var ingressInfo ListIngresses
-	err := json.Unmarshal([]byte(jsonString), &ingressInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (ingressInfo ListIngresses) Run(e common.Executor) string {
 	ingresses, err := e.Client.GetClient().NetworkingV1().Ingresses(ingressInfo.NamespaceName).List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list Ingresses: " + err.Error()
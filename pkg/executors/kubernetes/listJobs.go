@@ -22,7 +22,7 @@ import (
 )
 
 type ListJobs struct {
-	NamespaceName string `json:"namespaceName"`
+	NamespaceName string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
 }
 
 func (ListJobs) GetName() string {
@@ -33,25 +33,7 @@ func (ListJobs) GetDescription() string {
 	return "List all jobs in the cluster"
 }
 
-func (ListJobs) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (ListJobs) Exec(e common.Executor, jsonString string) string {
-	var jobsInfos ListJobs
-	err := json.Unmarshal([]byte(jsonString), &jobsInfos)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (jobsInfos ListJobs) Run(e common.Executor) string {
 	jobs, err := e.Client.GetClient().BatchV1().Jobs(jobsInfos.NamespaceName).List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list jobs: " + err.Error()
@@ -22,7 +22,7 @@ import (
 )
 
 type ListLimitRanges struct {
-	NamespaceName string `json:"namespaceName"`
+	NamespaceName string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
 }
 
 func (ListLimitRanges) GetName() string {
@@ -33,25 +33,7 @@ func (ListLimitRanges) GetDescription() string {
 	return "List all LimitRanges in a namespace"
 }
 
-func (ListLimitRanges) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (ListLimitRanges) Exec(e common.Executor, jsonString string) string {
-	var lrInfo ListLimitRanges
-	err := json.Unmarshal([]byte(jsonString), &lrInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (lrInfo ListLimitRanges) Run(e common.Executor) string {
 	limitRanges, err := e.Client.GetClient().CoreV1().LimitRanges(lrInfo.NamespaceName).List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list LimitRanges: " + err.Error()
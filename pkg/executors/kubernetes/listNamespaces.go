@@ -31,16 +31,7 @@ func (ListNamespaces) GetDescription() string {
 	return "List all namespaces in the cluster"
 }
 
-func (ListNamespaces) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {}
-	}
-	`
-}
-
-func (ListNamespaces) Exec(e common.Executor, jsonString string) string {
+func (ListNamespaces) Run(e common.Executor) string {
 	namespaces, err := e.Client.GetClient().CoreV1().Namespaces().List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list namespaces: " + err.Error()
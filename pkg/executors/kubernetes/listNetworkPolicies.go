@@ -22,7 +22,7 @@ import (
 )
 
 type ListNetworkPolicies struct {
-	NamespaceName string `json:"namespaceName"`
+	NamespaceName string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
 }
 
 func (ListNetworkPolicies) GetName() string {
@@ -33,25 +33,7 @@ func (ListNetworkPolicies) GetDescription() string {
 	return "List all NetworkPolicies in a namespace"
 }
 
-func (ListNetworkPolicies) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (ListNetworkPolicies) Exec(e common.Executor, jsonString string) string {
-	var policyInfo ListNetworkPolicies
-	err := json.Unmarshal([]byte(jsonString), &policyInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (policyInfo ListNetworkPolicies) Run(e common.Executor) string {
 	networkPolicies, err := e.Client.GetClient().NetworkingV1().NetworkPolicies(policyInfo.NamespaceName).List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list NetworkPolicies: " + err.Error()
@@ -22,36 +22,18 @@ import (
 )
 
 type ListPDBs struct {
-	NamespaceName string `json:"namespaceName"`
+	NamespaceName string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
 }
 
 func (ListPDBs) GetName() string {
-	return "listPDBs"
+	return "listPdbs"
 }
 
 func (ListPDBs) GetDescription() string {
 	return "List all PodDisruptionBudgets (PDBs) in a namespace"
 }
 
-func (ListPDBs) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (ListPDBs) Exec(e common.Executor, jsonString string) string {
-	var pdbInfo ListPDBs
-	err := json.Unmarshal([]byte(jsonString), &pdbInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (pdbInfo ListPDBs) Run(e common.Executor) string {
 	pdbs, err := e.Client.GetClient().PolicyV1().PodDisruptionBudgets(pdbInfo.NamespaceName).List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list PDBs: " + err.Error()
@@ -31,16 +31,7 @@ func (ListPersistentVolumes) GetDescription() string {
 	return "List all PersistentVolumes in the cluster"
 }
 
-func (ListPersistentVolumes) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {}
-	}
-	`
-}
-
-func (ListPersistentVolumes) Exec(e common.Executor, jsonString string) string {
+func (ListPersistentVolumes) Run(e common.Executor) string {
 	persistentVolumes, err := e.Client.GetClient().CoreV1().PersistentVolumes().List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list PersistentVolumes: " + err.Error()
@@ -22,7 +22,7 @@ import (
 )
 
 type ListPersistentVolumeClaims struct {
-	NamespaceName string `json:"namespaceName"`
+	NamespaceName string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
 }
 
 func (ListPersistentVolumeClaims) GetName() string {
@@ -33,25 +33,7 @@ func (ListPersistentVolumeClaims) GetDescription() string {
 	return "List all PersistentVolumeClaims in a namespace"
 }
 
-func (ListPersistentVolumeClaims) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (ListPersistentVolumeClaims) Exec(e common.Executor, jsonString string) string {
-	var pvcInfo ListPersistentVolumeClaims
-	err := json.Unmarshal([]byte(jsonString), &pvcInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (pvcInfo ListPersistentVolumeClaims) Run(e common.Executor) string {
 	persistentVolumeClaims, err := e.Client.GetClient().CoreV1().PersistentVolumeClaims(pvcInfo.NamespaceName).List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list PersistentVolumeClaims: " + err.Error()
@@ -22,7 +22,7 @@ import (
 )
 
 type ListPods struct {
-	NamespaceName string `json:"namespaceName"`
+	NamespaceName string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
 }
 
 func (ListPods) GetName() string {
@@ -33,25 +33,7 @@ func (ListPods) GetDescription() string {
 	return "List all pods in a namespace"
 }
 
-func (ListPods) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-			"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (ListPods) Exec(e common.Executor, jsonString string) string {
-	var podsInfos ListPods
-	err := json.Unmarshal([]byte(jsonString), &podsInfos)
-	if err != nil {
-		return "Error while retrieving the NamespaceName parameter:" + err.Error()
-	}
+func (podsInfos ListPods) Run(e common.Executor) string {
 	pod, err := e.Client.GetClient().CoreV1().Pods(podsInfos.NamespaceName).List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list pods." + err.Error()
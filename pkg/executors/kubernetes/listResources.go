@@ -0,0 +1,66 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ListResources struct {
+	Resource      string `json:"resource" jsonschema:"required" description:"Kind, plural or short name of the resources, e.g. Rollout, scaledobjects, vs or certificates.cert-manager.io"`
+	Group         string `json:"group" description:"API group of the resources, e.g. argoproj.io, to choose between resources with the same name"`
+	Version       string `json:"version" description:"API version of the resources, the preferred version if empty"`
+	NamespaceName string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
+	LabelSelector string `json:"labelSelector" description:"Label selector, e.g. app=web,tier!=cache"`
+	FieldSelector string `json:"fieldSelector" description:"Field selector, e.g. status.phase=Running"`
+	Limit         int    `json:"limit" jsonschema:"minimum=0,maximum=500" description:"Maximum number of resources, 100 if empty"`
+}
+
+func (ListResources) GetName() string {
+	return "listResources"
+}
+
+func (ListResources) GetDescription() string {
+	return "List any Kubernetes resources served by the cluster, including the custom resources, filtered by label and field selectors"
+}
+
+func (resourcesInfo ListResources) Run(ctx context.Context, e common.Executor) common.Result {
+	resource, err := resolveResource(e, resourcesInfo.Resource, resourcesInfo.Group, resourcesInfo.Version)
+	if err != nil {
+		return common.ErrorResult("unable to find the resources", err)
+	}
+
+	limit := resourcesInfo.Limit
+	if limit == 0 {
+		limit = 100
+	}
+	list, err := resource.in(resourcesInfo.NamespaceName).List(ctx, metav1.ListOptions{
+		LabelSelector: resourcesInfo.LabelSelector,
+		FieldSelector: resourcesInfo.FieldSelector,
+		Limit:         int64(limit),
+	})
+	if err != nil {
+		return common.ErrorResult("unable to list the resources", err)
+	}
+	result, err := json.Marshal(list)
+	if err != nil {
+		return common.ErrorResult("unable to encode the resources", err)
+	}
+	return common.TextResult(string(result))
+}
@@ -22,7 +22,7 @@ import (
 )
 
 type ListRoleBindings struct {
-	NamespaceName string `json:"namespaceName"`
+	NamespaceName string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
 }
 
 func (ListRoleBindings) GetName() string {
@@ -33,25 +33,7 @@ func (ListRoleBindings) GetDescription() string {
 	return "List all RoleBindings in a namespace"
 }
 
-func (ListRoleBindings) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (ListRoleBindings) Exec(e common.Executor, jsonString string) string {
-	var rbInfo ListRoleBindings
-	err := json.Unmarshal([]byte(jsonString), &rbInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (rbInfo ListRoleBindings) Run(e common.Executor) string {
 	roleBindings, err := e.Client.GetClient().RbacV1().RoleBindings(rbInfo.NamespaceName).List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list RoleBindings: " + err.Error()
@@ -22,7 +22,7 @@ import (
 )
 
 type ListRoles struct {
-	NamespaceName string `json:"namespaceName"`
+	NamespaceName string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
 }
 
 func (ListRoles) GetName() string {
@@ -33,25 +33,7 @@ func (ListRoles) GetDescription() string {
 	return "List all Roles in a namespace"
 }
 
-func (ListRoles) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (ListRoles) Exec(e common.Executor, jsonString string) string {
-	var roleInfo ListRoles
-	err := json.Unmarshal([]byte(jsonString), &roleInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (roleInfo ListRoles) Run(e common.Executor) string {
 	roles, err := e.Client.GetClient().RbacV1().Roles(roleInfo.NamespaceName).List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list Roles: " + err.Error()
@@ -22,7 +22,7 @@ import (
 )
 
 type ListSecrets struct {
-	NamespaceName string `json:"namespaceName"`
+	NamespaceName string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
 }
 
 func (ListSecrets) GetName() string {
@@ -33,25 +33,7 @@ func (ListSecrets) GetDescription() string {
 	return "List all Secrets in a namespace"
 }
 
-func (ListSecrets) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (ListSecrets) Exec(e common.Executor, jsonString string) string {
-	var secretInfo ListSecrets
-	err := json.Unmarshal([]byte(jsonString), &secretInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (secretInfo ListSecrets) Run(e common.Executor) string {
 	secrets, err := e.Client.GetClient().CoreV1().Secrets(secretInfo.NamespaceName).List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list Secrets: " + err.Error()
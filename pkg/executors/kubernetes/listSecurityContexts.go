@@ -22,7 +22,7 @@ import (
 )
 
 type ListSecurityContexts struct {
-	NamespaceName string `json:"namespaceName"`
+	NamespaceName string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
 }
 
 func (ListSecurityContexts) GetName() string {
@@ -33,25 +33,7 @@ func (ListSecurityContexts) GetDescription() string {
 	return "List all SecurityContexts for Pods in a namespace"
 }
 
-func (ListSecurityContexts) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (ListSecurityContexts) Exec(e common.Executor, jsonString string) string {
-	var scInfo ListSecurityContexts
-	err := json.Unmarshal([]byte(jsonString), &scInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (scInfo ListSecurityContexts) Run(e common.Executor) string {
 	pods, err := e.Client.GetClient().CoreV1().Pods(scInfo.NamespaceName).List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list Pods: " + err.Error()
@@ -22,7 +22,7 @@ import (
 )
 
 type ListServicesAccounts struct {
-	NamespaceName string `json:"namespaceName"`
+	NamespaceName string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
 }
 
 func (ListServicesAccounts) GetName() string {
@@ -33,25 +33,7 @@ func (ListServicesAccounts) GetDescription() string {
 	return "List all service accounts in a namespace"
 }
 
-func (ListServicesAccounts) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-			"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (ListServicesAccounts) Exec(e common.Executor, jsonString string) string {
-	var saInfos ListServicesAccounts
-	err := json.Unmarshal([]byte(jsonString), &saInfos)
-	if err != nil {
-		return "Error while retrieving the NamespaceName parameter:" + err.Error()
-	}
+func (saInfos ListServicesAccounts) Run(e common.Executor) string {
 	serviceAccounts, err := e.Client.GetClient().CoreV1().ServiceAccounts(saInfos.NamespaceName).List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list service accounts." + err.Error()
@@ -22,7 +22,7 @@ import (
 )
 
 type ListStatefulSets struct {
-	NamespaceName string `json:"namespaceName"`
+	NamespaceName string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
 }
 
 func (ListStatefulSets) GetName() string {
@@ -33,25 +33,7 @@ func (ListStatefulSets) GetDescription() string {
 	return "List all StatefulSets in the cluster"
 }
 
-func (ListStatefulSets) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (ListStatefulSets) Exec(e common.Executor, jsonString string) string {
-	var stsInfos ListStatefulSets
-	err := json.Unmarshal([]byte(jsonString), &stsInfos)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
+func (stsInfos ListStatefulSets) Run(e common.Executor) string {
 	statefulsets, err := e.Client.GetClient().AppsV1().StatefulSets(stsInfos.NamespaceName).List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list statefulsets: " + err.Error()
@@ -31,16 +31,7 @@ func (ListStorageClasses) GetDescription() string {
 	return "List all StorageClasses in the cluster"
 }
 
-func (ListStorageClasses) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {}
-	}
-	`
-}
-
-func (ListStorageClasses) Exec(e common.Executor, jsonString string) string {
+func (ListStorageClasses) Run(e common.Executor) string {
 	storageClasses, err := e.Client.GetClient().StorageV1().StorageClasses().List(e.Context, metav1.ListOptions{})
 	if err != nil {
 		return "Unable to list StorageClasses: " + err.Error()
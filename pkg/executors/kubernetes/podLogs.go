@@ -1,4 +1,4 @@
-// Copyright © 2024 Ava AI.
+// Copyright © 2025 Ava AI.
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -15,19 +15,43 @@
 package kubernetes
 
 import (
-	"encoding/json"
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/matthisholleville/ava/pkg/common"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
-var (
-	tailLines = int64(100)
+const (
+	DEFAULT_TAIL_LINES = 100
+	// DEFAULT_FILTERED_TAIL_LINES are read when the lines are filtered, so
+	// the matches are found further back.
+	DEFAULT_FILTERED_TAIL_LINES = 1000
+	// maxLogPods bounds the pods of a selector whose logs are merged.
+	maxLogPods = 10
 )
 
 type PodLogs struct {
-	PodName       string `json:"podName"`
-	NamespaceName string `json:"namespaceName"`
+	PodName        string `json:"podName" description:"Name of the pod, required without a deployment or a selector"`
+	NamespaceName  string `json:"namespaceName" jsonschema:"required" description:"Namespace of the pod"`
+	DeploymentName string `json:"deploymentName" description:"Name of a deployment, the logs of its pods are merged by time"`
+	LabelSelector  string `json:"labelSelector" description:"Label selector of pods, e.g. app=web, their logs are merged by time"`
+	Container      string `json:"container" description:"Container or init container, the default container if empty"`
+	Previous       bool   `json:"previous" description:"Logs of the previous instance of the container, e.g. before it crashed"`
+	SinceSeconds   int    `json:"sinceSeconds" jsonschema:"minimum=0" description:"Only the logs of the last seconds"`
+	TailLines      int    `json:"tailLines" jsonschema:"minimum=0,maximum=10000" description:"Lines read from the end of the logs, 100 by default or 1000 with include"`
+	Include        string `json:"include" description:"Regular expression, only the matching lines are kept, e.g. (?i)error|exception"`
+	Exclude        string `json:"exclude" description:"Regular expression, the matching lines are removed"`
+	Context        int    `json:"context" jsonschema:"minimum=0,maximum=50" description:"Lines kept before and after each line matching include, e.g. to keep a stack trace"`
 }
 
 func (PodLogs) GetName() string {
@@ -35,39 +59,215 @@ func (PodLogs) GetName() string {
 }
 
 func (PodLogs) GetDescription() string {
-	return "Get the logs of a pod"
+	return "Get the logs of a pod, of its previous container after a crash, or of the pods of a deployment merged by time, filtered by regular expressions with context lines"
 }
 
-func (PodLogs) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"podName": {
-			"type": "string"
-			},
-			"namespaceName": {
-			"type": "string"
-			}
+func (podInfo PodLogs) Run(ctx context.Context, e common.Executor) common.Result {
+	filter, err := newLogFilter(podInfo.Include, podInfo.Exclude, podInfo.Context)
+	if err != nil {
+		return common.ErrorResult("", err)
+	}
+
+	client := e.Client.GetClient()
+	pods, err := podInfo.pods(ctx, client)
+	if err != nil {
+		return common.ErrorResult("", err)
+	}
+	if len(pods) == 0 {
+		return common.TextResult("No pods found")
+	}
+
+	if podInfo.PodName != "" {
+		logs, err := podInfo.logs(ctx, client, podInfo.PodName, false)
+		if err != nil {
+			return common.ErrorResult("unable to retrieve the pod logs", err)
 		}
+		return common.TextResult(logsOutput(filter.apply(splitLines(logs))))
 	}
-	`
+
+	var header []string
+	if len(pods) > maxLogPods {
+		header = append(header, fmt.Sprintf("%d pods found, the logs of %d are shown", len(pods), maxLogPods))
+		pods = pods[:maxLogPods]
+	}
+	var lines []logLine
+	for _, pod := range pods {
+		logs, err := podInfo.logs(ctx, client, pod, true)
+		if err != nil {
+			header = append(header, fmt.Sprintf("%s: %s", pod, err.Error()))
+			continue
+		}
+		lines = append(lines, parseLogLines(pod, logs)...)
+	}
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].time.Before(lines[j].time)
+	})
+
+	merged := make([]string, 0, len(lines))
+	for _, line := range lines {
+		merged = append(merged, line.String())
+	}
+	return common.TextResult(strings.Join(append(header, logsOutput(filter.apply(merged))), "\n"))
 }
 
-func (PodLogs) Exec(e common.Executor, jsonString string) string {
-	var podInfo PodLogs
-	err := json.Unmarshal([]byte(jsonString), &podInfo)
-	if err != nil {
-		return "Error while retrieving the podName parameter:" + err.Error()
+func logsOutput(lines []string) string {
+	if len(lines) == 0 {
+		return "No log lines, check the filters and the time window"
 	}
-	podLogOptions := v1.PodLogOptions{
-		TailLines: &tailLines,
+	return strings.Join(lines, "\n")
+}
+
+// pods returns the names of the pods whose logs are read.
+func (podInfo PodLogs) pods(ctx context.Context, client kubernetes.Interface) ([]string, error) {
+	given := 0
+	for _, value := range []string{podInfo.PodName, podInfo.DeploymentName, podInfo.LabelSelector} {
+		if value != "" {
+			given++
+		}
+	}
+	if given != 1 {
+		return nil, errors.New("exactly one of podName, deploymentName and labelSelector is required")
+	}
+	if podInfo.PodName != "" {
+		return []string{podInfo.PodName}, nil
+	}
+
+	selector := podInfo.LabelSelector
+	if podInfo.DeploymentName != "" {
+		deployment, err := client.AppsV1().Deployments(podInfo.NamespaceName).Get(ctx, podInfo.DeploymentName, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get the deployment: %w", err)
+		}
+		s, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector of the deployment: %w", err)
+		}
+		selector = s.String()
 	}
-	podLogs, err := e.Client.Client.CoreV1().Pods(podInfo.NamespaceName).GetLogs(podInfo.PodName, &podLogOptions).DoRaw(e.Context)
+
+	list, err := client.CoreV1().Pods(podInfo.NamespaceName).List(ctx, metav1.ListOptions{LabelSelector: selector})
 	if err != nil {
-		return "Unable to retrieve pod logs." + err.Error()
+		return nil, fmt.Errorf("unable to list the pods: %w", err)
+	}
+	names := make([]string, 0, len(list.Items))
+	for _, pod := range list.Items {
+		names = append(names, pod.Name)
 	}
+	sort.Strings(names)
+	return names, nil
+}
 
-	return string(podLogs)
+func (podInfo PodLogs) logs(ctx context.Context, client kubernetes.Interface, pod string, timestamps bool) ([]byte, error) {
+	tailLines := int64(podInfo.TailLines)
+	if tailLines == 0 {
+		tailLines = DEFAULT_TAIL_LINES
+		if podInfo.Include != "" {
+			tailLines = DEFAULT_FILTERED_TAIL_LINES
+		}
+	}
+	options := &v1.PodLogOptions{
+		Container:  podInfo.Container,
+		Previous:   podInfo.Previous,
+		TailLines:  &tailLines,
+		Timestamps: timestamps,
+	}
+	if podInfo.SinceSeconds > 0 {
+		since := int64(podInfo.SinceSeconds)
+		options.SinceSeconds = &since
+	}
+	return client.CoreV1().Pods(podInfo.NamespaceName).GetLogs(pod, options).DoRaw(ctx)
+}
+
+func splitLines(logs []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(logs))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
+
+// logLine is a line of the merged logs of several pods.
+type logLine struct {
+	pod  string
+	time time.Time
+	text string
+}
+
+func (l logLine) String() string {
+	if l.time.IsZero() {
+		return fmt.Sprintf("%s %s", l.pod, l.text)
+	}
+	return fmt.Sprintf("%s %s %s", l.pod, l.time.Format(time.RFC3339Nano), l.text)
+}
+
+// parseLogLines reads the lines prefixed by their timestamp. A line without
+// one keeps the time of the previous line.
+func parseLogLines(pod string, logs []byte) []logLine {
+	var lines []logLine
+	var last time.Time
+	for _, text := range splitLines(logs) {
+		if timestamp, rest, ok := strings.Cut(text, " "); ok {
+			if t, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+				last, text = t, rest
+			}
+		}
+		lines = append(lines, logLine{pod: pod, time: last, text: text})
+	}
+	return lines
+}
+
+// logFilter keeps the lines matching include and not exclude, with the
+// lines around them like grep -C.
+type logFilter struct {
+	include, exclude *regexp.Regexp
+	context          int
+}
+
+func newLogFilter(include, exclude string, context int) (*logFilter, error) {
+	filter := &logFilter{context: context}
+	var err error
+	if include != "" {
+		if filter.include, err = regexp.Compile(include); err != nil {
+			return nil, fmt.Errorf("invalid include: %w", err)
+		}
+	}
+	if exclude != "" {
+		if filter.exclude, err = regexp.Compile(exclude); err != nil {
+			return nil, fmt.Errorf("invalid exclude: %w", err)
+		}
+	}
+	return filter, nil
+}
 
+func (f *logFilter) apply(lines []string) []string {
+	if f.exclude != nil {
+		kept := lines[:0:0]
+		for _, line := range lines {
+			if !f.exclude.MatchString(line) {
+				kept = append(kept, line)
+			}
+		}
+		lines = kept
+	}
+	if f.include == nil {
+		return lines
+	}
+
+	var result []string
+	last := -1
+	for i, line := range lines {
+		if !f.include.MatchString(line) {
+			continue
+		}
+		start := max(i-f.context, last+1)
+		if last >= 0 && start > last+1 {
+			result = append(result, "--")
+		}
+		end := min(i+f.context, len(lines)-1)
+		result = append(result, lines[start:end+1]...)
+		last = end
+	}
+	return result
 }
@@ -0,0 +1,70 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogFilter(t *testing.T) {
+	lines := []string{
+		"GET /health 200",
+		"processing order 1",
+		"ERROR panic: nil map",
+		"  at main.go:12",
+		"  at server.go:40",
+		"GET /health 200",
+		"processing order 2",
+		"GET /health 200",
+		"ERROR timeout",
+	}
+
+	filter, err := newLogFilter("ERROR", "/health", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"processing order 1", "ERROR panic: nil map", "  at main.go:12", "--", "processing order 2", "ERROR timeout"}
+	if got := filter.apply(lines); !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if _, err := newLogFilter("(", "", 0); err == nil {
+		t.Errorf("an invalid regular expression should be refused")
+	}
+}
+
+func TestParseLogLines(t *testing.T) {
+	lines := parseLogLines("web-1", []byte("2025-01-10T18:30:00.5Z started\ncontinued\n"))
+	if len(lines) != 2 || lines[1].time != time.Date(2025, 1, 10, 18, 30, 0, 5e8, time.UTC) || lines[1].text != "continued" {
+		t.Errorf("a line without timestamp should keep the previous one: %+v", lines)
+	}
+}
+
+func TestPodLogsSelector(t *testing.T) {
+	e := crashingPod()
+
+	if result := (PodLogs{NamespaceName: "default"}).Run(context.Background(), e); !result.IsError {
+		t.Errorf("a pod, a deployment or a selector should be required: %+v", result)
+	}
+
+	result := PodLogs{NamespaceName: "default", DeploymentName: "web", Previous: true}.Run(context.Background(), e)
+	if result.IsError || !strings.HasPrefix(result.Content, "web-6d4-x ") {
+		t.Errorf("the logs of the pods of the deployment should be prefixed by the pod: %+v", result)
+	}
+}
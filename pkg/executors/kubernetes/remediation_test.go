@@ -0,0 +1,152 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/matthisholleville/ava/pkg/kubernetes"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestRollbackDeployment(t *testing.T) {
+	e := crashingPod()
+	client := e.Client.GetClient()
+	controller := true
+	previous := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "web-5c9", Namespace: "default",
+			Labels:          map[string]string{"app": "web"},
+			Annotations:     map[string]string{revisionAnnotation: "2"},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", UID: "deployment", Controller: &controller}},
+		},
+		Spec: appsv1.ReplicaSetSpec{Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web", appsv1.DefaultDeploymentUniqueLabelKey: "5c9"}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "web:1.1"}}},
+		}},
+	}
+	if _, err := client.AppsV1().ReplicaSets("default").Create(context.Background(), previous, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	result := RollbackDeployment{DeploymentName: "web", NamespaceName: "default"}.Run(context.Background(), e)
+	if result.IsError || !strings.Contains(result.Content, "from revision 3 () to revision 2 (web:1.1)") {
+		t.Fatalf("unexpected result: %s", result.Content)
+	}
+	deployment, _ := client.AppsV1().Deployments("default").Get(context.Background(), "web", metav1.GetOptions{})
+	if image := deployment.Spec.Template.Spec.Containers[0].Image; image != "web:1.1" {
+		t.Errorf("image = %s, want web:1.1", image)
+	}
+	if _, ok := deployment.Spec.Template.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; ok {
+		t.Error("the pod-template-hash label was copied to the deployment")
+	}
+
+	result = RollbackDeployment{DeploymentName: "web", NamespaceName: "default", ToRevision: 7}.Run(context.Background(), e)
+	if !result.IsError {
+		t.Fatalf("expected an error for a missing revision, got %s", result.Content)
+	}
+}
+
+func TestDrainNode(t *testing.T) {
+	controller := true
+	pod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name, Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-6d4", Controller: &controller}},
+			},
+			Spec:   corev1.PodSpec{NodeName: "node-1"},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+	daemon := pod("agent-x")
+	daemon.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "agent", Controller: &controller}}
+	client := fake.NewSimpleClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"}},
+		pod("web-a"), pod("web-b"), daemon,
+	)
+	client.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{{Name: "pods/eviction", Kind: "Eviction", Group: "policy", Version: "v1"}},
+	}}
+	client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		if action.(k8stesting.CreateAction).GetObject().(metav1.Object).GetName() == "web-b" {
+			return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+		}
+		return true, nil, nil
+	})
+	e := common.Executor{Client: &kubernetes.Client{Client: client}, Context: context.Background()}
+
+	result := DrainNode{NodeName: "node-1"}.Run(context.Background(), e)
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content)
+	}
+	for _, want := range []string{
+		"Node node-1 cordoned, schedulable before, unschedulable after",
+		"Evicted 1 pods:\n  default/web-a",
+		"Blocked by a PodDisruptionBudget, retry later: 1 pods:\n  default/web-b",
+	} {
+		if !strings.Contains(result.Content, want) {
+			t.Errorf("missing %q in:\n%s", want, result.Content)
+		}
+	}
+	if strings.Contains(result.Content, "agent-x:") || len(result.Metadata.Affected) != 2 {
+		t.Errorf("unexpected result: %s, %v", result.Content, result.Metadata.Affected)
+	}
+	node, _ := client.CoreV1().Nodes().Get(context.Background(), "node-1", metav1.GetOptions{})
+	if !node.Spec.Unschedulable {
+		t.Error("the node was not cordoned")
+	}
+
+	result = UncordonNode{NodeName: "node-1"}.Run(context.Background(), e)
+	if !strings.Contains(result.Content, "unschedulable before, schedulable after") {
+		t.Errorf("unexpected result: %s", result.Content)
+	}
+}
+
+func TestSuspendCronJob(t *testing.T) {
+	client := fake.NewSimpleClientset(&batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"},
+		Spec:       batchv1.CronJobSpec{Schedule: "0 * * * *"},
+	})
+	e := common.Executor{Client: &kubernetes.Client{Client: client}, Context: context.Background()}
+
+	result := SuspendCronJob{CronJobName: "backup", NamespaceName: "default"}.Run(context.Background(), e)
+	if result.IsError || !strings.Contains(result.Content, "active before, suspended after") {
+		t.Fatalf("unexpected result: %s", result.Content)
+	}
+	result = SuspendCronJob{CronJobName: "backup", NamespaceName: "default"}.Run(context.Background(), e)
+	if !strings.Contains(result.Content, "already suspended") || result.Metadata.Affected != nil {
+		t.Errorf("unexpected result: %s", result.Content)
+	}
+	result = ResumeCronJob{CronJobName: "backup", NamespaceName: "default"}.Run(context.Background(), e)
+	if !strings.Contains(result.Content, "suspended before, active after") {
+		t.Errorf("unexpected result: %s", result.Content)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// dynamicResource is the resource of the generic executors, resolved
+// through the discovery.
+type dynamicResource struct {
+	client     dynamic.NamespaceableResourceInterface
+	gvr        schema.GroupVersionResource
+	namespaced bool
+}
+
+// resolveResource finds the resource of a kind, a plural, a singular or a
+// short name, optionally qualified by its group like kubectl, e.g.
+// virtualservices.networking.istio.io.
+func resolveResource(e common.Executor, name, group, version string) (*dynamicResource, error) {
+	mapper, err := e.Client.GetRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	client, err := e.Client.GetDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.ToLower(name)
+	if group == "" && strings.Contains(name, ".") {
+		name, group, _ = strings.Cut(name, ".")
+	}
+
+	gvr, err := mapper.ResourceFor(schema.GroupVersionResource{Group: group, Version: version, Resource: name})
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, fmt.Errorf("the resource %s is not served by the cluster, list the CRDs to find it", qualified(name, group, version))
+		}
+		return nil, err
+	}
+
+	gvk, err := mapper.KindFor(gvr)
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dynamicResource{
+		client:     client.Resource(mapping.Resource),
+		gvr:        mapping.Resource,
+		namespaced: mapping.Scope.Name() == meta.RESTScopeNameNamespace,
+	}, nil
+}
+
+// in returns the client of the namespace, ignored by the cluster-scoped
+// resources.
+func (r *dynamicResource) in(namespace string) dynamic.ResourceInterface {
+	if !r.namespaced {
+		return r.client
+	}
+	return r.client.Namespace(namespace)
+}
+
+func qualified(name, group, version string) string {
+	for _, part := range []string{group, version} {
+		if part != "" {
+			name += "." + part
+		}
+	}
+	return name
+}
@@ -0,0 +1,114 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// restartedAtAnnotation is the pod template annotation set by kubectl
+// rollout restart.
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+type RestartStatefulSet struct {
+	StatefulSetName string `json:"statefulSetName" jsonschema:"required" description:"Name of the statefulset"`
+	NamespaceName   string `json:"namespaceName" jsonschema:"required" description:"Namespace of the statefulset"`
+}
+
+func (RestartStatefulSet) GetName() string {
+	return "restartStatefulSet"
+}
+
+func (RestartStatefulSet) GetDescription() string {
+	return "Perform a rollout restart for a statefulset"
+}
+
+func (restartInfo RestartStatefulSet) Run(ctx context.Context, e common.Executor) common.Result {
+	statefulSets := e.Client.GetClient().AppsV1().StatefulSets(restartInfo.NamespaceName)
+	statefulSet, err := statefulSets.Get(ctx, restartInfo.StatefulSetName, metav1.GetOptions{})
+	if err != nil {
+		return common.ErrorResult("unable to retrieve the statefulset", err)
+	}
+
+	previous := restartTemplate(&statefulSet.Spec.Template.ObjectMeta)
+	if _, err := statefulSets.Update(ctx, statefulSet, metav1.UpdateOptions{DryRun: e.DryRunOptions()}); err != nil {
+		return common.ErrorResult("failed to perform the rollout restart", err)
+	}
+
+	var desired int32 = 1
+	if statefulSet.Spec.Replicas != nil {
+		desired = *statefulSet.Spec.Replicas
+	}
+	return restarted(e, "StatefulSet", restartInfo.NamespaceName, restartInfo.StatefulSetName, previous, statefulSet.Status.ReadyReplicas, desired)
+}
+
+type RestartDaemonSet struct {
+	DaemonSetName string `json:"daemonSetName" jsonschema:"required" description:"Name of the daemonset"`
+	NamespaceName string `json:"namespaceName" jsonschema:"required" description:"Namespace of the daemonset"`
+}
+
+func (RestartDaemonSet) GetName() string {
+	return "restartDaemonSet"
+}
+
+func (RestartDaemonSet) GetDescription() string {
+	return "Perform a rollout restart for a daemonset"
+}
+
+func (restartInfo RestartDaemonSet) Run(ctx context.Context, e common.Executor) common.Result {
+	daemonSets := e.Client.GetClient().AppsV1().DaemonSets(restartInfo.NamespaceName)
+	daemonSet, err := daemonSets.Get(ctx, restartInfo.DaemonSetName, metav1.GetOptions{})
+	if err != nil {
+		return common.ErrorResult("unable to retrieve the daemonset", err)
+	}
+
+	previous := restartTemplate(&daemonSet.Spec.Template.ObjectMeta)
+	if _, err := daemonSets.Update(ctx, daemonSet, metav1.UpdateOptions{DryRun: e.DryRunOptions()}); err != nil {
+		return common.ErrorResult("failed to perform the rollout restart", err)
+	}
+
+	return restarted(e, "DaemonSet", restartInfo.NamespaceName, restartInfo.DaemonSetName, previous, daemonSet.Status.NumberReady, daemonSet.Status.DesiredNumberScheduled)
+}
+
+// restartTemplate sets the restart annotation on a pod template and returns
+// the previous one.
+func restartTemplate(template *metav1.ObjectMeta) string {
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	previous := template.Annotations[restartedAtAnnotation]
+	template.Annotations[restartedAtAnnotation] = time.Now().Format(time.RFC3339)
+	if previous == "" {
+		previous = "never"
+	}
+	return previous
+}
+
+// restarted reports a restart with the previous one and the pods ready
+// before it.
+func restarted(e common.Executor, kind, namespace, name, previous string, ready, desired int32) common.Result {
+	verb := "triggered"
+	if e.DryRun {
+		verb = "would be triggered"
+	}
+	result := common.TextResult(fmt.Sprintf("Rollout restart %s for %s %s, %d/%d pods were ready, previous restart: %s", verb, kind, name, ready, desired, previous))
+	result.Metadata.Affected = []common.ObjectRef{{Kind: kind, Namespace: namespace, Name: name}}
+	return result
+}
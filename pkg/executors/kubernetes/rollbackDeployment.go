@@ -0,0 +1,97 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	appsv1 "k8s.io/api/apps/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type RollbackDeployment struct {
+	DeploymentName string `json:"deploymentName" jsonschema:"required" description:"Name of the deployment"`
+	NamespaceName  string `json:"namespaceName" jsonschema:"required" description:"Namespace of the deployment"`
+	ToRevision     int    `json:"toRevision" jsonschema:"minimum=0" description:"Revision to roll back to, the previous one if empty"`
+}
+
+func (RollbackDeployment) GetName() string {
+	return "rollbackDeployment"
+}
+
+func (RollbackDeployment) GetDescription() string {
+	return "Roll back a deployment to the previous revision of its ReplicaSets, like kubectl rollout undo"
+}
+
+func (rollbackInfo RollbackDeployment) Run(ctx context.Context, e common.Executor) common.Result {
+	client := e.Client.GetClient()
+	deployments := client.AppsV1().Deployments(rollbackInfo.NamespaceName)
+	deployment, err := deployments.Get(ctx, rollbackInfo.DeploymentName, metav1.GetOptions{})
+	if err != nil {
+		return common.ErrorResult("unable to retrieve the deployment", err)
+	}
+	if deployment.Spec.Paused {
+		return common.ErrorResult("unable to roll back a paused deployment", nil)
+	}
+
+	owned, err := ownedReplicaSets(ctx, client, deployment)
+	if err != nil {
+		return common.ErrorResult("unable to list the ReplicaSets of the deployment", err)
+	}
+	if len(owned) == 0 {
+		return common.ErrorResult("the deployment has no ReplicaSet", nil)
+	}
+
+	// The ReplicaSets are sorted by revision, the current one first.
+	current := owned[0]
+	var target *appsv1.ReplicaSet
+	for i := range owned {
+		revision := replicaSetRevision(owned[i])
+		if (rollbackInfo.ToRevision == 0 && i > 0) || (rollbackInfo.ToRevision != 0 && revision == rollbackInfo.ToRevision) {
+			target = &owned[i]
+			break
+		}
+	}
+	if target == nil {
+		if rollbackInfo.ToRevision == 0 {
+			return common.ErrorResult("the deployment has no previous revision", nil)
+		}
+		return common.ErrorResult(fmt.Sprintf("revision %d of the deployment not found", rollbackInfo.ToRevision), nil)
+	}
+
+	template := *target.Spec.Template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	from := fmt.Sprintf("revision %d (%s)", replicaSetRevision(current), templateImages(deployment.Spec.Template))
+	to := fmt.Sprintf("revision %d (%s)", replicaSetRevision(*target), templateImages(template))
+	if apiequality.Semantic.DeepEqual(template, deployment.Spec.Template) {
+		return common.TextResult(fmt.Sprintf("Deployment %s already runs the template of %s, skipped rollback", rollbackInfo.DeploymentName, to))
+	}
+
+	deployment.Spec.Template = template
+	if _, err := deployments.Update(ctx, deployment, metav1.UpdateOptions{DryRun: e.DryRunOptions()}); err != nil {
+		return common.ErrorResult("failed to roll back the deployment", err)
+	}
+
+	verb := "rolled back"
+	if e.DryRun {
+		verb = "would be rolled back"
+	}
+	result := common.TextResult(fmt.Sprintf("Deployment %s %s from %s to %s", rollbackInfo.DeploymentName, verb, from, to))
+	result.Metadata.Affected = []common.ObjectRef{{Kind: "Deployment", Namespace: rollbackInfo.NamespaceName, Name: rollbackInfo.DeploymentName}}
+	return result
+}
@@ -15,7 +15,7 @@
 package kubernetes
 
 import (
-	"encoding/json"
+	"context"
 	"time"
 
 	"github.com/matthisholleville/ava/pkg/common"
@@ -23,8 +23,8 @@ import (
 )
 
 type RolloutDeployment struct {
-	DeploymentName string `json:"deploymentName"`
-	NamespaceName  string `json:"namespaceName"`
+	DeploymentName string `json:"deploymentName" jsonschema:"required" description:"Name of the deployment"`
+	NamespaceName  string `json:"namespaceName" jsonschema:"required" description:"Namespace of the deployment"`
 }
 
 func (RolloutDeployment) GetName() string {
@@ -35,34 +35,12 @@ func (RolloutDeployment) GetDescription() string {
 	return "Perform a rollout restart for a deployment"
 }
 
-func (RolloutDeployment) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"deploymentName": {
-				"type": "string"
-			},
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (RolloutDeployment) Exec(e common.Executor, jsonString string) string {
-	var rolloutInfo RolloutDeployment
-	err := json.Unmarshal([]byte(jsonString), &rolloutInfo)
-	if err != nil {
-		return "Error while retrieving parameters: " + err.Error()
-	}
-
+func (rolloutInfo RolloutDeployment) Run(ctx context.Context, e common.Executor) common.Result {
 	// Get the deployment
 	client := e.Client.GetClient()
-	deployment, err := client.AppsV1().Deployments(rolloutInfo.NamespaceName).Get(e.Context, rolloutInfo.DeploymentName, metav1.GetOptions{})
+	deployment, err := client.AppsV1().Deployments(rolloutInfo.NamespaceName).Get(ctx, rolloutInfo.DeploymentName, metav1.GetOptions{})
 	if err != nil {
-		return "Unable to retrieve deployment: " + err.Error()
+		return common.ErrorResult("unable to retrieve the deployment", err)
 	}
 
 	// Modify the deployment to add the rollout restart annotation
@@ -72,10 +50,17 @@ func (RolloutDeployment) Exec(e common.Executor, jsonString string) string {
 	deployment.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"] = time.Now().Format(time.RFC3339)
 
 	// Update the deployment
-	_, err = client.AppsV1().Deployments(rolloutInfo.NamespaceName).Update(e.Context, deployment, metav1.UpdateOptions{})
+	_, err = client.AppsV1().Deployments(rolloutInfo.NamespaceName).Update(ctx, deployment, metav1.UpdateOptions{
+		DryRun: e.DryRunOptions(),
+	})
 	if err != nil {
-		return "Failed to perform rollout restart: " + err.Error()
+		return common.ErrorResult("failed to perform the rollout restart", err)
 	}
 
-	return "Rollout restart successfully triggered for deployment " + rolloutInfo.DeploymentName
+	result := common.TextResult("Rollout restart successfully triggered for deployment " + rolloutInfo.DeploymentName)
+	if e.DryRun {
+		result.Content = "Rollout restart would be triggered for deployment " + rolloutInfo.DeploymentName
+	}
+	result.Metadata.Affected = []common.ObjectRef{{Kind: "Deployment", Namespace: rolloutInfo.NamespaceName, Name: rolloutInfo.DeploymentName}}
+	return result
 }
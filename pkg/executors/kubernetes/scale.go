@@ -0,0 +1,98 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ScaleDeployment struct {
+	DeploymentName string `json:"deploymentName" jsonschema:"required" description:"Name of the deployment"`
+	NamespaceName  string `json:"namespaceName" jsonschema:"required" description:"Namespace of the deployment"`
+	Replicas       int    `json:"replicas" jsonschema:"required,minimum=0,maximum=1000" description:"Number of replicas"`
+}
+
+func (ScaleDeployment) GetName() string {
+	return "scaleDeployment"
+}
+
+func (ScaleDeployment) GetDescription() string {
+	return "Scale a deployment to a number of replicas"
+}
+
+func (scaleInfo ScaleDeployment) Run(ctx context.Context, e common.Executor) common.Result {
+	deployments := e.Client.GetClient().AppsV1().Deployments(scaleInfo.NamespaceName)
+	return scale(e, "Deployment", scaleInfo.NamespaceName, scaleInfo.DeploymentName, scaleInfo.Replicas,
+		func() (*autoscalingv1.Scale, error) {
+			return deployments.GetScale(ctx, scaleInfo.DeploymentName, metav1.GetOptions{})
+		},
+		func(s *autoscalingv1.Scale) (*autoscalingv1.Scale, error) {
+			return deployments.UpdateScale(ctx, scaleInfo.DeploymentName, s, metav1.UpdateOptions{DryRun: e.DryRunOptions()})
+		})
+}
+
+type ScaleStatefulSet struct {
+	StatefulSetName string `json:"statefulSetName" jsonschema:"required" description:"Name of the statefulset"`
+	NamespaceName   string `json:"namespaceName" jsonschema:"required" description:"Namespace of the statefulset"`
+	Replicas        int    `json:"replicas" jsonschema:"required,minimum=0,maximum=1000" description:"Number of replicas"`
+}
+
+func (ScaleStatefulSet) GetName() string {
+	return "scaleStatefulSet"
+}
+
+func (ScaleStatefulSet) GetDescription() string {
+	return "Scale a statefulset to a number of replicas"
+}
+
+func (scaleInfo ScaleStatefulSet) Run(ctx context.Context, e common.Executor) common.Result {
+	statefulSets := e.Client.GetClient().AppsV1().StatefulSets(scaleInfo.NamespaceName)
+	return scale(e, "StatefulSet", scaleInfo.NamespaceName, scaleInfo.StatefulSetName, scaleInfo.Replicas,
+		func() (*autoscalingv1.Scale, error) {
+			return statefulSets.GetScale(ctx, scaleInfo.StatefulSetName, metav1.GetOptions{})
+		},
+		func(s *autoscalingv1.Scale) (*autoscalingv1.Scale, error) {
+			return statefulSets.UpdateScale(ctx, scaleInfo.StatefulSetName, s, metav1.UpdateOptions{DryRun: e.DryRunOptions()})
+		})
+}
+
+// scale updates the scale subresource and reports the replicas before and
+// after.
+func scale(e common.Executor, kind, namespace, name string, replicas int,
+	get func() (*autoscalingv1.Scale, error), update func(*autoscalingv1.Scale) (*autoscalingv1.Scale, error)) common.Result {
+	current, err := get()
+	if err != nil {
+		return common.ErrorResult(fmt.Sprintf("unable to get the scale of the %s", kind), err)
+	}
+	before, running := current.Spec.Replicas, current.Status.Replicas
+
+	current.Spec.Replicas = int32(replicas)
+	if _, err := update(current); err != nil {
+		return common.ErrorResult(fmt.Sprintf("unable to scale the %s", kind), err)
+	}
+
+	verb := "scaled"
+	if e.DryRun {
+		verb = "would be scaled"
+	}
+	result := common.TextResult(fmt.Sprintf("%s %s %s from %d to %d replicas, %d were running", kind, name, verb, before, replicas, running))
+	result.Metadata.Affected = []common.ObjectRef{{Kind: kind, Namespace: namespace, Name: name}}
+	return result
+}
@@ -23,8 +23,7 @@ import (
 )
 
 type TopPods struct {
-	NamespaceName string `json:"namespaceName"`
-	PodName       string `json:"podName"`
+	NamespaceName string `json:"namespaceName" description:"Namespace, all the namespaces if empty"`
 }
 
 func (TopPods) GetName() string {
@@ -35,25 +34,7 @@ func (TopPods) GetDescription() string {
 	return "Retrieve CPU and memory usage of all pods in a namespace"
 }
 
-func (TopPods) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"namespaceName": {
-				"type": "string"
-			}
-		}
-	}
-	`
-}
-
-func (TopPods) Exec(e common.Executor, jsonString string) string {
-	var podMetrics TopPods
-	err := json.Unmarshal([]byte(jsonString), &podMetrics)
-	if err != nil {
-		return "Error while retrieving the NamespaceName parameter: " + err.Error()
-	}
+func (podMetrics TopPods) Run(e common.Executor) string {
 	metricsClient := e.Client.GetMetricsClient()
 	podMetricsList, err := metricsClient.MetricsV1beta1().PodMetricses(podMetrics.NamespaceName).List(e.Context, metav1.ListOptions{})
 	if err != nil {
@@ -0,0 +1,157 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+// DEFAULT_WAIT_TIMEOUT is how long the wait executors watch by default,
+// within the timeout of the executor.
+const DEFAULT_WAIT_TIMEOUT = 5 * time.Minute
+
+// waitMargin is kept from the timeout of the executor to report the state
+// when the wait times out.
+const waitMargin = 3 * time.Second
+
+// maxFailingPods is the number of failing pods reported.
+const maxFailingPods = 10
+
+// waitContext bounds a wait by its timeout in seconds and the timeout of the
+// executor.
+func waitContext(ctx context.Context, seconds int) (context.Context, context.CancelFunc) {
+	timeout := DEFAULT_WAIT_TIMEOUT
+	if seconds > 0 {
+		timeout = time.Duration(seconds) * time.Second
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline) - waitMargin; remaining < timeout {
+			timeout = remaining
+		}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// watchUntil watches the objects listed by lw until done returns true. It
+// reports whether the wait timed out.
+func watchUntil(ctx context.Context, lw cache.ListerWatcher, objType runtime.Object, done watchtools.ConditionFunc) (bool, error) {
+	_, err := watchtools.UntilWithSync(ctx, lw, objType, nil, done)
+	if err != nil && ctx.Err() != nil {
+		return true, nil
+	}
+	return false, err
+}
+
+// listWatch returns the ListerWatcher of the given list and watch functions
+// with the options applied.
+func listWatch(options metav1.ListOptions,
+	list func(metav1.ListOptions) (runtime.Object, error), watchFunc func(metav1.ListOptions) (watch.Interface, error)) cache.ListerWatcher {
+	return &cache.ListWatch{
+		ListFunc: func(o metav1.ListOptions) (runtime.Object, error) {
+			o.LabelSelector, o.FieldSelector = options.LabelSelector, options.FieldSelector
+			return list(o)
+		},
+		WatchFunc: func(o metav1.ListOptions) (watch.Interface, error) {
+			o.LabelSelector, o.FieldSelector = options.LabelSelector, options.FieldSelector
+			return watchFunc(o)
+		},
+	}
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// failingPods describes the pods of the selector which are not ready, with
+// the reason of their containers.
+func failingPods(ctx context.Context, client kubernetes.Interface, namespace, selector string) string {
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Sprintf("Failing pods: <%s>", err.Error())
+	}
+
+	var failing []string
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if podReady(pod) || pod.Status.Phase == corev1.PodSucceeded {
+			continue
+		}
+		failing = append(failing, podFailure(pod))
+	}
+	if len(failing) == 0 {
+		return "Failing pods: none"
+	}
+	sort.Strings(failing)
+	lines := []string{fmt.Sprintf("Failing pods: %d", len(failing))}
+	for i, pod := range failing {
+		if i == maxFailingPods {
+			lines = append(lines, fmt.Sprintf("  ... and %d more", len(failing)-maxFailingPods))
+			break
+		}
+		lines = append(lines, "  "+pod)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// podFailure describes why a pod is not ready.
+func podFailure(pod *corev1.Pod) string {
+	reasons := []string{string(pod.Status.Phase)}
+	if pod.DeletionTimestamp != nil {
+		reasons = append(reasons, "terminating")
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse {
+			reasons = append(reasons, fmt.Sprintf("unschedulable: %s", valueOr(c.Message, c.Reason)))
+		}
+	}
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.Ready {
+			continue
+		}
+		reason := "not ready"
+		switch {
+		case status.State.Waiting != nil:
+			reason = valueOr(status.State.Waiting.Reason, "waiting")
+		case status.State.Terminated != nil:
+			reason = fmt.Sprintf("%s, exit code %d", valueOr(status.State.Terminated.Reason, "terminated"), status.State.Terminated.ExitCode)
+		}
+		if last := status.LastTerminationState.Terminated; last != nil {
+			reason += fmt.Sprintf(", last terminated %s (exit code %d)", valueOr(last.Reason, "?"), last.ExitCode)
+		}
+		if status.RestartCount > 0 {
+			reason += fmt.Sprintf(", %d restarts", status.RestartCount)
+		}
+		reasons = append(reasons, fmt.Sprintf("%s: %s", status.Name, reason))
+	}
+	return fmt.Sprintf("%s/%s: %s", pod.Namespace, pod.Name, strings.Join(reasons, ", "))
+}
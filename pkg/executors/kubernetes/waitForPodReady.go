@@ -0,0 +1,133 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+type WaitForPodReady struct {
+	LabelSelector  string `json:"labelSelector" jsonschema:"required" description:"Label selector of the pods, e.g. app=web"`
+	NamespaceName  string `json:"namespaceName" jsonschema:"required" description:"Namespace of the pods"`
+	MinReady       int    `json:"minReady" jsonschema:"minimum=0" description:"Number of ready pods to wait for, all the pods if empty"`
+	TimeoutSeconds int    `json:"timeoutSeconds" jsonschema:"minimum=1,maximum=600" description:"How long to wait, 300 by default, within the timeout of the executor"`
+}
+
+func (WaitForPodReady) GetName() string {
+	return "waitForPodReady"
+}
+
+func (WaitForPodReady) GetDescription() string {
+	return "Wait until the pods matching a label selector are ready or the wait times out, and return the failing pods"
+}
+
+func (podInfo WaitForPodReady) Run(ctx context.Context, e common.Executor) common.Result {
+	selector, err := labels.Parse(podInfo.LabelSelector)
+	if err != nil {
+		return common.ErrorResult("invalid label selector", err)
+	}
+	client := e.Client.GetClient()
+	pods := client.CoreV1().Pods(podInfo.NamespaceName)
+
+	start := time.Now()
+	waitCtx, cancel := waitContext(ctx, podInfo.TimeoutSeconds)
+	defer cancel()
+	lw := listWatch(metav1.ListOptions{LabelSelector: selector.String()},
+		func(o metav1.ListOptions) (runtime.Object, error) { return pods.List(waitCtx, o) },
+		func(o metav1.ListOptions) (watch.Interface, error) { return pods.Watch(waitCtx, o) })
+	// The pods seen by the watch, by name.
+	seen := map[string]*corev1.Pod{}
+	timedOut, err := watchUntil(waitCtx, lw, &corev1.Pod{}, func(event watch.Event) (bool, error) {
+		pod, ok := event.Object.(*corev1.Pod)
+		if !ok || !selector.Matches(labels.Set(pod.Labels)) {
+			return false, nil
+		}
+		if event.Type == watch.Deleted {
+			delete(seen, pod.Name)
+		} else {
+			seen[pod.Name] = pod
+		}
+		return podInfo.ready(seen), nil
+	})
+	if err != nil {
+		return common.ErrorResult("unable to watch the pods", err)
+	}
+
+	list, err := pods.List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return common.ErrorResult("unable to list the pods", err)
+	}
+	current := map[string]*corev1.Pod{}
+	for i := range list.Items {
+		current[list.Items[i].Name] = &list.Items[i]
+	}
+	ready, total := readyPods(current)
+	waited := time.Since(start).Round(time.Second)
+	var outcome string
+	switch {
+	case podInfo.ready(current):
+		outcome = fmt.Sprintf("ready after %s", waited)
+	case total == 0:
+		outcome = fmt.Sprintf("no pod found after %s", waited)
+	case timedOut:
+		outcome = fmt.Sprintf("timed out after %s", waited)
+	default:
+		outcome = "not ready"
+	}
+
+	lines := []string{
+		fmt.Sprintf("Pods %s: %s", selector.String(), outcome),
+		fmt.Sprintf("Ready: %d/%d", ready, total),
+	}
+	if podInfo.MinReady > 0 {
+		lines[1] += fmt.Sprintf(", %d required", podInfo.MinReady)
+	}
+	lines = append(lines, failingPods(ctx, client, podInfo.NamespaceName, selector.String()))
+	return common.TextResult(strings.Join(lines, "\n"))
+}
+
+// ready reports whether enough pods are ready, all of them without
+// minReady. The terminating pods are not counted.
+func (podInfo WaitForPodReady) ready(pods map[string]*corev1.Pod) bool {
+	ready, total := readyPods(pods)
+	if podInfo.MinReady > 0 {
+		return ready >= podInfo.MinReady
+	}
+	return total > 0 && ready == total
+}
+
+func readyPods(pods map[string]*corev1.Pod) (int, int) {
+	ready, total := 0, 0
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		total++
+		if podReady(pod) {
+			ready++
+		}
+	}
+	return ready, total
+}
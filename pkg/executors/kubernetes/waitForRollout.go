@@ -0,0 +1,265 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+)
+
+type WaitForRollout struct {
+	DeploymentName  string `json:"deploymentName" description:"Name of the deployment, required without a statefulset or a daemonset"`
+	StatefulSetName string `json:"statefulSetName" description:"Name of the statefulset"`
+	DaemonSetName   string `json:"daemonSetName" description:"Name of the daemonset"`
+	NamespaceName   string `json:"namespaceName" jsonschema:"required" description:"Namespace of the workload"`
+	TimeoutSeconds  int    `json:"timeoutSeconds" jsonschema:"minimum=1,maximum=600" description:"How long to wait, 300 by default, within the timeout of the executor"`
+}
+
+func (WaitForRollout) GetName() string {
+	return "waitForRollout"
+}
+
+func (WaitForRollout) GetDescription() string {
+	return "Wait until the rollout of a deployment, statefulset or daemonset completes, stalls or times out, e.g. after a restart, and return the replica counts and the failing pods"
+}
+
+// rolloutStatus is the progress of a rollout, like kubectl rollout status.
+type rolloutStatus struct {
+	done bool
+	// stalled is why the rollout cannot complete.
+	stalled string
+	// waiting is what the rollout waits for.
+	waiting  string
+	counts   string
+	selector *metav1.LabelSelector
+}
+
+// rollout is a workload whose rollout is watched.
+type rollout struct {
+	kind, name string
+	objType    runtime.Object
+	list       func(context.Context, metav1.ListOptions) (runtime.Object, error)
+	watch      func(context.Context, metav1.ListOptions) (watch.Interface, error)
+	get        func(context.Context) (runtime.Object, error)
+	status     func(runtime.Object) rolloutStatus
+}
+
+// workloadClient is the typed client of a workload kind.
+type workloadClient[T, L runtime.Object] interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+	List(ctx context.Context, opts metav1.ListOptions) (L, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+}
+
+func newRollout[T, L runtime.Object](kind, name string, objType T, client workloadClient[T, L], status func(T) rolloutStatus) *rollout {
+	return &rollout{
+		kind:    kind,
+		name:    name,
+		objType: objType,
+		list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return client.List(ctx, opts)
+		},
+		watch: client.Watch,
+		get: func(ctx context.Context) (runtime.Object, error) {
+			return client.Get(ctx, name, metav1.GetOptions{})
+		},
+		status: func(object runtime.Object) rolloutStatus {
+			return status(object.(T))
+		},
+	}
+}
+
+func (rolloutInfo WaitForRollout) Run(ctx context.Context, e common.Executor) common.Result {
+	client := e.Client.GetClient()
+	r, err := rolloutInfo.rollout(client)
+	if err != nil {
+		return common.ErrorResult("", err)
+	}
+	if _, err := r.get(ctx); err != nil {
+		return common.ErrorResult(fmt.Sprintf("unable to get the %s", strings.ToLower(r.kind)), err)
+	}
+
+	start := time.Now()
+	waitCtx, cancel := waitContext(ctx, rolloutInfo.TimeoutSeconds)
+	defer cancel()
+	lw := listWatch(metav1.ListOptions{FieldSelector: fields.OneTermEqualSelector("metadata.name", r.name).String()},
+		func(o metav1.ListOptions) (runtime.Object, error) { return r.list(waitCtx, o) },
+		func(o metav1.ListOptions) (watch.Interface, error) { return r.watch(waitCtx, o) })
+	timedOut, err := watchUntil(waitCtx, lw, r.objType, func(event watch.Event) (bool, error) {
+		object, err := meta.Accessor(event.Object)
+		if err != nil || object.GetName() != r.name {
+			return false, nil
+		}
+		if event.Type == watch.Deleted {
+			return false, fmt.Errorf("the %s was deleted", strings.ToLower(r.kind))
+		}
+		status := r.status(event.Object)
+		return status.done || status.stalled != "", nil
+	})
+	if err != nil {
+		return common.ErrorResult("unable to watch the rollout", err)
+	}
+
+	object, err := r.get(ctx)
+	if err != nil {
+		return common.ErrorResult(fmt.Sprintf("unable to get the %s", strings.ToLower(r.kind)), err)
+	}
+	status := r.status(object)
+	waited := time.Since(start).Round(time.Second)
+	var outcome string
+	switch {
+	case status.done:
+		outcome = fmt.Sprintf("rolled out after %s", waited)
+	case status.stalled != "":
+		outcome = fmt.Sprintf("stalled after %s, %s", waited, status.stalled)
+	case timedOut:
+		outcome = fmt.Sprintf("timed out after %s, %s", waited, status.waiting)
+	default:
+		outcome = status.waiting
+	}
+
+	lines := []string{fmt.Sprintf("%s %s: %s", r.kind, r.name, outcome), status.counts}
+	if selector, err := metav1.LabelSelectorAsSelector(status.selector); err == nil {
+		lines = append(lines, failingPods(ctx, client, rolloutInfo.NamespaceName, selector.String()))
+	}
+	return common.TextResult(strings.Join(lines, "\n"))
+}
+
+func (rolloutInfo WaitForRollout) rollout(client kubernetes.Interface) (*rollout, error) {
+	given := 0
+	for _, value := range []string{rolloutInfo.DeploymentName, rolloutInfo.StatefulSetName, rolloutInfo.DaemonSetName} {
+		if value != "" {
+			given++
+		}
+	}
+	if given != 1 {
+		return nil, errors.New("exactly one of deploymentName, statefulSetName and daemonSetName is required")
+	}
+
+	apps := client.AppsV1()
+	switch {
+	case rolloutInfo.DeploymentName != "":
+		return newRollout("Deployment", rolloutInfo.DeploymentName, &appsv1.Deployment{}, apps.Deployments(rolloutInfo.NamespaceName), deploymentRollout), nil
+	case rolloutInfo.StatefulSetName != "":
+		return newRollout("StatefulSet", rolloutInfo.StatefulSetName, &appsv1.StatefulSet{}, apps.StatefulSets(rolloutInfo.NamespaceName), statefulSetRollout), nil
+	default:
+		return newRollout("DaemonSet", rolloutInfo.DaemonSetName, &appsv1.DaemonSet{}, apps.DaemonSets(rolloutInfo.NamespaceName), daemonSetRollout), nil
+	}
+}
+
+func deploymentRollout(deployment *appsv1.Deployment) rolloutStatus {
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	s := deployment.Status
+	status := rolloutStatus{
+		selector: deployment.Spec.Selector,
+		counts: fmt.Sprintf("Replicas: %d desired, %d updated, %d ready, %d available, %d total",
+			desired, s.UpdatedReplicas, s.ReadyReplicas, s.AvailableReplicas, s.Replicas),
+	}
+	if deployment.Generation > s.ObservedGeneration {
+		status.waiting = "waiting for the update of the deployment to be observed"
+		return status
+	}
+	for _, c := range s.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded" {
+			status.stalled = fmt.Sprintf("%s: %s", c.Reason, c.Message)
+			return status
+		}
+	}
+	switch {
+	case s.UpdatedReplicas < desired:
+		status.waiting = fmt.Sprintf("%d of %d new replicas updated", s.UpdatedReplicas, desired)
+	case s.Replicas > s.UpdatedReplicas:
+		status.waiting = fmt.Sprintf("%d old replicas pending termination", s.Replicas-s.UpdatedReplicas)
+	case s.AvailableReplicas < s.UpdatedReplicas:
+		status.waiting = fmt.Sprintf("%d of %d updated replicas available", s.AvailableReplicas, s.UpdatedReplicas)
+	default:
+		status.done = true
+	}
+	if !status.done && deployment.Spec.Paused {
+		status.stalled = "the deployment is paused, " + status.waiting
+	}
+	return status
+}
+
+func statefulSetRollout(statefulSet *appsv1.StatefulSet) rolloutStatus {
+	desired := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		desired = *statefulSet.Spec.Replicas
+	}
+	s := statefulSet.Status
+	status := rolloutStatus{
+		selector: statefulSet.Spec.Selector,
+		counts: fmt.Sprintf("Replicas: %d desired, %d updated, %d ready, %d available, %d current",
+			desired, s.UpdatedReplicas, s.ReadyReplicas, s.AvailableReplicas, s.CurrentReplicas),
+	}
+	strategy := statefulSet.Spec.UpdateStrategy
+	switch {
+	case strategy.Type == appsv1.OnDeleteStatefulSetStrategyType:
+		status.stalled = "the OnDelete update strategy only updates the pods when they are deleted"
+	case statefulSet.Generation > s.ObservedGeneration:
+		status.waiting = "waiting for the update of the statefulset to be observed"
+	case s.ReadyReplicas < desired:
+		status.waiting = fmt.Sprintf("%d of %d pods ready", s.ReadyReplicas, desired)
+	case strategy.RollingUpdate != nil && strategy.RollingUpdate.Partition != nil && *strategy.RollingUpdate.Partition > 0:
+		partition := *strategy.RollingUpdate.Partition
+		if s.UpdatedReplicas < desired-partition {
+			status.waiting = fmt.Sprintf("%d of %d pods updated above the partition %d", s.UpdatedReplicas, desired-partition, partition)
+		} else {
+			status.done = true
+		}
+	case s.UpdateRevision != s.CurrentRevision:
+		status.waiting = fmt.Sprintf("%d of %d pods updated to revision %s", s.UpdatedReplicas, desired, s.UpdateRevision)
+	default:
+		status.done = true
+	}
+	return status
+}
+
+func daemonSetRollout(daemonSet *appsv1.DaemonSet) rolloutStatus {
+	s := daemonSet.Status
+	status := rolloutStatus{
+		selector: daemonSet.Spec.Selector,
+		counts: fmt.Sprintf("Pods: %d desired, %d updated, %d ready, %d available, %d misscheduled",
+			s.DesiredNumberScheduled, s.UpdatedNumberScheduled, s.NumberReady, s.NumberAvailable, s.NumberMisscheduled),
+	}
+	switch {
+	case daemonSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType:
+		status.stalled = "the OnDelete update strategy only updates the pods when they are deleted"
+	case daemonSet.Generation > s.ObservedGeneration:
+		status.waiting = "waiting for the update of the daemonset to be observed"
+	case s.UpdatedNumberScheduled < s.DesiredNumberScheduled:
+		status.waiting = fmt.Sprintf("%d of %d pods updated", s.UpdatedNumberScheduled, s.DesiredNumberScheduled)
+	case s.NumberAvailable < s.DesiredNumberScheduled:
+		status.waiting = fmt.Sprintf("%d of %d updated pods available", s.NumberAvailable, s.DesiredNumberScheduled)
+	default:
+		status.done = true
+	}
+	return status
+}
@@ -0,0 +1,126 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/matthisholleville/ava/pkg/kubernetes"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDeploymentRollout(t *testing.T) {
+	replicas := int32(3)
+	deployment := func(status appsv1.DeploymentStatus) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     status,
+		}
+	}
+
+	for _, tt := range []struct {
+		name    string
+		status  appsv1.DeploymentStatus
+		done    bool
+		stalled bool
+		waiting string
+	}{
+		{"not observed", appsv1.DeploymentStatus{ObservedGeneration: 1}, false, false, "to be observed"},
+		{"updating", appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 1}, false, false, "1 of 3 new replicas updated"},
+		{"old replicas", appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 3}, false, false, "1 old replicas pending termination"},
+		{"unavailable", appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 2}, false, false, "2 of 3 updated replicas available"},
+		{"stalled", appsv1.DeploymentStatus{ObservedGeneration: 2, Conditions: []appsv1.DeploymentCondition{{
+			Type: appsv1.DeploymentProgressing, Reason: "ProgressDeadlineExceeded", Message: `ReplicaSet "web-7f9" has timed out progressing.`,
+		}}}, false, true, ""},
+		{"done", appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3}, true, false, ""},
+	} {
+		status := deploymentRollout(deployment(tt.status))
+		if status.done != tt.done || (status.stalled != "") != tt.stalled || !strings.Contains(status.waiting, tt.waiting) {
+			t.Errorf("%s: unexpected status %+v", tt.name, status)
+		}
+	}
+}
+
+func TestWaitForRollout(t *testing.T) {
+	e := crashingPod()
+	result := WaitForRollout{DeploymentName: "web", NamespaceName: "default", TimeoutSeconds: 1}.Run(context.Background(), e)
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content)
+	}
+	for _, want := range []string{
+		"Deployment web: timed out after 1s, 0 of 1 new replicas updated",
+		"Replicas: 1 desired, 0 updated, 0 ready, 0 available, 0 total",
+		"default/web-6d4-x: Running, app: CrashLoopBackOff, last terminated OOMKilled (exit code 137), 7 restarts",
+	} {
+		if !strings.Contains(result.Content, want) {
+			t.Errorf("missing %q in:\n%s", want, result.Content)
+		}
+	}
+
+	// The rollout completes while it is watched.
+	deployments := e.Client.GetClient().AppsV1().Deployments("default")
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		deployment, _ := deployments.Get(context.Background(), "web", metav1.GetOptions{})
+		deployment.Status = appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1, AvailableReplicas: 1}
+		_, _ = deployments.UpdateStatus(context.Background(), deployment, metav1.UpdateOptions{})
+	}()
+	result = WaitForRollout{DeploymentName: "web", NamespaceName: "default", TimeoutSeconds: 10}.Run(context.Background(), e)
+	if !strings.Contains(result.Content, "Deployment web: rolled out after") {
+		t.Errorf("unexpected result: %s", result.Content)
+	}
+
+	result = WaitForRollout{DeploymentName: "web", DaemonSetName: "agent", NamespaceName: "default"}.Run(context.Background(), e)
+	if !result.IsError {
+		t.Errorf("expected an error with two workloads, got %s", result.Content)
+	}
+}
+
+func TestWaitForPodReady(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-a", Namespace: "default", Labels: map[string]string{"app": "web"}},
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	})
+	e := common.Executor{Client: &kubernetes.Client{Client: client}, Context: context.Background()}
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		pod, _ := client.CoreV1().Pods("default").Get(context.Background(), "web-a", metav1.GetOptions{})
+		pod.Status = corev1.PodStatus{Phase: corev1.PodRunning, Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}}
+		_, _ = client.CoreV1().Pods("default").UpdateStatus(context.Background(), pod, metav1.UpdateOptions{})
+	}()
+
+	result := WaitForPodReady{LabelSelector: "app=web", NamespaceName: "default", TimeoutSeconds: 10}.Run(context.Background(), e)
+	for _, want := range []string{"Pods app=web: ready after", "Ready: 1/1", "Failing pods: none"} {
+		if !strings.Contains(result.Content, want) {
+			t.Errorf("missing %q in:\n%s", want, result.Content)
+		}
+	}
+
+	// The wait stops before the timeout of the executor to report the state.
+	ctx, cancel := context.WithTimeout(context.Background(), waitMargin+time.Second)
+	defer cancel()
+	result = WaitForPodReady{LabelSelector: "app=api", NamespaceName: "default"}.Run(ctx, e)
+	if !strings.Contains(result.Content, "Pods app=api: no pod found after 1s") {
+		t.Errorf("unexpected result: %s", result.Content)
+	}
+}
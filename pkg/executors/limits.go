@@ -0,0 +1,76 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executors
+
+import (
+	"fmt"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/matthisholleville/ava/pkg/executors/limits"
+	"github.com/matthisholleville/ava/pkg/executors/policy"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// limitKey returns the key of the calls counted together by a limit.
+func limitKey(e common.Executor, arguments string) limits.KeyFunc {
+	target := policy.ParseTarget(arguments)
+	return func(per string) string {
+		switch per {
+		case limits.PerAll:
+			return ""
+		case limits.PerNamespace:
+			return target.Namespace
+		case limits.PerOwner:
+			return ownerKey(e, target)
+		default:
+			return targetKey(target.Kind, target.Namespace, target.Name)
+		}
+	}
+}
+
+func targetKey(kind, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+}
+
+// ownerKey returns the controller owning the pod, e.g. its deployment, or
+// the target itself.
+func ownerKey(e common.Executor, target policy.Target) string {
+	key := targetKey(target.Kind, target.Namespace, target.Name)
+	if target.Kind != "pod" || e.Client == nil {
+		return key
+	}
+
+	client := e.Client.GetClient()
+	pod, err := client.CoreV1().Pods(target.Namespace).Get(e.Context, target.Name, metav1.GetOptions{})
+	if err != nil {
+		return key
+	}
+
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return key
+	}
+
+	if owner.Kind == "ReplicaSet" {
+		replicaSet, err := client.AppsV1().ReplicaSets(target.Namespace).Get(e.Context, owner.Name, metav1.GetOptions{})
+		if err == nil {
+			if deployment := metav1.GetControllerOf(replicaSet); deployment != nil {
+				owner = deployment
+			}
+		}
+	}
+
+	return targetKey(owner.Kind, target.Namespace, owner.Name)
+}
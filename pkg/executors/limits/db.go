@@ -0,0 +1,138 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package limits
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	db "github.com/matthisholleville/ava/internal/prisma"
+)
+
+const (
+	DEFAULT_SQL_TIMEOUT = 5 * time.Second
+	// pruneInterval bounds how often the old calls are deleted.
+	pruneInterval = time.Minute
+
+	writeDisabledSetting = "executors.write.disabled"
+)
+
+type dbStore struct {
+	db *db.PrismaClient
+
+	mu     sync.Mutex
+	pruned time.Time
+}
+
+// NewDBStore returns a store shared by the replicas of the server.
+func NewDBStore(client *db.PrismaClient) Store {
+	return &dbStore{db: client}
+}
+
+func (s *dbStore) Record(ctx context.Context, executor, key string, since time.Time) (int, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, DEFAULT_SQL_TIMEOUT)
+	defer cancel()
+
+	usage, err := s.db.ExecutorUsage.CreateOne(
+		db.ExecutorUsage.Executor.Set(executor),
+		db.ExecutorUsage.Key.Set(key),
+	).Exec(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// The call is saved before counting: concurrent calls of the replicas
+	// see each other and the limit is never exceeded.
+	var result []struct {
+		Count int `json:"count"`
+	}
+	err = s.db.Prisma.QueryRaw(
+		`SELECT COUNT(*)::int AS count FROM "ExecutorUsage" WHERE "executor" = $1 AND "key" = $2 AND "createdAt" >= $3`,
+		executor, key, since,
+	).Exec(ctx, &result)
+	if err != nil {
+		return usage.ID, 0, err
+	}
+	if len(result) == 0 {
+		return usage.ID, 0, errors.New("the calls could not be counted")
+	}
+	return usage.ID, result[0].Count, nil
+}
+
+// Prune deletes the calls older than before, at most once per minute.
+func (s *dbStore) Prune(ctx context.Context, before time.Time) error {
+	s.mu.Lock()
+	if time.Since(s.pruned) < pruneInterval {
+		s.mu.Unlock()
+		return nil
+	}
+	s.pruned = time.Now()
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, DEFAULT_SQL_TIMEOUT)
+	defer cancel()
+
+	_, err := s.db.ExecutorUsage.FindMany(
+		db.ExecutorUsage.CreatedAt.Lt(before),
+	).Delete().Exec(ctx)
+	return err
+}
+
+func (s *dbStore) Forget(ctx context.Context, id int) error {
+	ctx, cancel := context.WithTimeout(ctx, DEFAULT_SQL_TIMEOUT)
+	defer cancel()
+
+	_, err := s.db.ExecutorUsage.FindUnique(
+		db.ExecutorUsage.ID.Equals(id),
+	).Delete().Exec(ctx)
+	return err
+}
+
+func (s *dbStore) WriteDisabled(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, DEFAULT_SQL_TIMEOUT)
+	defer cancel()
+
+	setting, err := s.db.Setting.FindUnique(
+		db.Setting.Key.Equals(writeDisabledSetting),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(setting.Value)
+}
+
+func (s *dbStore) SetWriteDisabled(ctx context.Context, disabled bool, by string) error {
+	ctx, cancel := context.WithTimeout(ctx, DEFAULT_SQL_TIMEOUT)
+	defer cancel()
+
+	value := strconv.FormatBool(disabled)
+	_, err := s.db.Setting.UpsertOne(
+		db.Setting.Key.Equals(writeDisabledSetting),
+	).Create(
+		db.Setting.Key.Set(writeDisabledSetting),
+		db.Setting.Value.Set(value),
+		db.Setting.UpdatedBy.Set(by),
+	).Update(
+		db.Setting.Value.Set(value),
+		db.Setting.UpdatedBy.Set(by),
+	).Exec(ctx)
+	return err
+}
@@ -0,0 +1,205 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package limits bounds the number of executor calls over time and holds
+// the kill switch of the write executors. The server shares them between
+// its replicas through the database.
+package limits
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/matthisholleville/ava/internal/configuration"
+)
+
+const (
+	PerTarget    = "target"
+	PerOwner     = "owner"
+	PerNamespace = "namespace"
+	PerAll       = "all"
+)
+
+// Store counts the executor calls and keeps the kill switch.
+type Store interface {
+	// Record saves a call and returns its ID and the number of calls of the
+	// executor for the key since the given time, including this one.
+	Record(ctx context.Context, executor, key string, since time.Time) (id int, count int, err error)
+	// Forget removes a call that did not run.
+	Forget(ctx context.Context, id int) error
+	// Prune deletes the calls older than the given time, no limit counts
+	// them anymore.
+	Prune(ctx context.Context, before time.Time) error
+	WriteDisabled(ctx context.Context) (bool, error)
+	SetWriteDisabled(ctx context.Context, disabled bool, by string) error
+}
+
+var (
+	mu           sync.RWMutex
+	defaultStore Store = NewMemoryStore()
+)
+
+// Configure sets the store used by the executors.
+func Configure(store Store) {
+	mu.Lock()
+	defer mu.Unlock()
+	defaultStore = store
+}
+
+func Default() Store {
+	mu.RLock()
+	defer mu.RUnlock()
+	return defaultStore
+}
+
+// Validate checks the limits of the configuration.
+func Validate(limits []configuration.Limit) error {
+	for i, limit := range limits {
+		if _, err := path.Match(limit.Executor, ""); err != nil || limit.Executor == "" {
+			return fmt.Errorf("executors.limits[%d].executor must be a glob of executor names, got %q", i, limit.Executor)
+		}
+		switch limit.Per {
+		case "", PerTarget, PerOwner, PerNamespace, PerAll:
+		default:
+			return fmt.Errorf("executors.limits[%d].per must be target, owner, namespace or all, got %q", i, limit.Per)
+		}
+		if limit.Max <= 0 || limit.Window <= 0 {
+			return fmt.Errorf("executors.limits[%d] must have a positive max and window", i)
+		}
+	}
+	return nil
+}
+
+// KeyFunc returns the key of the calls counted together by a limit.
+type KeyFunc func(per string) string
+
+// Check records the call against every matching limit. If a limit is
+// reached, the call is forgotten and the message returned. Otherwise the
+// returned release function forgets the call, if it does not run.
+func Check(ctx context.Context, store Store, limits []configuration.Limit, executor string, key KeyFunc) (release func(), message string, ok bool) {
+	var ids []int
+	release = func() {
+		for _, id := range ids {
+			_ = store.Forget(context.WithoutCancel(ctx), id)
+		}
+	}
+
+	now := time.Now()
+	var longest time.Duration
+	for _, limit := range limits {
+		longest = max(longest, limit.Window)
+	}
+	if longest > 0 {
+		// A failed prune is retried at the next check.
+		_ = store.Prune(ctx, now.Add(-longest))
+	}
+
+	for _, limit := range limits {
+		if matched, _ := path.Match(limit.Executor, executor); !matched {
+			continue
+		}
+
+		per := limit.Per
+		if per == "" {
+			per = PerTarget
+		}
+		scope := key(per)
+
+		id, count, err := store.Record(ctx, limit.Executor, fmt.Sprintf("%s:%s", per, scope), now.Add(-limit.Window))
+		if err != nil {
+			release()
+			return nil, fmt.Sprintf("the limits of %s could not be checked: %s", executor, err.Error()), false
+		}
+		ids = append(ids, id)
+
+		if count > limit.Max {
+			release()
+			if scope == "" {
+				scope = "all the calls"
+			}
+			return nil, fmt.Sprintf("the limit of %d calls of %s per %s for %s is reached", limit.Max, limit.Executor, limit.Window, scope), false
+		}
+	}
+
+	return release, "", true
+}
+
+type memoryStore struct {
+	mu            sync.Mutex
+	nextID        int
+	calls         map[int]memoryCall
+	writeDisabled bool
+}
+
+type memoryCall struct {
+	executor string
+	key      string
+	at       time.Time
+}
+
+// NewMemoryStore returns a store local to the process, used by the CLI.
+func NewMemoryStore() Store {
+	return &memoryStore{calls: make(map[int]memoryCall)}
+}
+
+func (s *memoryStore) Record(_ context.Context, executor, key string, since time.Time) (int, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	now := time.Now()
+	s.calls[s.nextID] = memoryCall{executor: executor, key: key, at: now}
+
+	count := 0
+	for _, call := range s.calls {
+		if call.executor == executor && call.key == key && !call.at.Before(since) {
+			count++
+		}
+	}
+	return s.nextID, count, nil
+}
+
+func (s *memoryStore) Forget(_ context.Context, id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.calls, id)
+	return nil
+}
+
+func (s *memoryStore) Prune(_ context.Context, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, call := range s.calls {
+		if call.at.Before(before) {
+			delete(s.calls, id)
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) WriteDisabled(context.Context) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.writeDisabled, nil
+}
+
+func (s *memoryStore) SetWriteDisabled(_ context.Context, disabled bool, _ string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writeDisabled = disabled
+	return nil
+}
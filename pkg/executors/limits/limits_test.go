@@ -0,0 +1,96 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package limits
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matthisholleville/ava/internal/configuration"
+)
+
+func TestCheck(t *testing.T) {
+	store := NewMemoryStore()
+	limits := []configuration.Limit{
+		{Executor: "deletePod", Per: PerOwner, Max: 2, Window: time.Hour},
+		{Executor: "rollout*", Max: 1, Window: 30 * time.Minute},
+	}
+	key := func(owner string) KeyFunc {
+		return func(per string) string {
+			if per == PerOwner {
+				return "deployment/default/" + owner
+			}
+			return "deployment/default/web"
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, message, ok := Check(context.Background(), store, limits, "deletePod", key("web")); !ok {
+			t.Fatalf("call %d should be allowed: %s", i, message)
+		}
+	}
+
+	_, message, ok := Check(context.Background(), store, limits, "deletePod", key("web"))
+	if ok || !strings.Contains(message, "deployment/default/web") {
+		t.Errorf("the third call should be blocked, got %q", message)
+	}
+
+	if _, _, ok := Check(context.Background(), store, limits, "deletePod", key("api")); !ok {
+		t.Errorf("the calls of another owner should be allowed")
+	}
+
+	release, _, ok := Check(context.Background(), store, limits, "rolloutDeployment", key("web"))
+	if !ok {
+		t.Fatalf("the first rollout should be allowed")
+	}
+	release()
+	if _, message, ok := Check(context.Background(), store, limits, "rolloutDeployment", key("web")); !ok {
+		t.Errorf("a released call should not count: %s", message)
+	}
+}
+
+func TestCheckPrunes(t *testing.T) {
+	store := NewMemoryStore().(*memoryStore)
+	store.nextID = 1
+	store.calls[1] = memoryCall{executor: "deletePod", key: "target:old", at: time.Now().Add(-2 * time.Hour)}
+	limits := []configuration.Limit{
+		{Executor: "deletePod", Max: 2, Window: time.Minute},
+		{Executor: "rollout*", Max: 1, Window: time.Hour},
+	}
+
+	if _, message, ok := Check(context.Background(), store, limits, "deletePod", func(string) string { return "web" }); !ok {
+		t.Fatalf("the call should be allowed: %s", message)
+	}
+	if _, ok := store.calls[1]; ok || len(store.calls) != 1 {
+		t.Errorf("the calls older than the longest window should be deleted: %+v", store.calls)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	invalid := [][]configuration.Limit{
+		{{Executor: "", Max: 1, Window: time.Hour}},
+		{{Executor: "deletePod", Per: "cluster", Max: 1, Window: time.Hour}},
+		{{Executor: "deletePod", Max: 0, Window: time.Hour}},
+		{{Executor: "[", Max: 1, Window: time.Hour}},
+	}
+
+	for _, limits := range invalid {
+		if err := Validate(limits); err == nil {
+			t.Errorf("limits %+v should be invalid", limits)
+		}
+	}
+}
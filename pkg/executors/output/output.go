@@ -0,0 +1,337 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package output shrinks the output of the executors before it is sent to
+// the model: noisy fields are removed, lists of Kubernetes objects become
+// compact tables and the result is truncated to a budget.
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/matthisholleville/ava/internal/configuration"
+)
+
+const (
+	DEFAULT_MAX_BYTES = 16 * 1024
+	// bytesPerToken approximates the number of bytes of a token.
+	bytesPerToken = 4
+)
+
+// noisyFields are removed from the Kubernetes objects, as paths of keys.
+var noisyFields = [][]string{
+	{"metadata", "managedFields"},
+	{"metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "uid"},
+	{"metadata", "selfLink"},
+	{"status", "images"},
+}
+
+type Processor struct {
+	// MaxBytes is the size of the output sent to the model.
+	MaxBytes int
+	// Raw disables the removal of the noisy fields and the tables.
+	Raw bool
+	Now func() time.Time
+}
+
+// New returns a processor using the smallest of the byte and token budgets.
+func New(config configuration.Output) *Processor {
+	maxBytes := config.MaxBytes
+	if config.MaxTokens > 0 && (maxBytes <= 0 || config.MaxTokens*bytesPerToken < maxBytes) {
+		maxBytes = config.MaxTokens * bytesPerToken
+	}
+	if maxBytes <= 0 {
+		maxBytes = DEFAULT_MAX_BYTES
+	}
+
+	return &Processor{
+		MaxBytes: maxBytes,
+		Raw:      config.Raw,
+		Now:      time.Now,
+	}
+}
+
+// Process returns the output to send to the model.
+func (p *Processor) Process(output string) string {
+	if !p.Raw {
+		output = p.compact(output)
+	}
+	return p.truncate(output)
+}
+
+func (p *Processor) compact(output string) string {
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &object); err != nil {
+		return output
+	}
+
+	if items, ok := object["items"].([]interface{}); ok && isObjectList(items) {
+		return p.table(items)
+	}
+
+	for _, path := range noisyFields {
+		remove(object, path)
+	}
+
+	result, err := json.Marshal(object)
+	if err != nil {
+		return output
+	}
+	return string(result)
+}
+
+func (p *Processor) truncate(output string) string {
+	if len(output) <= p.MaxBytes {
+		return output
+	}
+
+	kept := strings.ToValidUTF8(output[:p.MaxBytes], "")
+	return fmt.Sprintf("%s\n[... output truncated: %d of %d bytes shown]", kept, len(kept), len(output))
+}
+
+// isObjectList returns true if the items are Kubernetes objects.
+func isObjectList(items []interface{}) bool {
+	for _, item := range items {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if _, ok := object["metadata"].(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+type row struct {
+	name      string
+	namespace string
+	status    string
+	restarts  string
+	usage     string
+	age       string
+}
+
+// table projects the objects to their name, namespace, status, restarts,
+// resource usage and age. Empty columns are omitted.
+func (p *Processor) table(items []interface{}) string {
+	if len(items) == 0 {
+		return "No resources found."
+	}
+
+	rows := make([]row, 0, len(items))
+	for _, item := range items {
+		object := item.(map[string]interface{})
+		rows = append(rows, row{
+			name:      getString(object, "metadata", "name"),
+			namespace: getString(object, "metadata", "namespace"),
+			status:    status(object),
+			restarts:  restarts(object),
+			usage:     usage(object),
+			age:       p.age(getString(object, "metadata", "creationTimestamp")),
+		})
+	}
+
+	columns := []struct {
+		header string
+		value  func(row) string
+	}{
+		{"NAME", func(r row) string { return r.name }},
+		{"NAMESPACE", func(r row) string { return r.namespace }},
+		{"STATUS", func(r row) string { return r.status }},
+		{"RESTARTS", func(r row) string { return r.restarts }},
+		{"USAGE", func(r row) string { return r.usage }},
+		{"AGE", func(r row) string { return r.age }},
+	}
+
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "%d resources\n", len(rows))
+	w := tabwriter.NewWriter(&buffer, 0, 0, 2, ' ', 0)
+
+	var headers []string
+	var values []func(row) string
+	for _, column := range columns {
+		for _, r := range rows {
+			if column.value(r) != "" {
+				headers = append(headers, column.header)
+				values = append(values, column.value)
+				break
+			}
+		}
+	}
+
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, r := range rows {
+		cells := make([]string, len(values))
+		for i, value := range values {
+			cells[i] = value(r)
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	w.Flush()
+
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+func (p *Processor) age(creationTimestamp string) string {
+	created, err := time.Parse(time.RFC3339, creationTimestamp)
+	if err != nil {
+		return ""
+	}
+
+	d := p.Now().Sub(created)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// status summarizes the state of the object the way kubectl get does.
+func status(object map[string]interface{}) string {
+	if getString(object, "metadata", "deletionTimestamp") != "" {
+		return "Terminating"
+	}
+
+	statuses, _ := get(object, "status", "containerStatuses").([]interface{})
+	for _, containerStatus := range statuses {
+		state, _ := containerStatus.(map[string]interface{})
+		for _, key := range []string{"waiting", "terminated"} {
+			if reason := getString(state, "state", key, "reason"); reason != "" {
+				return reason
+			}
+		}
+	}
+
+	if desired, ok := get(object, "spec", "replicas").(float64); ok {
+		ready, _ := get(object, "status", "readyReplicas").(float64)
+		return fmt.Sprintf("%d/%d ready", int(ready), int(desired))
+	}
+
+	if desired, ok := get(object, "status", "desiredNumberScheduled").(float64); ok {
+		ready, _ := get(object, "status", "numberReady").(float64)
+		return fmt.Sprintf("%d/%d ready", int(ready), int(desired))
+	}
+
+	conditions, _ := get(object, "status", "conditions").([]interface{})
+	for _, c := range conditions {
+		condition, _ := c.(map[string]interface{})
+		conditionType := getString(condition, "type")
+		conditionStatus := getString(condition, "status")
+		switch {
+		case conditionType == "Ready" && getString(object, "status", "phase") == "":
+			if conditionStatus == "True" {
+				return "Ready"
+			}
+			return "NotReady"
+		case (conditionType == "Complete" || conditionType == "Failed") && conditionStatus == "True":
+			return conditionType
+		}
+	}
+
+	if phase := getString(object, "status", "phase"); phase != "" {
+		return phase
+	}
+
+	return getString(object, "type")
+}
+
+func restarts(object map[string]interface{}) string {
+	statuses, ok := get(object, "status", "containerStatuses").([]interface{})
+	if !ok {
+		return ""
+	}
+
+	total := 0
+	for _, containerStatus := range statuses {
+		state, _ := containerStatus.(map[string]interface{})
+		count, _ := state["restartCount"].(float64)
+		total += int(count)
+	}
+	return fmt.Sprint(total)
+}
+
+// usage returns the resource usage of pod and node metrics.
+func usage(object map[string]interface{}) string {
+	if resources, ok := object["usage"].(map[string]interface{}); ok {
+		return formatUsage(resources)
+	}
+
+	containers, ok := object["containers"].([]interface{})
+	if !ok {
+		return ""
+	}
+
+	var usages []string
+	for _, c := range containers {
+		container, _ := c.(map[string]interface{})
+		resources, ok := container["usage"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		usages = append(usages, fmt.Sprintf("%s: %s", getString(container, "name"), formatUsage(resources)))
+	}
+	return strings.Join(usages, ", ")
+}
+
+func formatUsage(resources map[string]interface{}) string {
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make([]string, 0, len(names))
+	for _, name := range names {
+		values = append(values, fmt.Sprintf("%s=%v", name, resources[name]))
+	}
+	return strings.Join(values, " ")
+}
+
+func get(object map[string]interface{}, path ...string) interface{} {
+	var value interface{} = object
+	for _, key := range path {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = m[key]
+	}
+	return value
+}
+
+func getString(object map[string]interface{}, path ...string) string {
+	value, _ := get(object, path...).(string)
+	return value
+}
+
+func remove(object map[string]interface{}, path []string) {
+	parent, ok := get(object, path[:len(path)-1]...).(map[string]interface{})
+	if ok {
+		delete(parent, path[len(path)-1])
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matthisholleville/ava/internal/configuration"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var now = time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+
+func newTestProcessor(config configuration.Output) *Processor {
+	p := New(config)
+	p.Now = func() time.Time { return now }
+	return p
+}
+
+func TestProcessListAsTable(t *testing.T) {
+	pods := v1.PodList{
+		Items: []v1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "web",
+					Namespace:         "default",
+					CreationTimestamp: metav1.NewTime(now.Add(-3 * time.Hour)),
+					ManagedFields:     []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+				},
+				Status: v1.PodStatus{
+					Phase: v1.PodRunning,
+					ContainerStatuses: []v1.ContainerStatus{
+						{
+							RestartCount: 12,
+							State: v1.ContainerState{
+								Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+							},
+						},
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "api",
+					Namespace:         "default",
+					CreationTimestamp: metav1.NewTime(now.Add(-72 * time.Hour)),
+				},
+				Status: v1.PodStatus{
+					Phase:             v1.PodRunning,
+					ContainerStatuses: []v1.ContainerStatus{{}},
+				},
+			},
+		},
+	}
+	data, _ := json.Marshal(pods)
+
+	result := newTestProcessor(configuration.Output{}).Process(string(data))
+
+	want := strings.Join([]string{
+		"2 resources",
+		"NAME  NAMESPACE  STATUS            RESTARTS  AGE",
+		"web   default    CrashLoopBackOff  12        3h",
+		"api   default    Running           0         3d",
+	}, "\n")
+	if result != want {
+		t.Errorf("wrong table:\ngot:\n%s\nwant:\n%s", result, want)
+	}
+}
+
+func TestProcessStripsNoisyFields(t *testing.T) {
+	node := v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          "worker-1",
+			UID:           "1234",
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubelet"}},
+			Annotations: map[string]string{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+				"team": "sre",
+			},
+		},
+		Status: v1.NodeStatus{
+			Images: []v1.ContainerImage{{Names: []string{"nginx"}}},
+		},
+	}
+	data, _ := json.Marshal(node)
+
+	result := newTestProcessor(configuration.Output{}).Process(string(data))
+
+	for _, noisy := range []string{"managedFields", "last-applied-configuration", "1234", "nginx"} {
+		if strings.Contains(result, noisy) {
+			t.Errorf("output should not contain %q: %s", noisy, result)
+		}
+	}
+	for _, kept := range []string{`"name":"worker-1"`, `"team":"sre"`} {
+		if !strings.Contains(result, kept) {
+			t.Errorf("output should contain %q: %s", kept, result)
+		}
+	}
+}
+
+func TestProcessTruncates(t *testing.T) {
+	result := newTestProcessor(configuration.Output{MaxTokens: 5}).Process(strings.Repeat("a", 100))
+
+	want := strings.Repeat("a", 20) + "\n[... output truncated: 20 of 100 bytes shown]"
+	if result != want {
+		t.Errorf("wrong truncation: got %q want %q", result, want)
+	}
+}
+
+func TestProcessRaw(t *testing.T) {
+	data := `{"metadata":{"name":"web","managedFields":[]}}`
+
+	result := newTestProcessor(configuration.Output{Raw: true}).Process(data)
+
+	if result != data {
+		t.Errorf("raw output should not be modified: got %s", result)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package params
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/matthisholleville/ava/pkg/common"
+)
+
+// Runner is an executor whose parameters are the fields of its struct. It
+// must also implement ContextRunner, or LegacyRunner until it is migrated.
+type Runner interface {
+	GetName() string
+	GetDescription() string
+}
+
+// ContextRunner runs until ctx is done, ctx is bounded by the timeout of
+// the executor.
+type ContextRunner interface {
+	Run(ctx context.Context, e common.Executor) common.Result
+}
+
+// LegacyRunner returns its errors in its output, e.Context is bounded by
+// the timeout of the executor.
+//
+// Deprecated: implement ContextRunner.
+type LegacyRunner interface {
+	Run(e common.Executor) string
+}
+
+// Executor runs a Runner with the arguments of the model decoded into a
+// new value of P.
+type Executor[P Runner] struct {
+	schema string
+}
+
+// New returns the executor of P. It panics if the schema of P cannot be
+// generated or if P does not run, which is a programming error.
+func New[P Runner]() Executor[P] {
+	var p P
+	switch any(p).(type) {
+	case ContextRunner, LegacyRunner:
+	default:
+		panic(fmt.Sprintf("executor %s does not implement Run", p.GetName()))
+	}
+
+	schema, err := Schema(reflect.TypeFor[P]())
+	if err != nil {
+		panic(err)
+	}
+	return Executor[P]{schema: schema}
+}
+
+func (Executor[P]) GetName() string {
+	var p P
+	return p.GetName()
+}
+
+func (Executor[P]) GetDescription() string {
+	var p P
+	return p.GetDescription()
+}
+
+func (x Executor[P]) GetParams() string {
+	return x.schema
+}
+
+func (Executor[P]) Validate(arguments string) error {
+	var p P
+	return Decode(arguments, &p)
+}
+
+func (x Executor[P]) Run(ctx context.Context, e common.Executor, arguments string) common.Result {
+	var p P
+	if err := Decode(arguments, &p); err != nil {
+		return common.ErrorResult("", err)
+	}
+
+	switch runner := any(p).(type) {
+	case ContextRunner:
+		return runner.Run(ctx, e)
+	case LegacyRunner:
+		e.Context = ctx
+		return common.TextResult(runner.Run(e))
+	}
+	return common.ErrorResult(fmt.Sprintf("executor %s does not implement Run", x.GetName()), nil)
+}
@@ -0,0 +1,152 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package params
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/matthisholleville/ava/internal/configuration"
+)
+
+// Field is a parameter defined at runtime, e.g. in the configuration.
+type Field struct {
+	Name        string
+	Type        string
+	Description string
+	Required    bool
+	Enum        []string
+}
+
+// ConfigFields returns the fields of the parameters of the configuration.
+func ConfigFields(parameters []configuration.Parameter) []Field {
+	fields := make([]Field, 0, len(parameters))
+	for _, p := range parameters {
+		fields = append(fields, Field{
+			Name:        p.Name,
+			Type:        p.Type,
+			Description: p.Description,
+			Required:    p.Required,
+			Enum:        p.Enum,
+		})
+	}
+	return fields
+}
+
+// Object is the parameters of an executor defined at runtime, validated
+// like the parameters of a struct.
+type Object struct {
+	fields []Field
+	schema *property
+}
+
+var types = []string{"string", "integer", "number", "boolean"}
+
+// NewObject checks the fields, their type defaults to string.
+func NewObject(fields []Field) (*Object, error) {
+	object := &Object{schema: &property{Type: "object", Properties: map[string]*property{}}}
+	for _, f := range fields {
+		if f.Name == "" {
+			return nil, fmt.Errorf("a parameter has no name")
+		}
+		if _, ok := object.schema.Properties[f.Name]; ok {
+			return nil, fmt.Errorf("parameter %s is defined twice", f.Name)
+		}
+		if f.Type == "" {
+			f.Type = "string"
+		}
+		if !slices.Contains(types, f.Type) {
+			return nil, fmt.Errorf("parameter %s: type must be one of %s, got %q", f.Name, strings.Join(types, ", "), f.Type)
+		}
+
+		object.schema.Properties[f.Name] = &property{Type: f.Type, Description: f.Description, Enum: f.Enum}
+		if f.Required {
+			object.schema.Required = append(object.schema.Required, f.Name)
+		}
+		object.fields = append(object.fields, f)
+	}
+	return object, nil
+}
+
+// Schema returns the JSON schema of the parameters.
+func (o *Object) Schema() string {
+	result, _ := json.Marshal(o.schema)
+	return string(result)
+}
+
+// Decode validates the JSON arguments and returns their values. The error
+// is a *ValidationError listing every invalid parameter.
+func (o *Object) Decode(arguments string) (map[string]interface{}, error) {
+	if strings.TrimSpace(arguments) == "" {
+		arguments = "{}"
+	}
+
+	validation := &ValidationError{}
+	var values map[string]interface{}
+	if err := json.Unmarshal([]byte(arguments), &values); err != nil || values == nil {
+		validation.add("", "must be a JSON object")
+		return nil, validation
+	}
+
+	for _, f := range o.fields {
+		value, present := values[f.Name]
+		if !present || value == nil {
+			if f.Required {
+				validation.add(f.Name, "is required")
+			}
+			continue
+		}
+
+		if !hasType(value, f.Type) {
+			validation.add(f.Name, "must be a %s, got %v", f.Type, value)
+			continue
+		}
+		if f.Required && value == "" {
+			validation.add(f.Name, "must not be empty")
+			continue
+		}
+		if len(f.Enum) > 0 && !slices.Contains(f.Enum, fmt.Sprint(value)) {
+			validation.add(f.Name, "must be one of %s", strings.Join(f.Enum, ", "))
+		}
+	}
+
+	for key := range values {
+		if _, ok := o.schema.Properties[key]; !ok {
+			validation.add(key, "is not a parameter")
+		}
+	}
+
+	if len(validation.Fields) > 0 {
+		slices.SortFunc(validation.Fields, func(a, b FieldError) int {
+			return strings.Compare(a.Field, b.Field)
+		})
+		return nil, validation
+	}
+	return values, nil
+}
+
+func hasType(value interface{}, t string) bool {
+	switch v := value.(type) {
+	case string:
+		return t == "string"
+	case bool:
+		return t == "boolean"
+	case float64:
+		return t == "number" || (t == "integer" && v == float64(int64(v)))
+	}
+	return false
+}
@@ -0,0 +1,346 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package params types the parameters of the executors: they are the fields
+// of a Go struct, the JSON schema sent to the model is generated from the
+// struct tags and the arguments are validated against it before the
+// executor runs.
+//
+//	type GetPod struct {
+//		PodName string `json:"podName" jsonschema:"required" description:"Name of the pod"`
+//		Mode    string `json:"mode,omitempty" jsonschema:"enum=short|full"`
+//		Lines   int    `json:"lines,omitempty" jsonschema:"minimum=1,maximum=1000"`
+//	}
+//
+// The jsonschema tag holds comma separated options: required, enum with the
+// values separated by |, minimum and maximum.
+package params
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type property struct {
+	Type                 string               `json:"type"`
+	Description          string               `json:"description,omitempty"`
+	Enum                 []string             `json:"enum,omitempty"`
+	Minimum              *float64             `json:"minimum,omitempty"`
+	Maximum              *float64             `json:"maximum,omitempty"`
+	Items                *property            `json:"items,omitempty"`
+	Properties           map[string]*property `json:"properties,omitempty"`
+	Required             []string             `json:"required,omitempty"`
+	AdditionalProperties *property            `json:"additionalProperties,omitempty"`
+}
+
+// field is a parameter read from a struct field.
+type field struct {
+	name     string
+	index    int
+	required bool
+	schema   *property
+}
+
+// Schema returns the JSON schema of the parameters of t, a struct.
+func Schema(t reflect.Type) (string, error) {
+	if t.Kind() != reflect.Struct {
+		return "", fmt.Errorf("parameters must be a struct, got %s", t)
+	}
+
+	schema, err := typeSchema(t)
+	if err != nil {
+		return "", err
+	}
+	// The models expect the properties of an object, even without any.
+	if schema.Properties == nil {
+		schema.Properties = map[string]*property{}
+	}
+
+	result, err := json.Marshal(schema)
+	return string(result), err
+}
+
+func typeSchema(t reflect.Type) (*property, error) {
+	switch t.Kind() {
+	case reflect.String:
+		return &property{Type: "string"}, nil
+	case reflect.Bool:
+		return &property{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &property{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &property{Type: "number"}, nil
+	case reflect.Pointer:
+		return typeSchema(t.Elem())
+	case reflect.Slice, reflect.Array:
+		items, err := typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &property{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("the keys of %s must be strings", t)
+		}
+		values, err := typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &property{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		fields, err := fieldsOf(t)
+		if err != nil {
+			return nil, err
+		}
+		schema := &property{Type: "object"}
+		for _, f := range fields {
+			if schema.Properties == nil {
+				schema.Properties = make(map[string]*property)
+			}
+			schema.Properties[f.name] = f.schema
+			if f.required {
+				schema.Required = append(schema.Required, f.name)
+			}
+		}
+		return schema, nil
+	}
+	return nil, fmt.Errorf("type %s is not supported", t)
+}
+
+// fieldsOf reads the parameters of a struct from its exported fields.
+func fieldsOf(t reflect.Type) ([]field, error) {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(structField.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = structField.Name
+		}
+
+		schema, err := typeSchema(structField.Type)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s: %w", name, err)
+		}
+		schema.Description = structField.Tag.Get("description")
+
+		f := field{name: name, index: i, schema: schema}
+		if err := f.parseOptions(structField.Tag.Get("jsonschema")); err != nil {
+			return nil, fmt.Errorf("parameter %s: %w", name, err)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+func (f *field) parseOptions(tag string) error {
+	if tag == "" {
+		return nil
+	}
+
+	for _, option := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(option, "=")
+		switch key {
+		case "required":
+			f.required = true
+		case "enum":
+			f.schema.Enum = strings.Split(value, "|")
+		case "minimum", "maximum":
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s %q", key, value)
+			}
+			if key == "minimum" {
+				f.schema.Minimum = &number
+			} else {
+				f.schema.Maximum = &number
+			}
+		default:
+			return fmt.Errorf("unknown jsonschema option %q", key)
+		}
+	}
+	return nil
+}
+
+// FieldError is an invalid parameter.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists the invalid parameters of a call.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		messages = append(messages, strings.TrimSpace(fmt.Sprintf("%s %s", f.Field, f.Message)))
+	}
+	return fmt.Sprintf("invalid arguments: %s", strings.Join(messages, ", "))
+}
+
+func (e *ValidationError) add(field, format string, args ...interface{}) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// Decode validates the JSON arguments and decodes them into v, a pointer
+// to a struct. The error is a *ValidationError listing every invalid
+// parameter.
+func Decode(arguments string, v interface{}) error {
+	if strings.TrimSpace(arguments) == "" {
+		arguments = "{}"
+	}
+
+	t := reflect.TypeOf(v).Elem()
+	validation := &ValidationError{}
+	validate(t, json.RawMessage(arguments), "", validation)
+	if len(validation.Fields) > 0 {
+		return validation
+	}
+
+	if err := json.Unmarshal([]byte(arguments), v); err != nil {
+		var typeError *json.UnmarshalTypeError
+		if errors.As(err, &typeError) {
+			expected := typeError.Type.String()
+			if schema, err := typeSchema(typeError.Type); err == nil {
+				expected = schema.Type
+			}
+			validation.add(typeError.Field, "must be a %s, got %s", expected, typeError.Value)
+			return validation
+		}
+		validation.add("", err.Error())
+		return validation
+	}
+	return nil
+}
+
+// validate checks the raw value of a struct: the unknown and missing
+// parameters, the enums, the bounds and the types of the nested values.
+func validate(t reflect.Type, raw json.RawMessage, path string, validation *ValidationError) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &object); err != nil || object == nil {
+		validation.add(path, "must be a JSON object")
+		return
+	}
+
+	fields, err := fieldsOf(t)
+	if err != nil {
+		validation.add(path, err.Error())
+		return
+	}
+
+	known := make(map[string]bool)
+	for _, f := range fields {
+		known[f.name] = true
+		name := join(path, f.name)
+
+		value, present := object[f.name]
+		if !present || string(value) == "null" {
+			if f.required {
+				validation.add(name, "is required")
+			}
+			continue
+		}
+
+		if f.required && string(value) == `""` {
+			validation.add(name, "must not be empty")
+			continue
+		}
+
+		fieldType := t.Field(f.index).Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct {
+			validate(fieldType, value, name, validation)
+			continue
+		}
+
+		validateValue(f.schema, value, name, validation)
+	}
+
+	for key := range object {
+		if !known[key] {
+			validation.add(join(path, key), "is not a parameter")
+		}
+	}
+	slices.SortFunc(validation.Fields, func(a, b FieldError) int {
+		return strings.Compare(a.Field, b.Field)
+	})
+}
+
+func validateValue(schema *property, raw json.RawMessage, name string, validation *ValidationError) {
+	if len(schema.Enum) > 0 {
+		var value string
+		if err := json.Unmarshal(raw, &value); err == nil && !slices.Contains(schema.Enum, value) {
+			validation.add(name, "must be one of %s", strings.Join(schema.Enum, ", "))
+		}
+	}
+
+	if schema.Minimum != nil || schema.Maximum != nil {
+		var number float64
+		if err := json.Unmarshal(raw, &number); err != nil {
+			return
+		}
+		if schema.Minimum != nil && number < *schema.Minimum {
+			validation.add(name, "must be at least %g", *schema.Minimum)
+		}
+		if schema.Maximum != nil && number > *schema.Maximum {
+			validation.add(name, "must be at most %g", *schema.Maximum)
+		}
+	}
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// ErrorOutput formats an error of an executor for the model.
+func ErrorOutput(executor string, err error) string {
+	output := struct {
+		Executor string       `json:"executor"`
+		Error    string       `json:"error"`
+		Fields   []FieldError `json:"fields,omitempty"`
+	}{Executor: executor, Error: err.Error()}
+
+	var validation *ValidationError
+	if errors.As(err, &validation) {
+		output.Error = "invalid arguments, fix them and retry"
+		output.Fields = validation.Fields
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(output)
+	return strings.TrimSpace(buffer.String())
+}
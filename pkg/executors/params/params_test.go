@@ -0,0 +1,121 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package params
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type logs struct {
+	PodName   string `json:"podName" jsonschema:"required" description:"Name of the pod"`
+	Mode      string `json:"mode,omitempty" jsonschema:"enum=short|full"`
+	Lines     int    `json:"lines,omitempty" jsonschema:"minimum=1,maximum=1000"`
+	Previous  bool   `json:"previous,omitempty"`
+	Selector  map[string]string
+	internal  string
+	Container *struct {
+		Name string `json:"name" jsonschema:"required"`
+	} `json:"container,omitempty"`
+}
+
+func TestSchema(t *testing.T) {
+	schema, err := Schema(reflect.TypeFor[logs]())
+	if err != nil {
+		t.Fatalf("failed to generate the schema: %v", err)
+	}
+
+	var got property
+	if err := json.Unmarshal([]byte(schema), &got); err != nil {
+		t.Fatalf("the schema is not valid JSON: %v", err)
+	}
+
+	if !slices.Equal(got.Required, []string{"podName"}) {
+		t.Errorf("podName should be required, got %v", got.Required)
+	}
+	if got.Properties["podName"].Description != "Name of the pod" {
+		t.Errorf("the description is missing: %s", schema)
+	}
+	if !slices.Equal(got.Properties["mode"].Enum, []string{"short", "full"}) {
+		t.Errorf("the enum is missing: %s", schema)
+	}
+	if got.Properties["lines"].Type != "integer" || *got.Properties["lines"].Maximum != 1000 {
+		t.Errorf("lines should be a bounded integer: %s", schema)
+	}
+	if got.Properties["Selector"].AdditionalProperties.Type != "string" {
+		t.Errorf("Selector should be a map of strings: %s", schema)
+	}
+	if _, ok := got.Properties["internal"]; ok {
+		t.Errorf("unexported fields are not parameters: %s", schema)
+	}
+	if got.Properties["container"].Required[0] != "name" {
+		t.Errorf("the nested struct should have its required fields: %s", schema)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	var valid logs
+	if err := Decode(`{"podName":"web","mode":"full","lines":10,"container":{"name":"app"}}`, &valid); err != nil {
+		t.Fatalf("the arguments should be valid: %v", err)
+	}
+	if valid.PodName != "web" || valid.Lines != 10 || valid.Container.Name != "app" {
+		t.Errorf("the arguments were not decoded: %+v", valid)
+	}
+
+	tests := []struct {
+		arguments string
+		fields    []string
+	}{
+		{`{}`, []string{"podName"}},
+		{`{"podName":""}`, []string{"podName"}},
+		{`{"podName":"web","mode":"verbose","lines":0}`, []string{"lines", "mode"}},
+		{`{"podName":"web","namespace":"default"}`, []string{"namespace"}},
+		{`{"podName":"web","container":{}}`, []string{"container.name"}},
+		{`{"podName":"web","lines":"ten"}`, []string{"lines"}},
+		{`[]`, []string{""}},
+	}
+
+	for _, test := range tests {
+		var p logs
+		err := Decode(test.arguments, &p)
+
+		var validation *ValidationError
+		if !errors.As(err, &validation) {
+			t.Errorf("%s: expected a validation error, got %v", test.arguments, err)
+			continue
+		}
+
+		var fields []string
+		for _, f := range validation.Fields {
+			fields = append(fields, f.Field)
+		}
+		if !slices.Equal(fields, test.fields) {
+			t.Errorf("%s: got invalid fields %v, want %v", test.arguments, fields, test.fields)
+		}
+	}
+}
+
+func TestErrorOutput(t *testing.T) {
+	var p logs
+	output := ErrorOutput("podLogs", Decode(`{}`, &p))
+
+	want := `{"executor":"podLogs","error":"invalid arguments, fix them and retry","fields":[{"field":"podName","message":"is required"}]}`
+	if output != want {
+		t.Errorf("got %s, want %s", output, want)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/matthisholleville/ava/pkg/executors/plugin/proto"
+)
+
+// Executor calls an executor of a plugin.
+type Executor struct {
+	plugin *plugin
+	spec   *proto.Executor
+}
+
+func (x *Executor) GetName() string {
+	return x.spec.GetName()
+}
+
+func (x *Executor) GetDescription() string {
+	return x.spec.GetDescription()
+}
+
+func (x *Executor) GetParams() string {
+	if x.spec.GetParameters() == "" {
+		return `{"type":"object","properties":{}}`
+	}
+	return x.spec.GetParameters()
+}
+
+// Validate checks the arguments are an object, the plugin validates them
+// against its schema.
+func (x *Executor) Validate(arguments string) error {
+	var values map[string]interface{}
+	if err := json.Unmarshal([]byte(arguments), &values); err != nil {
+		return errors.New("the arguments must be a JSON object")
+	}
+	return nil
+}
+
+// Write returns true if the executor changes something.
+func (x *Executor) Write() bool {
+	return x.spec.GetWrite()
+}
+
+// Plugin returns the name of the plugin serving the executor.
+func (x *Executor) Plugin() string {
+	return x.plugin.name
+}
+
+// Run calls the executor. During a dry run, a write executor is not called:
+// the plugin could ignore the dry run and change something.
+func (x *Executor) Run(ctx context.Context, e common.Executor, arguments string) common.Result {
+	if e.DryRun && x.Write() {
+		return common.TextResult(fmt.Sprintf("The executor %s of the plugin %s would run with %s", x.GetName(), x.plugin.name, arguments))
+	}
+	if !x.plugin.isHealthy() {
+		return common.ErrorResult(fmt.Sprintf("plugin %s is unavailable", x.plugin.name), nil)
+	}
+
+	x.plugin.mu.RLock()
+	client := x.plugin.client
+	x.plugin.mu.RUnlock()
+
+	response, err := client.Execute(ctx, &proto.ExecuteRequest{
+		Executor:    x.GetName(),
+		Arguments:   arguments,
+		DryRun:      e.DryRun,
+		ThreadId:    e.ThreadID,
+		RunId:       e.RunID,
+		AlertLabels: e.AlertLabels,
+	})
+	if err != nil {
+		return common.ErrorResult(fmt.Sprintf("plugin %s failed", x.plugin.name), err)
+	}
+
+	result := common.Result{Content: response.GetContent(), IsError: response.GetIsError()}
+	for _, ref := range response.GetAffected() {
+		result.Metadata.Affected = append(result.Metadata.Affected, common.ObjectRef{
+			Kind:      ref.GetKind(),
+			Namespace: ref.GetNamespace(),
+			Name:      ref.GetName(),
+		})
+	}
+	return result
+}
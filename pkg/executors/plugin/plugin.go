@@ -0,0 +1,452 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package plugin runs executors out of the process of Ava. A plugin is a
+// gRPC server, either a binary started by Ava or an endpoint already
+// running, e.g. in a sidecar. Ava describes its executors at start and
+// calls them with the deadline of the executor. A plugin which crashes or
+// hangs only fails its own calls, the binaries are restarted by the health
+// checks.
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/matthisholleville/ava/internal/configuration"
+	"github.com/matthisholleville/ava/pkg/executors/plugin/proto"
+	"github.com/matthisholleville/ava/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// SocketEnv is the unix socket a plugin binary listens on.
+	SocketEnv = "AVA_PLUGIN_SOCKET"
+	// AddressEnv is the address a plugin endpoint listens on.
+	AddressEnv = "AVA_PLUGIN_ADDRESS"
+
+	DEFAULT_HEALTH_CHECK_INTERVAL = 30 * time.Second
+
+	// maxPingFailures is the number of failed health checks after which a
+	// binary still running is restarted.
+	maxPingFailures = 3
+
+	startTimeout       = 10 * time.Second
+	healthCheckTimeout = 5 * time.Second
+	stopTimeout        = 5 * time.Second
+)
+
+var (
+	mu             sync.RWMutex
+	defaultManager *Manager
+)
+
+// Configure sets the plugins used by the executors.
+func Configure(manager *Manager) {
+	mu.Lock()
+	defer mu.Unlock()
+	defaultManager = manager
+}
+
+// Default returns the plugins used by the executors, nil if none.
+func Default() *Manager {
+	mu.RLock()
+	defer mu.RUnlock()
+	return defaultManager
+}
+
+// Manager starts the plugins and checks their health.
+type Manager struct {
+	logger   logger.ILogger
+	interval time.Duration
+	plugins  []*plugin
+	stop     chan struct{}
+	done     sync.WaitGroup
+}
+
+type plugin struct {
+	name string
+	// path is the binary of the plugin, empty for an endpoint.
+	path    string
+	address string
+	logger  logger.ILogger
+
+	mu        sync.RWMutex
+	conn      *grpc.ClientConn
+	client    proto.PluginClient
+	health    grpc_health_v1.HealthClient
+	cmd       *exec.Cmd
+	exited    chan struct{}
+	dir       string
+	healthy   bool
+	failures  int
+	executors []*Executor
+}
+
+// Validate checks the plugins of the configuration.
+func Validate(config configuration.Plugins) error {
+	names := make(map[string]bool)
+	for i, endpoint := range config.Endpoints {
+		if endpoint.Name == "" || endpoint.Address == "" {
+			return fmt.Errorf("executors.plugins.endpoints[%d] must have a name and an address", i)
+		}
+		if names[endpoint.Name] {
+			return fmt.Errorf("executors.plugins.endpoints[%d]: the plugin %s is defined twice", i, endpoint.Name)
+		}
+		names[endpoint.Name] = true
+	}
+	if config.HealthCheckInterval < 0 {
+		return fmt.Errorf("executors.plugins.healthCheckInterval must be positive")
+	}
+	if config.Directory != "" {
+		if info, err := os.Stat(config.Directory); err != nil || !info.IsDir() {
+			return fmt.Errorf("executors.plugins.directory %s is not a directory", config.Directory)
+		}
+	}
+	return nil
+}
+
+// Start starts the binaries of the directory, connects to the endpoints and
+// describes their executors. A plugin which fails to start is retried by
+// the health checks.
+func Start(config configuration.Plugins, logger logger.ILogger) (*Manager, error) {
+	m := &Manager{
+		logger:   logger,
+		interval: config.HealthCheckInterval,
+		stop:     make(chan struct{}),
+	}
+	if m.interval <= 0 {
+		m.interval = DEFAULT_HEALTH_CHECK_INTERVAL
+	}
+
+	if config.Directory != "" {
+		entries, err := os.ReadDir(config.Directory)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			info, err := entry.Info()
+			if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+				continue
+			}
+			m.plugins = append(m.plugins, &plugin{
+				name:   entry.Name(),
+				path:   filepath.Join(config.Directory, entry.Name()),
+				logger: logger,
+			})
+		}
+	}
+	for _, endpoint := range config.Endpoints {
+		m.plugins = append(m.plugins, &plugin{name: endpoint.Name, address: endpoint.Address, logger: logger})
+	}
+
+	var wg sync.WaitGroup
+	for _, p := range m.plugins {
+		wg.Add(1)
+		go func(p *plugin) {
+			defer wg.Done()
+			if err := p.start(); err != nil {
+				logger.Error(fmt.Sprintf("Plugin %s failed to start: %s", p.name, err.Error()))
+			}
+		}(p)
+	}
+	wg.Wait()
+
+	m.done.Add(1)
+	go m.checkHealth()
+
+	return m, nil
+}
+
+// Executors returns the executors of the plugins. The executors of an
+// unhealthy plugin are kept, their calls fail until it recovers.
+func (m *Manager) Executors() []*Executor {
+	if m == nil {
+		return nil
+	}
+
+	var executors []*Executor
+	names := make(map[string]string)
+	for _, p := range m.plugins {
+		for _, executor := range p.describedExecutors() {
+			if other, ok := names[executor.GetName()]; ok {
+				m.logger.Warn(fmt.Sprintf("Executor %s of the plugin %s is ignored, the plugin %s has the same", executor.GetName(), p.name, other))
+				continue
+			}
+			names[executor.GetName()] = p.name
+			executors = append(executors, executor)
+		}
+	}
+	return executors
+}
+
+// Close stops the health checks and the binaries.
+func (m *Manager) Close() {
+	if m == nil {
+		return
+	}
+	close(m.stop)
+	m.done.Wait()
+	for _, p := range m.plugins {
+		p.close()
+	}
+}
+
+func (m *Manager) checkHealth() {
+	defer m.done.Done()
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+			for _, p := range m.plugins {
+				p.check()
+			}
+		}
+	}
+}
+
+// start starts the binary, if any, connects to the plugin and describes its
+// executors.
+func (p *plugin) start() error {
+	p.close()
+
+	address := p.address
+	if p.path != "" {
+		dir, err := os.MkdirTemp("", "ava-plugin-")
+		if err != nil {
+			return err
+		}
+		socket := filepath.Join(dir, "plugin.sock")
+		address = "unix://" + socket
+
+		cmd := exec.Command(p.path)
+		cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%s", SocketEnv, socket))
+		cmd.Stdout = &logger.LoggerWriter{Logger: p.logger.Info}
+		cmd.Stderr = &logger.LoggerWriter{Logger: p.logger.Warn}
+		if err := cmd.Start(); err != nil {
+			_ = os.RemoveAll(dir)
+			return err
+		}
+
+		exited := make(chan struct{})
+		go func() {
+			_ = cmd.Wait()
+			close(exited)
+		}()
+
+		p.mu.Lock()
+		p.cmd, p.exited, p.dir = cmd, exited, dir
+		p.mu.Unlock()
+	}
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	p.conn = conn
+	p.client = proto.NewPluginClient(conn)
+	p.health = grpc_health_v1.NewHealthClient(conn)
+	p.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), startTimeout)
+	defer cancel()
+	if err := p.waitServing(ctx); err != nil {
+		return err
+	}
+	return p.describe(ctx)
+}
+
+// waitServing waits for the plugin to listen and report serving.
+func (p *plugin) waitServing(ctx context.Context) error {
+	for {
+		err := p.ping(ctx)
+		if err == nil {
+			return nil
+		}
+		if p.hasExited() {
+			return fmt.Errorf("the plugin exited")
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("the plugin is not serving: %w", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+func (p *plugin) ping(ctx context.Context) error {
+	p.mu.RLock()
+	health := p.health
+	p.mu.RUnlock()
+
+	response, err := health.Check(ctx, &grpc_health_v1.HealthCheckRequest{}, grpc.WaitForReady(false))
+	if err != nil {
+		return err
+	}
+	if response.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		return fmt.Errorf("status %s", response.GetStatus())
+	}
+	return nil
+}
+
+func (p *plugin) describe(ctx context.Context) error {
+	p.mu.RLock()
+	client := p.client
+	p.mu.RUnlock()
+
+	response, err := client.Describe(ctx, &proto.DescribeRequest{})
+	if err != nil {
+		return err
+	}
+
+	executors := make([]*Executor, 0, len(response.GetExecutors()))
+	for _, spec := range response.GetExecutors() {
+		if spec.GetName() == "" {
+			return fmt.Errorf("an executor has no name")
+		}
+		executors = append(executors, &Executor{plugin: p, spec: spec})
+	}
+
+	p.mu.Lock()
+	p.executors = executors
+	p.healthy = true
+	p.failures = 0
+	p.mu.Unlock()
+	p.logger.Info(fmt.Sprintf("Plugin %s serves %d executors", p.name, len(executors)))
+	return nil
+}
+
+// check restarts a crashed binary, or one which stopped serving, and
+// describes again a plugin which recovers.
+func (p *plugin) check() {
+	if p.path != "" && (p.hasExited() || p.connection() == nil) {
+		p.setHealthy(false)
+		p.logger.Warn(fmt.Sprintf("Plugin %s is not running, restarting it", p.name))
+		if err := p.start(); err != nil {
+			p.logger.Error(fmt.Sprintf("Plugin %s failed to restart: %s", p.name, err.Error()))
+		}
+		return
+	}
+	if p.connection() == nil {
+		if err := p.start(); err != nil {
+			p.logger.Error(fmt.Sprintf("Plugin %s is unavailable: %s", p.name, err.Error()))
+		}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+	if err := p.ping(ctx); err != nil {
+		if p.isHealthy() {
+			p.logger.Warn(fmt.Sprintf("Plugin %s is unhealthy: %s", p.name, err.Error()))
+		}
+		p.setHealthy(false)
+		p.mu.Lock()
+		p.failures++
+		failures := p.failures
+		p.mu.Unlock()
+		if p.path != "" && failures >= maxPingFailures {
+			p.logger.Warn(fmt.Sprintf("Plugin %s failed %d health checks, restarting it", p.name, failures))
+			if err := p.start(); err != nil {
+				p.logger.Error(fmt.Sprintf("Plugin %s failed to restart: %s", p.name, err.Error()))
+			}
+		}
+		return
+	}
+	p.mu.Lock()
+	p.failures = 0
+	p.mu.Unlock()
+	if !p.isHealthy() {
+		if err := p.describe(ctx); err != nil {
+			p.logger.Error(fmt.Sprintf("Plugin %s could not be described: %s", p.name, err.Error()))
+		}
+	}
+}
+
+func (p *plugin) hasExited() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.exited == nil {
+		return false
+	}
+	select {
+	case <-p.exited:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *plugin) connection() *grpc.ClientConn {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.conn
+}
+
+func (p *plugin) isHealthy() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.healthy
+}
+
+func (p *plugin) setHealthy(healthy bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.healthy = healthy
+}
+
+func (p *plugin) describedExecutors() []*Executor {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.executors
+}
+
+// close closes the connection and stops the binary, it is interrupted then
+// killed if it does not exit.
+func (p *plugin) close() {
+	p.mu.Lock()
+	conn, cmd, exited, dir := p.conn, p.cmd, p.exited, p.dir
+	p.conn, p.cmd, p.exited, p.dir = nil, nil, nil, ""
+	p.healthy = false
+	p.failures = 0
+	p.mu.Unlock()
+
+	if conn != nil {
+		_ = conn.Close()
+	}
+	if cmd != nil {
+		_ = cmd.Process.Signal(os.Interrupt)
+		select {
+		case <-exited:
+		case <-time.After(stopTimeout):
+			_ = cmd.Process.Kill()
+			<-exited
+		}
+	}
+	if dir != "" {
+		_ = os.RemoveAll(dir)
+	}
+}
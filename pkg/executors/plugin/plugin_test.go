@@ -0,0 +1,198 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matthisholleville/ava/internal/configuration"
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/matthisholleville/ava/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type echoExecutor struct{}
+
+func (echoExecutor) Run(ctx context.Context, e common.Executor, arguments string) common.Result {
+	if strings.Contains(arguments, "hang") {
+		<-ctx.Done()
+		return common.ErrorResult("", ctx.Err())
+	}
+	result := common.TextResult("echo " + arguments)
+	if e.DryRun {
+		result.Metadata.Affected = []common.ObjectRef{{Kind: "Service", Name: "web"}}
+	}
+	return result
+}
+
+func (echoExecutor) GetParams() string      { return `{"type":"object","properties":{}}` }
+func (echoExecutor) Validate(string) error  { return nil }
+func (echoExecutor) GetDescription() string { return "Echo the arguments" }
+func (echoExecutor) GetName() string        { return "echo" }
+func (echoExecutor) Write() bool            { return true }
+
+func TestPlugin(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "plugin.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := NewServer(echoExecutor{})
+	go func() { _ = server.Serve(listener) }()
+
+	manager, err := Start(configuration.Plugins{
+		Endpoints: []configuration.PluginEndpoint{{Name: "echo", Address: "unix://" + socket}},
+	}, logger.InitLogger("raw", "error"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer manager.Close()
+
+	executors := manager.Executors()
+	if len(executors) != 1 || executors[0].GetName() != "echo" || !executors[0].Write() {
+		t.Fatalf("the executor should be described: %+v", executors)
+	}
+	echo := executors[0]
+
+	result := echo.Run(context.Background(), common.Executor{}, `{"a":1}`)
+	if result.IsError || result.Content != `echo {"a":1}` {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	if result := echo.Run(context.Background(), common.Executor{DryRun: true}, `{"a":1}`); result.IsError || !strings.Contains(result.Content, "would run") || len(result.Metadata.Affected) != 0 {
+		t.Errorf("a write executor should not be called during a dry run: %+v", result)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if result := echo.Run(ctx, common.Executor{}, `{"hang":true}`); !result.IsError {
+		t.Errorf("the deadline should be sent to the plugin: %+v", result)
+	}
+
+	server.Stop()
+	manager.plugins[0].check()
+	if result := echo.Run(context.Background(), common.Executor{}, `{}`); !result.IsError || !strings.Contains(result.Content, "unavailable") {
+		t.Errorf("the calls of an unhealthy plugin should fail: %+v", result)
+	}
+}
+
+// TestHelperPlugin is the binary of TestPluginBinary.
+func TestHelperPlugin(t *testing.T) {
+	if os.Getenv(SocketEnv) == "" {
+		t.Skip("started by TestPluginBinary")
+	}
+	if err := Serve(echoExecutor{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPluginBinary(t *testing.T) {
+	dir := t.TempDir()
+	script := fmt.Sprintf("#!/bin/sh\nexec %s -test.run=TestHelperPlugin\n", os.Args[0])
+	if err := os.WriteFile(filepath.Join(dir, "echo"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	manager, err := Start(configuration.Plugins{Directory: dir}, logger.InitLogger("raw", "error"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer manager.Close()
+
+	executors := manager.Executors()
+	if len(executors) != 1 {
+		t.Fatalf("the binary should be started and described: %+v", executors)
+	}
+
+	p := manager.plugins[0]
+	p.mu.RLock()
+	cmd := p.cmd
+	p.mu.RUnlock()
+	_ = cmd.Process.Kill()
+	<-p.exited
+
+	if result := executors[0].Run(context.Background(), common.Executor{}, `{}`); !result.IsError {
+		t.Errorf("the calls should fail while the plugin is down: %+v", result)
+	}
+
+	p.check()
+	if result := executors[0].Run(context.Background(), common.Executor{}, `{}`); result.IsError {
+		t.Errorf("the plugin should be restarted: %+v", result)
+	}
+}
+
+func TestPluginBinaryNotServing(t *testing.T) {
+	dir := t.TempDir()
+	script := fmt.Sprintf("#!/bin/sh\nexec %s -test.run=TestHelperPlugin\n", os.Args[0])
+	if err := os.WriteFile(filepath.Join(dir, "echo"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	manager, err := Start(configuration.Plugins{Directory: dir}, logger.InitLogger("raw", "error"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer manager.Close()
+
+	// The binary is running but its health checks fail.
+	p := manager.plugins[0]
+	conn, err := grpc.NewClient("unix://"+filepath.Join(dir, "missing.sock"), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	p.mu.Lock()
+	cmd := p.cmd
+	p.health = grpc_health_v1.NewHealthClient(conn)
+	p.mu.Unlock()
+
+	for i := 0; i < maxPingFailures-1; i++ {
+		p.check()
+	}
+	if p.isHealthy() || p.hasExited() {
+		t.Fatalf("the plugin should be unhealthy and still running")
+	}
+
+	p.check()
+	p.mu.RLock()
+	restarted := p.cmd != cmd
+	p.mu.RUnlock()
+	if !restarted || !p.isHealthy() {
+		t.Errorf("the plugin should be restarted after %d failed health checks", maxPingFailures)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	configs := []configuration.Plugins{
+		{Endpoints: []configuration.PluginEndpoint{{Name: "a"}}},
+		{Endpoints: []configuration.PluginEndpoint{{Name: "a", Address: "localhost:1"}, {Name: "a", Address: "localhost:2"}}},
+		{Directory: filepath.Join(t.TempDir(), "missing")},
+	}
+
+	for _, config := range configs {
+		if err := Validate(config); err == nil {
+			t.Errorf("plugins %+v should be invalid", config)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/matthisholleville/ava/pkg/executors/plugin/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// ServedExecutor is an executor served by a plugin, written like the
+// built-in ones, e.g. with params.New.
+type ServedExecutor interface {
+	Run(ctx context.Context, executor common.Executor, arguments string) common.Result
+	GetParams() string
+	Validate(arguments string) error
+	GetDescription() string
+	GetName() string
+}
+
+// Serve serves the executors on the socket given by Ava to a binary, or on
+// AVA_PLUGIN_ADDRESS for an endpoint. It returns when the plugin is
+// interrupted.
+func Serve(executors ...ServedExecutor) error {
+	network, address := "unix", os.Getenv(SocketEnv)
+	if address == "" {
+		network, address = "tcp", os.Getenv(AddressEnv)
+	}
+	if address == "" {
+		return fmt.Errorf("%s or %s must be set", SocketEnv, AddressEnv)
+	}
+	if path, ok := strings.CutPrefix(address, "unix://"); ok {
+		network, address = "unix", path
+	}
+
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		return err
+	}
+
+	server := NewServer(executors...)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+
+	return server.Serve(listener)
+}
+
+// NewServer returns the gRPC server of the executors, with the health
+// service.
+func NewServer(executors ...ServedExecutor) *grpc.Server {
+	server := grpc.NewServer()
+	proto.RegisterPluginServer(server, newService(executors))
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+	return server
+}
+
+type service struct {
+	proto.UnimplementedPluginServer
+	executors map[string]ServedExecutor
+	specs     []*proto.Executor
+}
+
+func newService(executors []ServedExecutor) *service {
+	s := &service{executors: make(map[string]ServedExecutor)}
+	for _, executor := range executors {
+		s.executors[executor.GetName()] = executor
+		writer, ok := executor.(interface{ Write() bool })
+		s.specs = append(s.specs, &proto.Executor{
+			Name:        executor.GetName(),
+			Description: executor.GetDescription(),
+			Parameters:  executor.GetParams(),
+			Write:       ok && writer.Write(),
+		})
+	}
+	return s
+}
+
+func (s *service) Describe(context.Context, *proto.DescribeRequest) (*proto.DescribeResponse, error) {
+	return &proto.DescribeResponse{Executors: s.specs}, nil
+}
+
+func (s *service) Execute(ctx context.Context, request *proto.ExecuteRequest) (*proto.ExecuteResponse, error) {
+	executor, ok := s.executors[request.GetExecutor()]
+	if !ok {
+		return &proto.ExecuteResponse{Content: fmt.Sprintf("executor %s not found", request.GetExecutor()), IsError: true}, nil
+	}
+	if err := executor.Validate(request.GetArguments()); err != nil {
+		return &proto.ExecuteResponse{Content: err.Error(), IsError: true}, nil
+	}
+
+	result := executor.Run(ctx, common.Executor{
+		Context:     ctx,
+		DryRun:      request.GetDryRun(),
+		ThreadID:    request.GetThreadId(),
+		RunID:       request.GetRunId(),
+		AlertLabels: request.GetAlertLabels(),
+	}, request.GetArguments())
+
+	response := &proto.ExecuteResponse{Content: result.Content, IsError: result.IsError}
+	for _, ref := range result.Metadata.Affected {
+		response.Affected = append(response.Affected, &proto.ObjectRef{Kind: ref.Kind, Namespace: ref.Namespace, Name: ref.Name})
+	}
+	return response, nil
+}
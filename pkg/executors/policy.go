@@ -0,0 +1,101 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/matthisholleville/ava/pkg/executors/policy"
+	"github.com/matthisholleville/ava/pkg/metrics"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type metadataGetter func(ctx context.Context, client kubernetes.Interface, namespace, name string) (metav1.Object, error)
+
+// targetGetters fetch the target objects of the policies with a selector,
+// by argument name.
+var targetGetters = map[string]metadataGetter{
+	"pod": func(ctx context.Context, client kubernetes.Interface, namespace, name string) (metav1.Object, error) {
+		return client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	},
+	"deployment": func(ctx context.Context, client kubernetes.Interface, namespace, name string) (metav1.Object, error) {
+		return client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	},
+	"statefulSet": func(ctx context.Context, client kubernetes.Interface, namespace, name string) (metav1.Object, error) {
+		return client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	},
+	"daemonSet": func(ctx context.Context, client kubernetes.Interface, namespace, name string) (metav1.Object, error) {
+		return client.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	},
+	"job": func(ctx context.Context, client kubernetes.Interface, namespace, name string) (metav1.Object, error) {
+		return client.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	},
+	"cronJob": func(ctx context.Context, client kubernetes.Interface, namespace, name string) (metav1.Object, error) {
+		return client.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	},
+	"service": func(ctx context.Context, client kubernetes.Interface, namespace, name string) (metav1.Object, error) {
+		return client.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	},
+	"configMap": func(ctx context.Context, client kubernetes.Interface, namespace, name string) (metav1.Object, error) {
+		return client.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	},
+	"secret": func(ctx context.Context, client kubernetes.Interface, namespace, name string) (metav1.Object, error) {
+		return client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	},
+	"node": func(ctx context.Context, client kubernetes.Interface, _, name string) (metav1.Object, error) {
+		return client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	},
+}
+
+// targetLabels returns the labels of the target objects of the executors.
+func targetLabels(e common.Executor) policy.LabelsFunc {
+	return func(target policy.Target) (map[string]string, error) {
+		get, ok := targetGetters[target.Kind]
+		if !ok || e.Client == nil {
+			return nil, fmt.Errorf("labels of %s are not supported", target.Kind)
+		}
+
+		object, err := get(e.Context, e.Client.GetClient(), target.Namespace, target.Name)
+		if err != nil {
+			return nil, err
+		}
+		return object.GetLabels(), nil
+	}
+}
+
+// evaluatePolicy returns the reason why the call is denied, if it is.
+func evaluatePolicy(e common.Executor, engine *policy.Engine, name, arguments string) (string, bool) {
+	decision := engine.Evaluate(policy.Request{
+		Executor:    name,
+		Arguments:   arguments,
+		AlertLabels: e.AlertLabels,
+	}, targetLabels(e))
+	if decision.Allowed {
+		return "", true
+	}
+	metrics.PolicyDeniedCounter.WithLabelValues(name, decision.Rule).Inc()
+
+	reason := "denied by default"
+	if decision.Rule != "" {
+		reason = fmt.Sprintf("denied by the policy %s", decision.Rule)
+	}
+	if decision.Message != "" {
+		reason = fmt.Sprintf("%s: %s", reason, decision.Message)
+	}
+	return fmt.Sprintf("was %s", reason), false
+}
@@ -0,0 +1,337 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy allows or denies the executor calls by executor name,
+// namespace, labels of the target object, labels of the alert and time
+// windows.
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/matthisholleville/ava/internal/configuration"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	EffectAllow = "allow"
+	EffectDeny  = "deny"
+)
+
+// Target is the object an executor call acts on, read from its arguments.
+type Target struct {
+	// Kind is the name of the argument without its Name suffix, e.g. pod
+	// for podName.
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+// LabelsFunc returns the labels of the target object.
+type LabelsFunc func(target Target) (map[string]string, error)
+
+type Request struct {
+	Executor    string
+	Arguments   string
+	AlertLabels map[string]string
+	Now         time.Time
+}
+
+type Decision struct {
+	Allowed bool
+	// Rule is the name of the matching rule, empty for the default effect.
+	Rule    string
+	Message string
+}
+
+type Engine struct {
+	defaultEffect string
+	rules         []rule
+}
+
+type rule struct {
+	configuration.PolicyRule
+	selector labels.Selector
+	windows  []window
+}
+
+type window struct {
+	start, end time.Time
+	days       map[time.Weekday]bool
+	from, to   time.Duration
+	location   *time.Location
+}
+
+// New compiles the policy.
+func New(config configuration.Policy) (*Engine, error) {
+	engine := &Engine{defaultEffect: EffectAllow}
+	switch config.Default {
+	case "", EffectAllow:
+	case EffectDeny:
+		engine.defaultEffect = EffectDeny
+	default:
+		return nil, fmt.Errorf("executors.policy.default must be allow or deny, got %q", config.Default)
+	}
+
+	for i, r := range config.Rules {
+		name := r.Name
+		if name == "" {
+			name = fmt.Sprintf("rules[%d]", i)
+			r.Name = name
+		}
+
+		if r.Effect != EffectAllow && r.Effect != EffectDeny {
+			return nil, fmt.Errorf("policy %s: effect must be allow or deny, got %q", name, r.Effect)
+		}
+
+		for _, pattern := range append(append([]string{}, r.Executors...), r.Namespaces...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("policy %s: invalid pattern %q: %w", name, pattern, err)
+			}
+		}
+
+		compiled := rule{PolicyRule: r}
+		if r.Selector != "" {
+			selector, err := labels.Parse(r.Selector)
+			if err != nil {
+				return nil, fmt.Errorf("policy %s: invalid selector: %w", name, err)
+			}
+			compiled.selector = selector
+		}
+
+		for _, w := range r.Windows {
+			parsed, err := parseWindow(w)
+			if err != nil {
+				return nil, fmt.Errorf("policy %s: %w", name, err)
+			}
+			compiled.windows = append(compiled.windows, parsed)
+		}
+
+		engine.rules = append(engine.rules, compiled)
+	}
+
+	return engine, nil
+}
+
+func parseWindow(w configuration.PolicyWindow) (window, error) {
+	parsed := window{location: time.UTC}
+
+	if w.Start != "" || w.End != "" {
+		var err error
+		if parsed.start, err = time.Parse(time.RFC3339, w.Start); err != nil {
+			return parsed, fmt.Errorf("invalid window start: %w", err)
+		}
+		if parsed.end, err = time.Parse(time.RFC3339, w.End); err != nil {
+			return parsed, fmt.Errorf("invalid window end: %w", err)
+		}
+		return parsed, nil
+	}
+
+	if w.TimeZone != "" {
+		location, err := time.LoadLocation(w.TimeZone)
+		if err != nil {
+			return parsed, fmt.Errorf("invalid window time zone: %w", err)
+		}
+		parsed.location = location
+	}
+
+	if len(w.Days) > 0 {
+		parsed.days = make(map[time.Weekday]bool)
+		for _, day := range w.Days {
+			weekday, ok := weekdays[strings.ToLower(day)]
+			if !ok {
+				return parsed, fmt.Errorf("invalid window day %q", day)
+			}
+			parsed.days[weekday] = true
+		}
+	}
+
+	var err error
+	if parsed.from, err = parseClock(w.From, 0); err != nil {
+		return parsed, err
+	}
+	if parsed.to, err = parseClock(w.To, 24*time.Hour); err != nil {
+		return parsed, err
+	}
+	return parsed, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// parseClock parses a HH:MM time of day.
+func parseClock(clock string, empty time.Duration) (time.Duration, error) {
+	if clock == "" {
+		return empty, nil
+	}
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("invalid window time %q, expected HH:MM", clock)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func (w window) contains(now time.Time) bool {
+	if !w.start.IsZero() {
+		return !now.Before(w.start) && now.Before(w.end)
+	}
+
+	now = now.In(w.location)
+	clock := time.Duration(now.Hour())*time.Hour + time.Duration(now.Minute())*time.Minute
+	day := now.Weekday()
+
+	if w.from <= w.to {
+		return w.matchesDay(day) && clock >= w.from && clock < w.to
+	}
+	// The window spans midnight, e.g. from 22:00 to 06:00: the morning
+	// belongs to the window started the day before.
+	if clock >= w.from {
+		return w.matchesDay(day)
+	}
+	return clock < w.to && w.matchesDay((day+6)%7)
+}
+
+func (w window) matchesDay(day time.Weekday) bool {
+	return w.days == nil || w.days[day]
+}
+
+// Evaluate returns the decision of the first matching rule. The labels of
+// the target are only fetched if a rule has a selector.
+func (e *Engine) Evaluate(request Request, targetLabels LabelsFunc) Decision {
+	target := ParseTarget(request.Arguments)
+	if request.Now.IsZero() {
+		request.Now = time.Now()
+	}
+
+	for _, r := range e.rules {
+		if !r.matches(request, target, targetLabels) {
+			continue
+		}
+		return Decision{
+			Allowed: r.Effect == EffectAllow,
+			Rule:    r.Name,
+			Message: r.Message,
+		}
+	}
+
+	return Decision{Allowed: e.defaultEffect == EffectAllow}
+}
+
+func (r rule) matches(request Request, target Target, targetLabels LabelsFunc) bool {
+	if len(r.Executors) > 0 && !matchAny(r.Executors, request.Executor) {
+		return false
+	}
+
+	if len(r.Namespaces) > 0 && (target.Namespace == "" || !matchAny(r.Namespaces, target.Namespace)) {
+		return false
+	}
+
+	for name, pattern := range r.AlertLabels {
+		value, ok := alertLabel(request.AlertLabels, name)
+		if !ok {
+			return false
+		}
+		if matched, _ := path.Match(pattern, value); !matched {
+			return false
+		}
+	}
+
+	if len(r.windows) > 0 {
+		inWindow := false
+		for _, w := range r.windows {
+			if w.contains(request.Now) {
+				inWindow = true
+				break
+			}
+		}
+		if !inWindow {
+			return false
+		}
+	}
+
+	if r.selector != nil {
+		if target.Name == "" {
+			return false
+		}
+		// When the labels are unknown, the deny rules fail closed.
+		if targetLabels == nil {
+			return r.Effect == EffectDeny
+		}
+		objectLabels, err := targetLabels(target)
+		if err != nil {
+			return r.Effect == EffectDeny
+		}
+		return r.selector.Matches(labels.Set(objectLabels))
+	}
+
+	return true
+}
+
+// alertLabel looks up a label ignoring the case of its name, the keys of
+// the configuration are lower cased.
+func alertLabel(alertLabels map[string]string, name string) (string, bool) {
+	if value, ok := alertLabels[name]; ok {
+		return value, true
+	}
+	for key, value := range alertLabels {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func matchAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTarget reads the target object from the arguments of an executor:
+// namespaceName and the first other argument named like podName.
+func ParseTarget(arguments string) Target {
+	var values map[string]interface{}
+	if err := json.Unmarshal([]byte(arguments), &values); err != nil {
+		return Target{}
+	}
+
+	var target Target
+	target.Namespace, _ = values["namespaceName"].(string)
+	for key, value := range values {
+		name, ok := value.(string)
+		if !ok || key == "namespaceName" || !strings.HasSuffix(key, "Name") || name == "" {
+			continue
+		}
+		kind := strings.TrimSuffix(key, "Name")
+		if target.Kind == "" || kind < target.Kind {
+			target.Kind = kind
+			target.Name = name
+		}
+	}
+	return target
+}
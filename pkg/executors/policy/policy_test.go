@@ -0,0 +1,144 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matthisholleville/ava/internal/configuration"
+)
+
+// friday is a Friday at 18:30 UTC.
+var friday = time.Date(2025, 1, 10, 18, 30, 0, 0, time.UTC)
+
+func TestEvaluate(t *testing.T) {
+	engine, err := New(configuration.Policy{
+		Rules: []configuration.PolicyRule{
+			{
+				Name:       "protect-kube-system",
+				Effect:     EffectDeny,
+				Executors:  []string{"delete*", "rollout*"},
+				Namespaces: []string{"kube-*"},
+				Message:    "kube-system is managed by the platform team",
+			},
+			{
+				Name:      "protect-databases",
+				Effect:    EffectDeny,
+				Executors: []string{"deletePod"},
+				Selector:  "app in (postgres, mysql)",
+			},
+			{
+				Name:        "staging-alerts",
+				Effect:      EffectAllow,
+				AlertLabels: map[string]string{"env": "staging*"},
+			},
+			{
+				Name:      "friday-evening-freeze",
+				Effect:    EffectDeny,
+				Executors: []string{"deletePod", "rolloutDeployment"},
+				Windows:   []configuration.PolicyWindow{{Days: []string{"Friday"}, From: "16:00", To: "02:00"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to compile the policy: %v", err)
+	}
+
+	labels := func(target Target) (map[string]string, error) {
+		switch target.Name {
+		case "postgres-0":
+			return map[string]string{"app": "postgres"}, nil
+		case "web":
+			return map[string]string{"app": "web"}, nil
+		}
+		return nil, errors.New("not found")
+	}
+
+	tests := []struct {
+		name      string
+		executor  string
+		arguments string
+		alert     map[string]string
+		now       time.Time
+		allowed   bool
+		rule      string
+	}{
+		{"kube-system", "deletePod", `{"podName":"coredns","namespaceName":"kube-system"}`, nil, friday.Add(-12 * time.Hour), false, "protect-kube-system"},
+		{"read in kube-system", "getPod", `{"podName":"coredns","namespaceName":"kube-system"}`, nil, friday, true, ""},
+		{"database", "deletePod", `{"podName":"postgres-0","namespaceName":"default"}`, nil, friday.Add(-12 * time.Hour), false, "protect-databases"},
+		{"unknown target fails closed", "deletePod", `{"podName":"gone","namespaceName":"default"}`, nil, friday.Add(-12 * time.Hour), false, "protect-databases"},
+		{"staging alert", "deletePod", `{"podName":"web","namespaceName":"default"}`, map[string]string{"env": "staging-eu"}, friday, true, "staging-alerts"},
+		{"freeze", "deletePod", `{"podName":"web","namespaceName":"default"}`, nil, friday, false, "friday-evening-freeze"},
+		{"freeze after midnight", "deletePod", `{"podName":"web","namespaceName":"default"}`, nil, friday.Add(7 * time.Hour), false, "friday-evening-freeze"},
+		{"after the freeze", "deletePod", `{"podName":"web","namespaceName":"default"}`, nil, friday.Add(9 * time.Hour), true, ""},
+	}
+
+	for _, test := range tests {
+		decision := engine.Evaluate(Request{
+			Executor:    test.executor,
+			Arguments:   test.arguments,
+			AlertLabels: test.alert,
+			Now:         test.now,
+		}, labels)
+
+		if decision.Allowed != test.allowed || decision.Rule != test.rule {
+			t.Errorf("%s: got %+v, want allowed=%v rule=%q", test.name, decision, test.allowed, test.rule)
+		}
+	}
+}
+
+func TestEvaluateDefaultDeny(t *testing.T) {
+	engine, err := New(configuration.Policy{
+		Default: EffectDeny,
+		Rules: []configuration.PolicyRule{
+			{Effect: EffectAllow, Executors: []string{"get*", "list*"}},
+			{
+				Effect:  EffectAllow,
+				Windows: []configuration.PolicyWindow{{Start: "2025-01-01T00:00:00Z", End: "2025-01-02T00:00:00Z"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to compile the policy: %v", err)
+	}
+
+	if !engine.Evaluate(Request{Executor: "getPod", Now: friday}, nil).Allowed {
+		t.Errorf("getPod should be allowed")
+	}
+	if engine.Evaluate(Request{Executor: "deletePod", Now: friday}, nil).Allowed {
+		t.Errorf("deletePod should be denied by default")
+	}
+	if !engine.Evaluate(Request{Executor: "deletePod", Now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}, nil).Allowed {
+		t.Errorf("deletePod should be allowed during the window")
+	}
+}
+
+func TestNewInvalid(t *testing.T) {
+	policies := []configuration.Policy{
+		{Default: "maybe"},
+		{Rules: []configuration.PolicyRule{{Effect: "block"}}},
+		{Rules: []configuration.PolicyRule{{Effect: EffectDeny, Selector: "app in ("}}},
+		{Rules: []configuration.PolicyRule{{Effect: EffectDeny, Windows: []configuration.PolicyWindow{{Days: []string{"Caturday"}}}}}},
+		{Rules: []configuration.PolicyRule{{Effect: EffectDeny, Windows: []configuration.PolicyWindow{{From: "25:00"}}}}},
+	}
+
+	for _, p := range policies {
+		if _, err := New(p); err == nil {
+			t.Errorf("policy %+v should be invalid", p)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/matthisholleville/ava/internal/configuration"
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/matthisholleville/ava/pkg/executors/output"
+	"github.com/matthisholleville/ava/pkg/executors/params"
+	"github.com/matthisholleville/ava/pkg/redact"
+)
+
+const DEFAULT_TIMEOUT = time.Minute
+
+// waitTimeout is the default timeout of the executors waiting up to 600
+// seconds, with a margin to report what they waited for.
+const waitTimeout = 10*time.Minute + 30*time.Second
+
+// defaultTimeouts replace DEFAULT_TIMEOUT for the executors which wait.
+var defaultTimeouts = map[string]time.Duration{
+	"wait":            waitTimeout,
+	"waitForRollout":  waitTimeout,
+	"waitForPodReady": waitTimeout,
+}
+
+// timeout returns the timeout of the executor, the first matching override
+// applies, then the timeout of all the executors, then the default of the
+// executor. The timeout of all the executors does not shorten the executors
+// which wait, only an override does.
+func timeout(config configuration.Executors, name string) time.Duration {
+	for _, t := range config.Timeouts {
+		if matched, _ := path.Match(t.Executor, name); matched && t.Timeout > 0 {
+			return t.Timeout
+		}
+	}
+	if d, ok := defaultTimeouts[name]; ok {
+		return max(config.Timeout, d)
+	}
+	if config.Timeout > 0 {
+		return config.Timeout
+	}
+	return DEFAULT_TIMEOUT
+}
+
+// run runs the executor until its timeout. An executor which does not
+// return once its context is done is abandoned.
+func run(e common.Executor, executor IExecutorV2, arguments string, timeout time.Duration) common.Result {
+	parent := e.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+	e.Context = ctx
+
+	results := make(chan common.Result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				results <- common.ErrorResult(fmt.Sprintf("executor %s panicked: %v", executor.GetName(), r), nil)
+			}
+		}()
+		results <- executor.Run(ctx, e, arguments)
+	}()
+
+	select {
+	case result := <-results:
+		return result
+	case <-ctx.Done():
+		select {
+		case result := <-results:
+			return result
+		default:
+		}
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return common.ErrorResult(fmt.Sprintf("executor %s timed out after %s", executor.GetName(), timeout), nil)
+		}
+		return common.ErrorResult(fmt.Sprintf("executor %s was cancelled", executor.GetName()), ctx.Err())
+	}
+}
+
+// format returns the redacted result sent to the model, the errors in the
+// structured format of the invalid arguments.
+func format(call *common.ExecutorCall, result common.Result, config configuration.Output) string {
+	if result.IsError {
+		call.Error = redact.String(result.Content)
+		return params.ErrorOutput(call.Executor, errors.New(call.Error))
+	}
+	return output.New(config).Process(redact.Output(result.Content))
+}
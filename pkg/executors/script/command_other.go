@@ -0,0 +1,31 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !unix
+
+package script
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+)
+
+func command(context.Context, *ScriptExecutor, string) (*exec.Cmd, error) {
+	return nil, errors.New("the scripts are only supported on Unix")
+}
+
+func checkSandbox(string) error {
+	return errors.New("the scripts are only supported on Unix")
+}
@@ -0,0 +1,100 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build unix
+
+package script
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// hostPaths are the only paths of the host visible to the scripts: the
+// interpreters, their libraries and the part of /etc they need. The
+// service account, the configuration and the secrets mounted in the pod
+// are not.
+var hostPaths = []string{
+	"/usr", "/bin", "/sbin", "/lib", "/lib32", "/lib64", "/libx32",
+	"/etc/alternatives", "/etc/ld.so.cache", "/etc/passwd", "/etc/group",
+	"/etc/nsswitch.conf", "/etc/hosts", "/etc/resolv.conf", "/etc/ssl",
+	"/etc/ca-certificates",
+}
+
+// sandbox returns the bubblewrap arguments before the command.
+func sandbox(bwrap string, network bool) []string {
+	args := []string{bwrap}
+	for _, path := range hostPaths {
+		// The merged /usr distributions link /bin and /lib to /usr.
+		if target, err := os.Readlink(path); err == nil {
+			args = append(args, "--symlink", target, path)
+		} else {
+			args = append(args, "--ro-bind-try", path, path)
+		}
+	}
+	args = append(args,
+		"--dev", "/dev",
+		"--proc", "/proc",
+		"--tmpfs", "/tmp",
+		"--unshare-all",
+		"--die-with-parent",
+		"--new-session",
+	)
+	if network {
+		args = append(args, "--share-net")
+	}
+	return args
+}
+
+// checkSandbox runs a command in the sandbox, bubblewrap fails where the
+// user namespaces cannot be created.
+func checkSandbox(bwrap string) error {
+	args := append(sandbox(bwrap, false), "--remount-ro", "/", "--", "/bin/sh", "-c", "true")
+	if output, err := exec.Command(args[0], args[1:]...).CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, output)
+	}
+	return nil
+}
+
+// command returns the command running the script of the directory. The
+// script and the processes it starts are killed when the context is done.
+func command(ctx context.Context, x *ScriptExecutor, dir string) (*exec.Cmd, error) {
+	var args []string
+	if x.sandbox.Disabled {
+		args = x.limits(filepath.Join(dir, "script"))
+	} else {
+		args = append(sandbox(x.sandbox.Command, x.config.Network),
+			"--ro-bind", dir, "/ava",
+			"--chdir", "/tmp",
+			"--remount-ro", "/",
+			"--",
+		)
+		args = append(args, x.limits("/ava/script")...)
+	}
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Dir = dir
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	// The processes left by the script may hold the output open.
+	cmd.WaitDelay = time.Second
+	return cmd, nil
+}
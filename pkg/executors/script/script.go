@@ -0,0 +1,265 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package script runs the scripts of the runbooks as executors, in a
+// separate process with resource limits. The sandbox adds a read-only
+// filesystem and no network unless allowed, the parameters are given as
+// environment variables.
+package script
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/matthisholleville/ava/internal/configuration"
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/matthisholleville/ava/pkg/executors/params"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	DEFAULT_INTERPRETER     = "sh"
+	DEFAULT_MEMORY          = "512Mi"
+	DEFAULT_CPU_TIME        = 30 * time.Second
+	DEFAULT_OUTPUT_LIMIT    = "64Ki"
+	DEFAULT_SANDBOX_COMMAND = "bwrap"
+)
+
+// environment is the whole environment of the scripts besides their
+// parameters, the one of Ava holds secrets.
+var environment = []string{
+	"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin",
+	"HOME=/tmp",
+	"TMPDIR=/tmp",
+	"LANG=C.UTF-8",
+}
+
+var envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ScriptExecutor is an executor defined in the configuration, running a
+// script.
+type ScriptExecutor struct {
+	config  configuration.ScriptExecutor
+	sandbox configuration.ScriptSandbox
+	params  *params.Object
+	// memory and output are in bytes.
+	memory int64
+	output int64
+}
+
+// NewScriptExecutor checks the script and its limits.
+func NewScriptExecutor(config configuration.ScriptExecutor, sandbox configuration.ScriptSandbox) (*ScriptExecutor, error) {
+	if config.Name == "" {
+		return nil, fmt.Errorf("a script executor has no name")
+	}
+	if config.Script == "" {
+		return nil, fmt.Errorf("script executor %s: script is required", config.Name)
+	}
+	if config.Interpreter == "" {
+		config.Interpreter = DEFAULT_INTERPRETER
+	}
+	if config.CPUTime <= 0 {
+		config.CPUTime = DEFAULT_CPU_TIME
+	}
+	if sandbox.Command == "" {
+		sandbox.Command = DEFAULT_SANDBOX_COMMAND
+	}
+
+	for _, p := range config.Parameters {
+		if !envName.MatchString(p.Name) || reserved(p.Name) {
+			return nil, fmt.Errorf("script executor %s: parameter %s cannot be an environment variable", config.Name, p.Name)
+		}
+	}
+	object, err := params.NewObject(params.ConfigFields(config.Parameters))
+	if err != nil {
+		return nil, fmt.Errorf("script executor %s: %w", config.Name, err)
+	}
+
+	executor := &ScriptExecutor{config: config, sandbox: sandbox, params: object}
+	if executor.memory, err = bytesQuantity(config.Memory, DEFAULT_MEMORY); err != nil {
+		return nil, fmt.Errorf("script executor %s: invalid memory: %w", config.Name, err)
+	}
+	if executor.output, err = bytesQuantity(config.OutputLimit, DEFAULT_OUTPUT_LIMIT); err != nil {
+		return nil, fmt.Errorf("script executor %s: invalid outputLimit: %w", config.Name, err)
+	}
+	return executor, nil
+}
+
+func reserved(name string) bool {
+	for _, env := range environment {
+		if strings.HasPrefix(env, name+"=") {
+			return true
+		}
+	}
+	return false
+}
+
+func bytesQuantity(value, empty string) (int64, error) {
+	if value == "" {
+		value = empty
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, err
+	}
+	if quantity.Value() <= 0 {
+		return 0, fmt.Errorf("%s must be positive", value)
+	}
+	return quantity.Value(), nil
+}
+
+// Check returns an error if the scripts cannot be sandboxed, e.g. when the
+// pod cannot create user namespaces.
+func Check(sandbox configuration.ScriptSandbox) error {
+	if sandbox.Disabled {
+		return nil
+	}
+	command := sandbox.Command
+	if command == "" {
+		command = DEFAULT_SANDBOX_COMMAND
+	}
+	if _, err := exec.LookPath(command); err != nil {
+		return fmt.Errorf("the scripts are sandboxed with %s, install it or disable executors.scripts.sandbox: %w", command, err)
+	}
+	if err := checkSandbox(command); err != nil {
+		return fmt.Errorf("the scripts cannot be sandboxed with %s: %w", command, err)
+	}
+	return nil
+}
+
+func (x *ScriptExecutor) GetName() string {
+	return x.config.Name
+}
+
+func (x *ScriptExecutor) GetDescription() string {
+	return x.config.Description
+}
+
+func (x *ScriptExecutor) GetParams() string {
+	return x.params.Schema()
+}
+
+func (x *ScriptExecutor) Validate(arguments string) error {
+	_, err := x.params.Decode(arguments)
+	return err
+}
+
+// Write returns true if the executor changes something.
+func (x *ScriptExecutor) Write() bool {
+	return x.config.Write
+}
+
+func (x *ScriptExecutor) Run(ctx context.Context, e common.Executor, arguments string) common.Result {
+	values, err := x.params.Decode(arguments)
+	if err != nil {
+		return common.ErrorResult("", err)
+	}
+
+	env := slices.Clone(environment)
+	for _, p := range x.config.Parameters {
+		env = append(env, fmt.Sprintf("%s=%s", p.Name, format(values[p.Name])))
+	}
+
+	if e.DryRun {
+		return common.TextResult(fmt.Sprintf("The script %s would run with %v", x.config.Name, env[len(environment):]))
+	}
+
+	dir, err := os.MkdirTemp("", "ava-script-")
+	if err != nil {
+		return common.ErrorResult("unable to write the script", err)
+	}
+	defer os.RemoveAll(dir)
+	if err := os.WriteFile(filepath.Join(dir, "script"), []byte(x.config.Script), 0o444); err != nil {
+		return common.ErrorResult("unable to write the script", err)
+	}
+
+	cmd, err := command(ctx, x, dir)
+	if err != nil {
+		return common.ErrorResult("", err)
+	}
+	output := &limitedBuffer{max: x.output}
+	cmd.Env = env
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	err = cmd.Run()
+	content := output.String()
+	if ctx.Err() != nil {
+		return common.ErrorResult(fmt.Sprintf("the script %s was stopped", x.config.Name), ctx.Err())
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return common.ErrorResult(fmt.Sprintf("the script %s exited with %d: %s", x.config.Name, exitErr.ExitCode(), content), nil)
+	}
+	if err != nil {
+		return common.ErrorResult(fmt.Sprintf("the script %s failed to run", x.config.Name), err)
+	}
+	return common.TextResult(content)
+}
+
+// limits returns the arguments running the interpreter with the resource
+// limits of the script.
+func (x *ScriptExecutor) limits(script string) []string {
+	return []string{
+		"/bin/sh", "-c", `ulimit -v "$1" && ulimit -t "$2" && shift 2 && exec "$@"`, "ava-script",
+		strconv.FormatInt(x.memory/1024, 10),
+		strconv.FormatInt(int64(math.Ceil(x.config.CPUTime.Seconds())), 10),
+		x.config.Interpreter, script,
+	}
+}
+
+func format(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// limitedBuffer keeps the beginning of the output.
+type limitedBuffer struct {
+	buffer    bytes.Buffer
+	max       int64
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - int64(b.buffer.Len()); int64(len(p)) > room {
+		b.buffer.Write(p[:max(room, 0)])
+		b.truncated = true
+		return len(p), nil
+	}
+	return b.buffer.Write(p)
+}
+
+func (b *limitedBuffer) String() string {
+	if b.truncated {
+		return b.buffer.String() + "\n[output truncated]"
+	}
+	return b.buffer.String()
+}
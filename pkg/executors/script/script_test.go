@@ -0,0 +1,155 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package script
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matthisholleville/ava/internal/configuration"
+	"github.com/matthisholleville/ava/pkg/common"
+)
+
+var noSandbox = configuration.ScriptSandbox{Disabled: true}
+
+func TestScriptExecutor(t *testing.T) {
+	t.Setenv("AVA_SECRET", "secret")
+
+	executor, err := NewScriptExecutor(configuration.ScriptExecutor{
+		Name: "greet",
+		Parameters: []configuration.Parameter{
+			{Name: "name", Required: true},
+			{Name: "times", Type: "integer"},
+		},
+		Script: `echo "hello $name $times${AVA_SECRET}"`,
+	}, noSandbox)
+	if err != nil {
+		t.Fatalf("failed to compile the executor: %v", err)
+	}
+
+	e := common.Executor{Context: context.Background()}
+	result := executor.Run(context.Background(), e, `{"name":"ava; rm -rf /","times":2}`)
+	if result.IsError || result.Content != "hello ava; rm -rf / 2\n" {
+		t.Errorf("the parameters should be environment variables, without the one of Ava: %+v", result)
+	}
+
+	if err := executor.Validate(`{"times":2}`); err == nil {
+		t.Errorf("the arguments should be validated")
+	}
+
+	e.DryRun = true
+	if result := executor.Run(context.Background(), e, `{"name":"ava"}`); result.IsError || !strings.Contains(result.Content, "would run") {
+		t.Errorf("nothing should run during a dry run: %+v", result)
+	}
+}
+
+func TestScriptExecutorLimits(t *testing.T) {
+	executor, err := NewScriptExecutor(configuration.ScriptExecutor{
+		Name:        "noisy",
+		Script:      "yes | head -c 100000; exit 3",
+		OutputLimit: "1Ki",
+	}, noSandbox)
+	if err != nil {
+		t.Fatalf("failed to compile the executor: %v", err)
+	}
+
+	result := executor.Run(context.Background(), common.Executor{}, `{}`)
+	if !result.IsError || !strings.Contains(result.Content, "exited with 3") || !strings.HasSuffix(result.Content, "[output truncated]") || len(result.Content) > 2048 {
+		t.Errorf("the output should be capped and the exit code reported: %.200s", result.Content)
+	}
+
+	sleeper, _ := NewScriptExecutor(configuration.ScriptExecutor{Name: "sleep", Script: "sleep 60 & wait"}, noSandbox)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if result := sleeper.Run(ctx, common.Executor{}, `{}`); !result.IsError || time.Since(start) > 5*time.Second {
+		t.Errorf("the script should be killed at the deadline: %+v", result)
+	}
+}
+
+func TestScriptExecutorSandbox(t *testing.T) {
+	sandbox := configuration.ScriptSandbox{}
+	if err := Check(sandbox); err != nil {
+		t.Skip(err)
+	}
+
+	executor, err := NewScriptExecutor(configuration.ScriptExecutor{
+		Name:   "touch",
+		Script: "touch /etc/ava-sandbox && echo writable; touch /tmp/file && echo tmp",
+	}, sandbox)
+	if err != nil {
+		t.Fatalf("failed to compile the executor: %v", err)
+	}
+
+	result := executor.Run(context.Background(), common.Executor{}, `{}`)
+	if strings.Contains(result.Content, "writable") || !strings.Contains(result.Content, "tmp") {
+		t.Errorf("the filesystem should be read-only but /tmp: %+v", result)
+	}
+	if _, err := os.Stat("/etc/ava-sandbox"); err == nil {
+		_ = os.Remove("/etc/ava-sandbox")
+		t.Errorf("the script should not write on the host")
+	}
+}
+
+func TestScriptExecutorSandboxHostFiles(t *testing.T) {
+	sandbox := configuration.ScriptSandbox{}
+	if err := Check(sandbox); err != nil {
+		t.Skip(err)
+	}
+
+	// The service account token and the configuration are mounted outside
+	// of the interpreter paths, like this file.
+	secret, err := filepath.Abs("script_test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	executor, err := NewScriptExecutor(configuration.ScriptExecutor{
+		Name:       "cat",
+		Parameters: []configuration.Parameter{{Name: "file", Required: true}},
+		Script:     `cat "$file" /etc/hostname && echo readable; sh -c "echo interpreter"`,
+	}, sandbox)
+	if err != nil {
+		t.Fatalf("failed to compile the executor: %v", err)
+	}
+
+	result := executor.Run(context.Background(), common.Executor{}, fmt.Sprintf(`{"file":%q}`, secret))
+	if strings.Contains(result.Content, "readable") || strings.Contains(result.Content, "package script") {
+		t.Errorf("the script should not read the files of the host: %+v", result)
+	}
+	if !strings.Contains(result.Content, "interpreter") {
+		t.Errorf("the script should run the interpreters: %+v", result)
+	}
+}
+
+func TestNewScriptExecutorInvalid(t *testing.T) {
+	configs := []configuration.ScriptExecutor{
+		{Script: "true"},
+		{Name: "noScript"},
+		{Name: "badName", Script: "true", Parameters: []configuration.Parameter{{Name: "pod-name"}}},
+		{Name: "reserved", Script: "true", Parameters: []configuration.Parameter{{Name: "PATH"}}},
+		{Name: "badMemory", Script: "true", Memory: "lots"},
+	}
+
+	for _, config := range configs {
+		if _, err := NewScriptExecutor(config, noSandbox); err == nil {
+			t.Errorf("executor %+v should be invalid", config)
+		}
+	}
+}
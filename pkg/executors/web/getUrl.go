@@ -15,7 +15,7 @@
 package web
 
 import (
-	"encoding/json"
+	"context"
 	"net/http"
 	"time"
 
@@ -23,7 +23,7 @@ import (
 )
 
 type GetUrl struct {
-	Url string `json:"url"`
+	Url string `json:"url" jsonschema:"required" description:"URL to call"`
 }
 
 func (GetUrl) GetName() string {
@@ -34,34 +34,21 @@ func (GetUrl) GetDescription() string {
 	return "Call a URL and return the status code and response time"
 }
 
-func (GetUrl) GetParams() string {
-	return `
-	{
-		"type": "object",
-		"properties": {
-			"url": {
-			"type": "string"
-			}
-		}
+func (urlInfo GetUrl) Run(ctx context.Context, e common.Executor) common.Result {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, urlInfo.Url, nil)
+	if err != nil {
+		return common.ErrorResult("invalid GET request", err)
 	}
-	`
-}
 
-func (GetUrl) Exec(e common.Executor, jsonString string) string {
 	// execute a check on the url and return the status code
-	var urlInfo GetUrl
-	err := json.Unmarshal([]byte(jsonString), &urlInfo)
-	if err != nil {
-		return "Error while retrieving the url parameter:" + err.Error()
-	}
 	start := time.Now()
 
-	resp, err := http.Get(urlInfo.Url)
+	resp, err := http.DefaultClient.Do(request)
 	if err != nil {
-		return "Error while executing the GET request:" + err.Error()
+		return common.ErrorResult("error while executing the GET request", err)
 	}
 	defer resp.Body.Close()
 
 	duration := time.Since(start)
-	return "GET request to " + urlInfo.Url + " returned status code " + resp.Status + " in " + duration.String()
+	return common.TextResult("GET request to " + urlInfo.Url + " returned status code " + resp.Status + " in " + duration.String())
 }
@@ -0,0 +1,283 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/matthisholleville/ava/internal/configuration"
+	"github.com/matthisholleville/ava/pkg/common"
+	"github.com/matthisholleville/ava/pkg/executors/params"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// maxResponseBytes bounds the response read, the output is bounded again
+// before being sent to the model. maxErrorBytes bounds the body in errors.
+const (
+	maxResponseBytes = 1 << 20
+	maxErrorBytes    = 1 << 10
+)
+
+var templateFuncs = template.FuncMap{
+	// json encodes a value in a JSON body.
+	"json": func(value interface{}) (string, error) {
+		result, err := json.Marshal(value)
+		return string(result), err
+	},
+}
+
+// HTTPExecutor is an executor defined in the configuration, calling an HTTP
+// endpoint.
+type HTTPExecutor struct {
+	config  configuration.HTTPExecutor
+	params  *params.Object
+	url     *template.Template
+	body    *template.Template
+	headers map[string]*template.Template
+	extract *jsonpath.JSONPath
+	write   bool
+}
+
+// NewHTTPExecutor compiles the templates of the executor.
+func NewHTTPExecutor(config configuration.HTTPExecutor) (*HTTPExecutor, error) {
+	if config.Name == "" {
+		return nil, fmt.Errorf("an HTTP executor has no name")
+	}
+	if config.URL == "" {
+		return nil, fmt.Errorf("HTTP executor %s: url is required", config.Name)
+	}
+	if config.Method == "" {
+		config.Method = http.MethodGet
+	}
+	config.Method = strings.ToUpper(config.Method)
+
+	object, err := params.NewObject(params.ConfigFields(config.Parameters))
+	if err != nil {
+		return nil, fmt.Errorf("HTTP executor %s: %w", config.Name, err)
+	}
+
+	executor := &HTTPExecutor{config: config, params: object, headers: make(map[string]*template.Template)}
+	if config.Write != nil {
+		executor.write = *config.Write
+	} else {
+		executor.write = config.Method != http.MethodGet && config.Method != http.MethodHead
+	}
+	if executor.url, err = parseTemplate("url", config.URL); err != nil {
+		return nil, fmt.Errorf("HTTP executor %s: %w", config.Name, err)
+	}
+	if executor.body, err = parseTemplate("body", config.Body); err != nil {
+		return nil, fmt.Errorf("HTTP executor %s: %w", config.Name, err)
+	}
+	for name, value := range config.Headers {
+		// The environment is expanded before the values of the model are
+		// rendered, a value cannot name an environment variable.
+		if value, err = expandEnv(value); err != nil {
+			return nil, fmt.Errorf("HTTP executor %s: header %s: %w", config.Name, name, err)
+		}
+		if executor.headers[name], err = parseTemplate(name, value); err != nil {
+			return nil, fmt.Errorf("HTTP executor %s: header %w", config.Name, err)
+		}
+	}
+
+	if config.Extract != "" {
+		executor.extract = jsonpath.New("extract").AllowMissingKeys(true)
+		if err := executor.extract.Parse(jsonPathTemplate(config.Extract)); err != nil {
+			return nil, fmt.Errorf("HTTP executor %s: invalid extract: %w", config.Name, err)
+		}
+	}
+
+	return executor, nil
+}
+
+func parseTemplate(name, text string) (*template.Template, error) {
+	t, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return t, nil
+}
+
+// jsonPathTemplate accepts $.a.b as well as the {.a.b} of kubectl.
+func jsonPathTemplate(path string) string {
+	if strings.HasPrefix(path, "{") {
+		return path
+	}
+	return fmt.Sprintf("{%s}", strings.TrimPrefix(path, "$"))
+}
+
+func (x *HTTPExecutor) GetName() string {
+	return x.config.Name
+}
+
+func (x *HTTPExecutor) GetDescription() string {
+	return x.config.Description
+}
+
+func (x *HTTPExecutor) GetParams() string {
+	return x.params.Schema()
+}
+
+func (x *HTTPExecutor) Validate(arguments string) error {
+	_, err := x.params.Decode(arguments)
+	return err
+}
+
+// Write returns true if the executor changes something.
+func (x *HTTPExecutor) Write() bool {
+	return x.write
+}
+
+func (x *HTTPExecutor) Run(ctx context.Context, e common.Executor, arguments string) common.Result {
+	values, err := x.params.Decode(arguments)
+	if err != nil {
+		return common.ErrorResult("", err)
+	}
+	// The optional parameters not given are empty in the templates.
+	for _, p := range x.config.Parameters {
+		if _, ok := values[p.Name]; !ok {
+			values[p.Name] = ""
+		}
+	}
+
+	request, err := x.request(ctx, values)
+	if err != nil {
+		return common.ErrorResult("unable to build the request", err)
+	}
+
+	if e.DryRun {
+		return common.TextResult(fmt.Sprintf("%s %s would be sent", request.Method, request.URL.Redacted()))
+	}
+
+	resp, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return common.ErrorResult(fmt.Sprintf("%s %s failed", request.Method, request.URL.Redacted()), err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return common.ErrorResult("unable to read the response", err)
+	}
+
+	if !x.allowed(resp.StatusCode) {
+		return common.ErrorResult(fmt.Sprintf("%s %s returned %s: %s", request.Method, request.URL.Redacted(), resp.Status, truncate(body, maxErrorBytes)), nil)
+	}
+
+	if x.extract == nil {
+		return common.TextResult(fmt.Sprintf("Status: %s\n%s", resp.Status, body))
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return common.ErrorResult("the response is not JSON, nothing can be extracted", err)
+	}
+	var extracted bytes.Buffer
+	if err := x.extract.Execute(&extracted, data); err != nil {
+		return common.ErrorResult("unable to extract "+x.config.Extract, err)
+	}
+	return common.TextResult(fmt.Sprintf("Status: %s\n%s", resp.Status, extracted.String()))
+}
+
+func (x *HTTPExecutor) request(ctx context.Context, values map[string]interface{}) (*http.Request, error) {
+	escaped := make(map[string]interface{}, len(values))
+	for name, value := range values {
+		escaped[name] = escape(value)
+	}
+
+	rawURL, err := render(x.url, escaped)
+	if err != nil {
+		return nil, err
+	}
+	body, err := render(x.body, values)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, x.config.Method, rawURL, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	for name, header := range x.headers {
+		value, err := render(header, values)
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set(name, value)
+	}
+	if body != "" && request.Header.Get("Content-Type") == "" {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	return request, nil
+}
+
+func render(t *template.Template, values map[string]interface{}) (string, error) {
+	var result strings.Builder
+	if err := t.Execute(&result, values); err != nil {
+		return "", err
+	}
+	return result.String(), nil
+}
+
+// escape formats a value for a path segment as well as a query parameter.
+func escape(value interface{}) string {
+	text := fmt.Sprint(value)
+	if number, ok := value.(float64); ok {
+		text = strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	return strings.ReplaceAll(url.QueryEscape(text), "+", "%20")
+}
+
+// expandEnv replaces the ${ENV} of a header, the secrets never go through
+// the model.
+func expandEnv(value string) (string, error) {
+	var missing []string
+	expanded := os.Expand(value, func(name string) string {
+		env, ok := os.LookupEnv(name)
+		if !ok {
+			missing = append(missing, name)
+		}
+		return env
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("environment variables %s are not set", strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
+
+func truncate(body []byte, maxBytes int) string {
+	if len(body) <= maxBytes {
+		return string(body)
+	}
+	return strings.ToValidUTF8(string(body[:maxBytes]), "") + "..."
+}
+
+func (x *HTTPExecutor) allowed(status int) bool {
+	if len(x.config.AllowedStatusCodes) == 0 {
+		return status >= 200 && status < 300
+	}
+	return slices.Contains(x.config.AllowedStatusCodes, status)
+}
@@ -0,0 +1,142 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/matthisholleville/ava/internal/configuration"
+	"github.com/matthisholleville/ava/pkg/common"
+)
+
+func TestHTTPExecutor(t *testing.T) {
+	t.Setenv("CATALOG_TOKEN", "secret")
+
+	var path, authorization, reason, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath() + "?" + r.URL.RawQuery
+		authorization = r.Header.Get("Authorization")
+		reason = r.Header.Get("X-Reason")
+		content, _ := io.ReadAll(r.Body)
+		body = string(content)
+		if strings.Contains(path, "missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":{"health":"degraded"}}`))
+	}))
+	defer server.Close()
+
+	executor, err := NewHTTPExecutor(configuration.HTTPExecutor{
+		Name:   "getServiceHealth",
+		Method: "post",
+		URL:    server.URL + "/services/{{ .service }}?replicas={{ .replicas }}",
+		Headers: map[string]string{
+			"Authorization": "Bearer ${CATALOG_TOKEN}",
+			"X-Reason":      "{{ .reason }}",
+		},
+		Body:    `{"reason":{{ json .reason }}}`,
+		Extract: "$.status.health",
+		Parameters: []configuration.Parameter{
+			{Name: "service", Required: true},
+			{Name: "replicas", Type: "integer"},
+			{Name: "reason"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to compile the executor: %v", err)
+	}
+
+	e := common.Executor{Context: context.Background()}
+	result := executor.Run(context.Background(), e, `{"service":"a b/c","replicas":3,"reason":"say \"hi\""}`)
+	if result.IsError || result.Content != "Status: 200 OK\ndegraded" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if path != "/services/a%20b%2Fc?replicas=3" {
+		t.Errorf("the values should be escaped in the URL, got %s", path)
+	}
+	if authorization != "Bearer secret" {
+		t.Errorf("the environment variable should be expanded, got %q", authorization)
+	}
+	if body != `{"reason":"say \"hi\""}` {
+		t.Errorf("unexpected body: %s", body)
+	}
+	if !executor.Write() {
+		t.Errorf("a POST executor should be a write executor by default")
+	}
+
+	if result := executor.Run(context.Background(), e, `{"service":"web","reason":"${CATALOG_TOKEN}"}`); result.IsError || reason != "${CATALOG_TOKEN}" {
+		t.Errorf("the environment should not be expanded in the values, got %q", reason)
+	}
+
+	if result := executor.Run(context.Background(), e, `{"service":"missing"}`); !result.IsError || !strings.Contains(result.Content, "404") {
+		t.Errorf("the status should be refused: %+v", result)
+	}
+
+	path = ""
+	e.DryRun = true
+	if result := executor.Run(context.Background(), e, `{"service":"web"}`); result.IsError || !strings.Contains(result.Content, "would be sent") || path != "" {
+		t.Errorf("nothing should be sent during a dry run: %+v", result)
+	}
+
+	if err := executor.Validate(`{"replicas":"three"}`); err == nil {
+		t.Errorf("the arguments should be validated")
+	}
+}
+
+func TestHTTPExecutorWrite(t *testing.T) {
+	readOnly := false
+	tests := []struct {
+		config configuration.HTTPExecutor
+		write  bool
+	}{
+		{configuration.HTTPExecutor{Name: "get", URL: "https://example.com"}, false},
+		{configuration.HTTPExecutor{Name: "head", Method: "head", URL: "https://example.com"}, false},
+		{configuration.HTTPExecutor{Name: "delete", Method: "DELETE", URL: "https://example.com"}, true},
+		{configuration.HTTPExecutor{Name: "search", Method: "POST", URL: "https://example.com", Write: &readOnly}, false},
+	}
+
+	for _, test := range tests {
+		executor, err := NewHTTPExecutor(test.config)
+		if err != nil {
+			t.Fatalf("failed to compile the executor: %v", err)
+		}
+		if executor.Write() != test.write {
+			t.Errorf("executor %s: expected write %t", test.config.Name, test.write)
+		}
+	}
+}
+
+func TestNewHTTPExecutorInvalid(t *testing.T) {
+	configs := []configuration.HTTPExecutor{
+		{URL: "https://example.com"},
+		{Name: "noURL"},
+		{Name: "badTemplate", URL: "https://example.com/{{ .service"},
+		{Name: "badExtract", URL: "https://example.com", Extract: "$.status["},
+		{Name: "missingEnv", URL: "https://example.com", Headers: map[string]string{"Authorization": "${AVA_MISSING_TOKEN}"}},
+		{Name: "badType", URL: "https://example.com", Parameters: []configuration.Parameter{{Name: "a", Type: "date"}}},
+	}
+
+	for _, config := range configs {
+		if _, err := NewHTTPExecutor(config); err == nil {
+			t.Errorf("executor %+v should be invalid", config)
+		}
+	}
+}
@@ -16,10 +16,15 @@ package kubernetes
 
 import (
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/kubectl/pkg/scheme"
 	"k8s.io/metrics/pkg/client/clientset/versioned"
@@ -37,6 +42,38 @@ func (c *Client) GetRestClient() rest.Interface {
 	return c.RestClient
 }
 
+// GetDynamicClient returns the client of any resource, e.g. of the custom
+// resources.
+func (c *Client) GetDynamicClient() (dynamic.Interface, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.DynamicClient == nil {
+		client, err := dynamic.NewForConfig(rest.CopyConfig(c.Config))
+		if err != nil {
+			return nil, err
+		}
+		c.DynamicClient = client
+	}
+	return c.DynamicClient, nil
+}
+
+// GetRESTMapper returns the mapper of the kinds, resources and short names
+// served by the cluster. The discovery is cached, and refreshed when a
+// resource is not found, e.g. after a CRD is installed.
+func (c *Client) GetRESTMapper() (meta.RESTMapper, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.RESTMapper == nil {
+		client, err := discovery.NewDiscoveryClientForConfig(rest.CopyConfig(c.Config))
+		if err != nil {
+			return nil, err
+		}
+		cached := memory.NewMemCacheClient(client)
+		c.RESTMapper = restmapper.NewShortcutExpander(restmapper.NewDeferredDiscoveryRESTMapper(cached), cached, nil)
+	}
+	return c.RESTMapper, nil
+}
+
 func (c *Client) GetMetricsClient() versioned.Interface {
 	return &c.MetricsClient
 }
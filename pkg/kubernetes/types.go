@@ -15,8 +15,12 @@
 package kubernetes
 
 import (
+	"sync"
+
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/metrics/pkg/client/clientset/versioned"
@@ -29,4 +33,9 @@ type Client struct {
 	RestClient         rest.Interface
 	Config             *rest.Config
 	ServerVersion      *version.Info
+	// DynamicClient and RESTMapper are built from Config when first used.
+	DynamicClient dynamic.Interface
+	RESTMapper    meta.RESTMapper
+
+	mu sync.Mutex
 }
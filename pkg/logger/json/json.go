@@ -17,6 +17,7 @@ package json
 import (
 	"os"
 
+	"github.com/matthisholleville/ava/pkg/redact"
 	"go.elastic.co/ecszap"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -27,23 +28,23 @@ type Json struct {
 }
 
 func (j *Json) Info(msg string, fields ...zap.Field) {
-	j.Logger.Info(msg, fields...)
+	j.Logger.Info(redact.String(msg), redactFields(fields)...)
 }
 
 func (j *Json) Debug(msg string, fields ...zap.Field) {
-	j.Logger.Debug(msg, fields...)
+	j.Logger.Debug(redact.String(msg), redactFields(fields)...)
 }
 
 func (j *Json) Warn(msg string, fields ...zap.Field) {
-	j.Logger.Warn(msg, fields...)
+	j.Logger.Warn(redact.String(msg), redactFields(fields)...)
 }
 
 func (j *Json) Error(msg string, fields ...zap.Field) {
-	j.Logger.Error(msg, fields...)
+	j.Logger.Error(redact.String(msg), redactFields(fields)...)
 }
 
 func (j *Json) Fatal(msg string, fields ...zap.Field) {
-	j.Logger.Fatal(msg, fields...)
+	j.Logger.Fatal(redact.String(msg), redactFields(fields)...)
 }
 
 func (j *Json) Init(logLevel string) error {
@@ -69,3 +70,20 @@ func (j *Json) Init(logLevel string) error {
 
 	return nil
 }
+
+// redactFields masks the secrets of the string and error fields.
+func redactFields(fields []zap.Field) []zap.Field {
+	redacted := make([]zap.Field, len(fields))
+	for i, field := range fields {
+		switch field.Type {
+		case zapcore.StringType:
+			field.String = redact.String(field.String)
+		case zapcore.ErrorType:
+			if err, ok := field.Interface.(error); ok {
+				field = zap.String(field.Key, redact.String(err.Error()))
+			}
+		}
+		redacted[i] = field
+	}
+	return redacted
+}
@@ -19,6 +19,7 @@ import (
 	"os"
 
 	"github.com/fatih/color"
+	"github.com/matthisholleville/ava/pkg/redact"
 	"go.uber.org/zap"
 )
 
@@ -42,31 +43,31 @@ func (r *Raw) shouldLog(level LogLevel) bool {
 
 func (r *Raw) Info(msg string, fields ...zap.Field) {
 	if r.shouldLog(InfoLevel) {
-		color.Green(msg)
+		color.Green(redact.String(msg))
 	}
 }
 
 func (r *Raw) Debug(msg string, fields ...zap.Field) {
 	if r.shouldLog(DebugLevel) {
-		color.Cyan(msg)
+		color.Cyan(redact.String(msg))
 	}
 }
 
 func (r *Raw) Warn(msg string, fields ...zap.Field) {
 	if r.shouldLog(WarnLevel) {
-		color.Yellow(msg)
+		color.Yellow(redact.String(msg))
 	}
 }
 
 func (r *Raw) Error(msg string, fields ...zap.Field) {
 	if r.shouldLog(ErrorLevel) {
-		color.Red(msg)
+		color.Red(redact.String(msg))
 	}
 }
 
 func (r *Raw) Fatal(msg string, fields ...zap.Field) {
 	if r.shouldLog(FatalLevel) {
-		color.Red(msg)
+		color.Red(redact.String(msg))
 		os.Exit(1)
 	}
 }
@@ -38,9 +38,50 @@ var (
 		[]string{"status", "type"},
 	)
 
+	TokenCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_token_counter", DEFAULT_NAMESPACE),
+			Help: "Number of tokens consumed by the analyses",
+		},
+		[]string{"model", "type"},
+	)
+	CostCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_cost_usd_counter", DEFAULT_NAMESPACE),
+			Help: "Estimated cost of the analyses in USD",
+		},
+		[]string{"model"},
+	)
+	ApprovalCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_approval_counter", DEFAULT_NAMESPACE),
+			Help: "Number of actions approved, rejected or expired",
+		},
+		[]string{"executor", "status"},
+	)
+	PolicyDeniedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_policy_denied_counter", DEFAULT_NAMESPACE),
+			Help: "Number of executor calls denied by the policies",
+		},
+		[]string{"executor", "rule"},
+	)
+	ExecutorBlockedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_executor_blocked_counter", DEFAULT_NAMESPACE),
+			Help: "Number of executor calls blocked by a limit or the kill switch",
+		},
+		[]string{"executor", "reason"},
+	)
+
 	CustomCounterMetrics = []*prometheus.CounterVec{
 		ExecutorCounter,
 		ChatCounter,
+		TokenCounter,
+		CostCounter,
+		ApprovalCounter,
+		PolicyDeniedCounter,
+		ExecutorBlockedCounter,
 	}
 )
 
@@ -0,0 +1,216 @@
+// Copyright © 2025 Ava AI.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redact masks secrets in the executor outputs, the persisted chats
+// and the logs.
+package redact
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const MASK = "[REDACTED]"
+
+const lastAppliedConfiguration = "kubectl.kubernetes.io/last-applied-configuration"
+
+var (
+	// sensitiveKey matches the names of keys and environment variables
+	// holding secrets, e.g. DB_PASSWORD or apiKey.
+	sensitiveKey = regexp.MustCompile(`(?i)^(.*[_.-])?(password|passwd|pwd|secret|token|api[_-]?key|apikey|access[_-]?key|secret[_-]?key|private[_-]?key|credentials?)$`)
+
+	// builtinPatterns match known token formats. When a pattern has groups,
+	// only the first one is masked.
+	builtinPatterns = []string{
+		// Bearer tokens
+		`(?i)\bbearer\s+([A-Za-z0-9\-._~+/]+=*)`,
+		// AWS access key IDs
+		`\b((?:AKIA|ASIA)[0-9A-Z]{16})\b`,
+		// JSON Web Tokens
+		`\b(eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+)`,
+		// Private keys
+		`(-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----)`,
+		// Secrets assigned to a key, e.g. DB_PASSWORD=... or "aws_secret_access_key": "..."
+		`(?i)[A-Za-z0-9_.-]*(?:password|passwd|secret|token|api[_-]?key|access[_-]?key|credentials?)["']?\s*[:=]\s*["']?([^\s"',;&}]+)`,
+	}
+
+	mu              sync.RWMutex
+	defaultRedactor = MustNew(nil)
+)
+
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+// New returns a redactor masking the built-in patterns and the given ones.
+func New(patterns []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, pattern := range append(append([]string{}, builtinPatterns...), patterns...) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+func MustNew(patterns []string) *Redactor {
+	r, err := New(patterns)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Configure adds patterns to the redactor used by String and Output.
+func Configure(patterns []string) error {
+	r, err := New(patterns)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	defaultRedactor = r
+	return nil
+}
+
+func get() *Redactor {
+	mu.RLock()
+	defer mu.RUnlock()
+	return defaultRedactor
+}
+
+// String masks the secrets of a text with the configured redactor.
+func String(s string) string {
+	return get().String(s)
+}
+
+// Output masks the secrets of an executor output with the configured redactor.
+func Output(s string) string {
+	return get().Output(s)
+}
+
+// String masks the matches of the patterns.
+func (r *Redactor) String(s string) string {
+	for _, re := range r.patterns {
+		matches := re.FindAllStringSubmatchIndex(s, -1)
+		if matches == nil {
+			continue
+		}
+
+		var result strings.Builder
+		last := 0
+		for _, match := range matches {
+			start, end := match[0], match[1]
+			if len(match) >= 4 && match[2] >= 0 {
+				start, end = match[2], match[3]
+			}
+			result.WriteString(s[last:start])
+			result.WriteString(MASK)
+			last = end
+		}
+		result.WriteString(s[last:])
+		s = result.String()
+	}
+	return s
+}
+
+// Output masks the data of Secrets, the sensitive environment variables and
+// keys of JSON outputs, then the patterns of every string.
+func (r *Redactor) Output(s string) string {
+	var value interface{}
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return r.String(s)
+	}
+
+	result, err := json.Marshal(r.value(value))
+	if err != nil {
+		return r.String(s)
+	}
+	return string(result)
+}
+
+func (r *Redactor) value(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return r.object(v)
+	case []interface{}:
+		for i := range v {
+			v[i] = r.value(v[i])
+		}
+		return v
+	case string:
+		return r.String(v)
+	default:
+		return v
+	}
+}
+
+func (r *Redactor) object(object map[string]interface{}) map[string]interface{} {
+	if isSecret(object) {
+		for _, key := range []string{"data", "stringData"} {
+			data, _ := object[key].(map[string]interface{})
+			for name := range data {
+				data[name] = MASK
+			}
+		}
+		// The last applied configuration contains the data of the Secret.
+		if metadata, ok := object["metadata"].(map[string]interface{}); ok {
+			if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+				if _, ok := annotations[lastAppliedConfiguration]; ok {
+					annotations[lastAppliedConfiguration] = MASK
+				}
+			}
+		}
+	}
+
+	// Environment variables of the containers
+	if name, ok := object["name"].(string); ok && sensitiveKey.MatchString(name) {
+		if _, ok := object["value"].(string); ok {
+			object["value"] = MASK
+		}
+	}
+
+	for key, value := range object {
+		if _, ok := value.(string); ok && sensitiveKey.MatchString(key) {
+			object[key] = MASK
+			continue
+		}
+		object[key] = r.value(value)
+	}
+	return object
+}
+
+// isSecret returns true if the object is a Kubernetes Secret. Objects
+// returned by the typed clients do not have a kind.
+func isSecret(object map[string]interface{}) bool {
+	if kind, ok := object["kind"].(string); ok {
+		return kind == "Secret"
+	}
+
+	if _, ok := object["metadata"].(map[string]interface{}); !ok {
+		return false
+	}
+	if _, ok := object["type"].(string); !ok {
+		return false
+	}
+	_, hasData := object["data"].(map[string]interface{})
+	_, hasStringData := object["stringData"].(map[string]interface{})
+	return hasData || hasStringData
+}